@@ -2,52 +2,418 @@ package application
 
 import (
 	"code/core"
+	"code/helpers"
 	"context"
 	"fmt"
 )
 
-func Answer(ctx context.Context, prompt, phoneNumber string, chunkStore core.ChunksDataStore, agent core.Agent, indexer core.SearchIndex, vectorizer core.Vectorizer, comms core.Comms, logger core.Logger) error {
+// replies to recipients that are over the rate limit
+const (
+	rateLimitedMessage = "You have asked a lot of questions in a short time, please try again in a little while."
+	dailyBudgetMessage = "We have answered as many questions as we can today, please try again tomorrow."
+)
+
+// most chunks referenced by the retrieved chunks, and most definitions of the terms they use, added to the
+// prompt, so a reference to a long section or a chapter of common terms does not crowd out the retrieved chunks
+const (
+	maxReferencedChunks = 5
+	maxDefinitionChunks = 5
+)
+
+func Answer(ctx context.Context, prompt string, recipient core.Recipient, citationPolicy core.CitationPolicy, smsOptions core.SMSOptions, chunkStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, definitionStore core.DefinitionStore, conversationStore core.ConversationStore, rateLimiter core.RateLimiter, agent core.Agent, indexer core.SearchIndex, vectorizer core.Vectorizer, comms core.Comms, logger core.Logger) error {
+	_, err := AnswerWithResult(ctx, prompt, recipient, citationPolicy, smsOptions, chunkStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, indexer, vectorizer, comms, logger)
+	return err
+}
+
+// AnswerWithResult is Answer returning the answer sent and the chunks it was generated from, the result is
+// empty when the recipient is rate limited.
+func AnswerWithResult(ctx context.Context, prompt string, recipient core.Recipient, citationPolicy core.CitationPolicy, smsOptions core.SMSOptions, chunkStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, definitionStore core.DefinitionStore, conversationStore core.ConversationStore, rateLimiter core.RateLimiter, agent core.Agent, indexer core.SearchIndex, vectorizer core.Vectorizer, comms core.Comms, logger core.Logger) (core.AskResult, error) {
 
 	logger.Info("received prompt='%s'", prompt)
 
-	logger.Info("vectorize prompt")
-	promptVD, err := vectorizer.Vectorize(ctx, prompt)
+	isRateLimited, err := checkRateLimit(ctx, recipient, rateLimiter, comms, logger)
+	if err != nil || isRateLimited {
+		return core.AskResult{}, err
+	}
+
+	logger.Info("getting conversation with recipient=%s", recipient.Key())
+	history, err := conversationStore.GetConversation(ctx, recipient.Key())
+	if err != nil {
+		return core.AskResult{}, fmt.Errorf("error getting conversation: %v", err)
+	}
+
+	result, err := generateAnswer(ctx, prompt, history, citationPolicy, chunkStore, referenceGraph, definitionStore, agent, indexer, vectorizer, logger)
+	if err != nil {
+		return core.AskResult{}, err
+	}
+
+	message := helpers.FormatAnswer(result.Answer)
+	logger.Info("sending to recipient=%s the answer=%s", recipient.Key(), message)
+	if err = sendReply(ctx, recipient, message, smsOptions, conversationStore, comms, logger); err != nil {
+		return core.AskResult{}, fmt.Errorf("error on send reply: %v", err)
+	}
+
+	logger.Info("adding turn to conversation with recipient=%s", recipient.Key())
+	turn := core.ConversationTurn{Prompt: prompt, Answer: result.Answer.Text, ChunkIDs: getRetrievedChunkIDs(result.Chunks)}
+	if err = conversationStore.AddConversationTurn(ctx, recipient.Key(), turn); err != nil {
+		return core.AskResult{}, fmt.Errorf("error adding conversation turn: %v", err)
+	}
+
+	logger.Info("answered prompt=%s", prompt)
+	return result, nil
+}
+
+// generateAnswer retrieves the chunks for the prompt, the ones cited in it or else the best matching ones
+// along with the ones of the previous turn, adds the chunks they reference and the definitions of the terms
+// they use, and asks the agent to answer with them.
+func generateAnswer(ctx context.Context, prompt string, history []core.ConversationTurn, citationPolicy core.CitationPolicy, chunkStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, definitionStore core.DefinitionStore, agent core.Agent, indexer core.SearchIndex, vectorizer core.Vectorizer, logger core.Logger) (core.AskResult, error) {
+	logger.Info("looking up statutes cited in the prompt")
+	chunks, err := getCitedChunks(ctx, getCitedChunkIDs(prompt, history), chunkStore, logger)
+	if err != nil {
+		return core.AskResult{}, fmt.Errorf("error getting cited chunks: %v", err)
+	}
+	scoredChunks := make([]core.ScoredChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		scoredChunks = append(scoredChunks, core.ScoredChunk{Chunk: chunk, IsCited: true})
+	}
+
+	if len(scoredChunks) > 0 {
+		logger.Info("found %d cited chunks, skipping search", len(scoredChunks))
+	} else {
+		scoredChunks, err = searchScoredChunks(ctx, getSearchText(prompt, history), chunkStore, indexer, vectorizer, logger)
+		if err != nil {
+			return core.AskResult{}, err
+		}
+		for _, scoredChunk := range scoredChunks {
+			chunks = append(chunks, scoredChunk.Chunk)
+		}
+		previousChunks, err := getPreviousChunks(ctx, chunks, history, chunkStore, logger)
+		if err != nil {
+			return core.AskResult{}, err
+		}
+		// chunks of the previous turn were not found by this search, so they have no score
+		for _, previousChunk := range previousChunks {
+			scoredChunks = append(scoredChunks, core.ScoredChunk{Chunk: previousChunk, IsContext: true})
+		}
+		chunks = append(chunks, previousChunks...)
+	}
+
+	referencedChunks, err := getReferencedChunks(ctx, chunks, chunkStore, referenceGraph, logger)
+	if err != nil {
+		return core.AskResult{}, err
+	}
+	// referenced chunks were not found by the search either, so they have no score
+	for _, referencedChunk := range referencedChunks {
+		scoredChunks = append(scoredChunks, core.ScoredChunk{Chunk: referencedChunk, IsContext: true})
+	}
+	chunks = append(chunks, referencedChunks...)
+
+	definitionChunks, err := getDefinitionChunks(ctx, chunks, chunkStore, definitionStore, logger)
+	if err != nil {
+		return core.AskResult{}, err
+	}
+	for _, definitionChunk := range definitionChunks {
+		scoredChunks = append(scoredChunks, core.ScoredChunk{Chunk: definitionChunk, IsContext: true})
+	}
+	chunks = append(chunks, definitionChunks...)
+
+	logger.Info("ask agent with prompt, %d previous turns and chunks", len(history))
+	answer, err := agent.AskWithChunks(ctx, prompt, history, chunks)
+	if err != nil {
+		return core.AskResult{}, fmt.Errorf("error asking agent prompt with chunks: %v", err)
+	}
+	logger.Info("agent answered with stopReason=%s, inputTokens=%d, outputTokens=%d", answer.StopReason, answer.InputTokens, answer.OutputTokens)
+	if answer.StopReason == core.StopReasonMaxTokens {
+		logger.Warn("answer was truncated at the maximum number of tokens")
+	}
+
+	logger.Info("verify citations of the answer")
+	answer, err = VerifyCitations(ctx, prompt, history, answer, chunks, citationPolicy, chunkStore, agent, logger)
+	if err != nil {
+		return core.AskResult{}, fmt.Errorf("error on verifying citations: %v", err)
+	}
+	return core.AskResult{Answer: answer, Chunks: scoredChunks}, nil
+}
+
+// getCitedChunkIDs returns the chunk IDs cited in the prompt. A follow-up citing only a subdivision or a subpart,
+// e.g. "what about subdivision 2?", refers to the sections or parts of the previous turn.
+func getCitedChunkIDs(prompt string, history []core.ConversationTurn) []string {
+	chunkIDs := make([]string, 0)
+	for _, citation := range helpers.ParsePromptCitations(prompt) {
+		chunkIDs = append(chunkIDs, citation.ChunkID)
+	}
+	if len(chunkIDs) > 0 || len(history) == 0 {
+		return chunkIDs
+	}
+	subdivisions := helpers.ParseSubdivisionReferences(prompt)
+	seenSections := make(map[string]bool)
+	for _, previousChunkID := range history[len(history)-1].ChunkIDs {
+		chapter, section, _ := helpers.SplitChunkID(previousChunkID)
+		statute := chapter + "." + section
+		if seenSections[statute] {
+			continue
+		}
+		seenSections[statute] = true
+		for _, subdivision := range subdivisions {
+			chunkIDs = append(chunkIDs, statute+"."+subdivision)
+		}
+	}
+	return chunkIDs
+}
+
+// getSearchText includes the previous prompt so that follow-ups like "and for a boat?" keep their context.
+func getSearchText(prompt string, history []core.ConversationTurn) string {
+	if len(history) == 0 {
+		return prompt
+	}
+	return history[len(history)-1].Prompt + "\n" + prompt
+}
+
+// getPreviousChunks returns the chunks of the previous turn that were not found again.
+func getPreviousChunks(ctx context.Context, chunks []core.Chunk, history []core.ConversationTurn, chunkStore core.ChunksDataStore, logger core.Logger) ([]core.Chunk, error) {
+	if len(history) == 0 {
+		return nil, nil
+	}
+	hasChunkID := make(map[string]bool)
+	for _, chunk := range chunks {
+		hasChunkID[chunk.ID] = true
+	}
+	previousChunkIDs := make([]string, 0)
+	for _, chunkID := range history[len(history)-1].ChunkIDs {
+		if !hasChunkID[chunkID] {
+			previousChunkIDs = append(previousChunkIDs, chunkID)
+		}
+	}
+	previousChunks, err := getChunks(ctx, previousChunkIDs, chunkStore, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error getting chunks of the previous turn: %v", err)
+	}
+	return previousChunks, nil
+}
+
+// getReferencedChunks returns the chunks referenced by the chunks that are not among them, in the order they
+// are referenced and at most maxReferencedChunks of them. A referenced section is looked up like a citation.
+func getReferencedChunks(ctx context.Context, chunks []core.Chunk, chunkStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, logger core.Logger) ([]core.Chunk, error) {
+	hasChunkID := make(map[string]bool)
+	for _, chunk := range chunks {
+		hasChunkID[chunk.ID] = true
+	}
+	isReferenced := make(map[string]bool)
+	referencedChunkIDs := make([]string, 0)
+	for _, chunk := range chunks {
+		chunkReferences, err := referenceGraph.GetReferences(ctx, chunk.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting references of chunkID=%s: %v", chunk.ID, err)
+		}
+		for _, referencedChunkID := range chunkReferences {
+			if !hasChunkID[referencedChunkID] && !isReferenced[referencedChunkID] {
+				isReferenced[referencedChunkID] = true
+				referencedChunkIDs = append(referencedChunkIDs, referencedChunkID)
+			}
+		}
+	}
+	if len(referencedChunkIDs) == 0 {
+		return nil, nil
+	}
+
+	logger.Info("looking up chunks referenced by the retrieved chunks, referencedChunkIDs=%v", referencedChunkIDs)
+	storedChunkIDs, err := listCitedChunkIDs(ctx, referencedChunkIDs, chunkStore, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error listing referenced chunk ids: %v", err)
+	}
+	newChunkIDs := make([]string, 0, maxReferencedChunks)
+	for _, storedChunkID := range storedChunkIDs {
+		// a referenced section lists all its subdivisions, including the ones already retrieved or referenced
+		if hasChunkID[storedChunkID] {
+			continue
+		}
+		if len(newChunkIDs) == maxReferencedChunks {
+			logger.Info("dropping referenced chunks past the first %d", maxReferencedChunks)
+			break
+		}
+		hasChunkID[storedChunkID] = true
+		newChunkIDs = append(newChunkIDs, storedChunkID)
+	}
+	return getChunks(ctx, newChunkIDs, chunkStore, logger)
+}
+
+// getDefinitionChunks returns the chunks defining the terms used by the chunks, from the definitions of the
+// chunk's chapter that apply to its section, that are not among them. At most maxDefinitionChunks are returned.
+func getDefinitionChunks(ctx context.Context, chunks []core.Chunk, chunkStore core.ChunksDataStore, definitionStore core.DefinitionStore, logger core.Logger) ([]core.Chunk, error) {
+	hasChunkID := make(map[string]bool)
+	for _, chunk := range chunks {
+		hasChunkID[chunk.ID] = true
+	}
+	chapterDefinitions := make(map[string][]core.Definition)
+	sectionTerms := make(map[string][]string)
+	sectionDefinitions := make(map[string]map[string]string)
+	definitionChunkIDs := make([]string, 0, maxDefinitionChunks)
+	for _, chunk := range chunks {
+		chapter, section, _ := helpers.SplitChunkID(chunk.ID)
+		if _, ok := chapterDefinitions[chapter]; !ok {
+			definitions, err := definitionStore.GetDefinitions(ctx, chapter)
+			if err != nil {
+				return nil, fmt.Errorf("error getting definitions of chapter=%s: %v", chapter, err)
+			}
+			chapterDefinitions[chapter] = definitions
+		}
+		statute := chapter + "." + section
+		definitions, ok := sectionDefinitions[statute]
+		if !ok {
+			definitions = helpers.SectionDefinitions(chapterDefinitions[chapter], section)
+			sectionDefinitions[statute] = definitions
+			terms := make([]string, 0, len(definitions))
+			for term := range definitions {
+				terms = append(terms, term)
+			}
+			sectionTerms[statute] = terms
+		}
+		for _, term := range helpers.FindDefinedTerms(chunk.Body, sectionTerms[statute]) {
+			definitionChunkID := definitions[term]
+			if hasChunkID[definitionChunkID] {
+				continue
+			}
+			if len(definitionChunkIDs) == maxDefinitionChunks {
+				logger.Info("dropping definitions past the first %d", maxDefinitionChunks)
+				return getStoredChunks(ctx, definitionChunkIDs, chunkStore, logger)
+			}
+			logger.Info("adding definition of term=%s in chunkID=%s", term, definitionChunkID)
+			hasChunkID[definitionChunkID] = true
+			definitionChunkIDs = append(definitionChunkIDs, definitionChunkID)
+		}
+	}
+	return getStoredChunks(ctx, definitionChunkIDs, chunkStore, logger)
+}
+
+// getStoredChunks is getChunks skipping the chunk IDs that are no longer stored, e.g. definitions of a
+// repealed section.
+func getStoredChunks(ctx context.Context, chunkIDs []string, chunkStore core.ChunksDataStore, logger core.Logger) ([]core.Chunk, error) {
+	storedChunkIDs, err := listCitedChunkIDs(ctx, chunkIDs, chunkStore, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error listing stored chunk ids: %v", err)
+	}
+	return getChunks(ctx, storedChunkIDs, chunkStore, logger)
+}
+
+// getRetrievedChunkIDs returns the IDs of the chunks cited or found by the search, leaving out the chunks added
+// as context so the chunks of a conversation don't pile up turn after turn.
+func getRetrievedChunkIDs(scoredChunks []core.ScoredChunk) []string {
+	chunkIDs := make([]string, 0, len(scoredChunks))
+	for _, scoredChunk := range scoredChunks {
+		if scoredChunk.IsContext {
+			continue
+		}
+		chunkIDs = append(chunkIDs, scoredChunk.ID)
+	}
+	return chunkIDs
+}
+
+func searchScoredChunks(ctx context.Context, searchText string, chunkStore core.ChunksDataStore, indexer core.SearchIndex, vectorizer core.Vectorizer, logger core.Logger) ([]core.ScoredChunk, error) {
+	logger.Info("vectorize search text")
+	promptVD, err := vectorizer.Vectorize(ctx, searchText)
 	if err != nil {
-		return fmt.Errorf("error vectorizing prompt: %v", err)
+		return nil, fmt.Errorf("error vectorizing prompt: %v", err)
 	}
 
 	logger.Info("search index for matching chunk ids")
-	chunkIDs, err := indexer.FindMatchingChunkIDs(ctx, promptVD)
+	scoredChunkIDs, err := indexer.FindScoredChunkIDs(ctx, promptVD)
 	if err != nil {
-		return fmt.Errorf("error finding matching chunk ids: %v", err)
+		return nil, fmt.Errorf("error finding matching chunk ids: %v", err)
 	}
 
-	if len(chunkIDs) == 0 {
+	if len(scoredChunkIDs) == 0 {
 		logger.Info("no matching chunks found")
 	} else {
 		logger.Info("getting chunks corresponding to matching chunk ids")
 	}
+	scoredChunks := make([]core.ScoredChunk, 0, len(scoredChunkIDs))
+	for _, scoredChunkID := range scoredChunkIDs {
+		chunks, err := getChunks(ctx, []string{scoredChunkID.ChunkID}, chunkStore, logger)
+		if err != nil {
+			return nil, err
+		}
+		scoredChunks = append(scoredChunks, core.ScoredChunk{Chunk: chunks[0], Score: scoredChunkID.Score})
+	}
+	return scoredChunks, nil
+}
+
+// getCitedChunks returns the chunks of the cited chunk IDs, all subdivisions of a section are returned
+// when the citation has no subdivision. Citations that match no chunk are ignored.
+func getCitedChunks(ctx context.Context, citedChunkIDs []string, chunkStore core.ChunksDataStore, logger core.Logger) ([]core.Chunk, error) {
+	chunkIDs, err := listCitedChunkIDs(ctx, citedChunkIDs, chunkStore, logger)
+	if err != nil {
+		return nil, err
+	}
+	return getChunks(ctx, chunkIDs, chunkStore, logger)
+}
+
+// listCitedChunkIDs returns the IDs of the stored chunks of the cited chunk IDs, see getCitedChunks.
+func listCitedChunkIDs(ctx context.Context, citedChunkIDs []string, chunkStore core.ChunksDataStore, logger core.Logger) ([]string, error) {
+	chunkIDs := make([]string, 0)
+	for _, citedChunkID := range citedChunkIDs {
+		_, _, subdivision := helpers.SplitChunkID(citedChunkID)
+		prefix := citedChunkID
+		if len(subdivision) == 0 {
+			prefix = prefix + "."
+		}
+		storedChunkIDs, err := chunkStore.ListChunkIDs(ctx, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("error listing chunk ids with prefix=%s: %v", prefix, err)
+		}
+		matchingChunkIDs := make([]string, 0)
+		for _, storedChunkID := range storedChunkIDs {
+			// the prefix "609.52.2" also lists "609.52.2a", keep only the cited subdivision
+			if len(subdivision) > 0 && storedChunkID != citedChunkID {
+				continue
+			}
+			matchingChunkIDs = append(matchingChunkIDs, storedChunkID)
+		}
+		if len(matchingChunkIDs) == 0 {
+			logger.Info("no chunks found for cited chunkID=%s", citedChunkID)
+			continue
+		}
+		helpers.SortChunkIDs(matchingChunkIDs)
+		chunkIDs = append(chunkIDs, matchingChunkIDs...)
+	}
+	return chunkIDs, nil
+}
+
+func getChunks(ctx context.Context, chunkIDs []string, chunkStore core.ChunksDataStore, logger core.Logger) ([]core.Chunk, error) {
 	var chunks = make([]core.Chunk, 0, len(chunkIDs))
 	for _, chunkID := range chunkIDs {
 		logger.Info("getting chunk with chunkID=%s", chunkID)
 		chunk, err := chunkStore.GetChunk(ctx, chunkID)
 		if err != nil {
-			return fmt.Errorf("error getting chunk for chunkID=%s: %v", chunkID, err)
+			return nil, fmt.Errorf("error getting chunk for chunkID=%s: %v", chunkID, err)
 		}
 		chunks = append(chunks, chunk)
 	}
+	return chunks, nil
+}
 
-	logger.Info("ask agent with prompt and chunks")
-	answer, err := agent.AskWithChunks(ctx, prompt, chunks)
+// sendRateLimited tells the recipient about the limit once, later questions are ignored until it is allowed again.
+// checkRateLimit takes a reply from the recipient's rate limit, it returns true if the recipient is rate limited
+// and was told so.
+func checkRateLimit(ctx context.Context, recipient core.Recipient, rateLimiter core.RateLimiter, comms core.Comms, logger core.Logger) (bool, error) {
+	logger.Info("checking rate limit of recipient=%s", recipient.RateLimitKey())
+	decision, err := rateLimiter.Allow(ctx, recipient.RateLimitKey())
 	if err != nil {
-		return fmt.Errorf("error asking agent prompt with chunks: %v", err)
+		return false, fmt.Errorf("error checking rate limit: %v", err)
 	}
-
-	logger.Info("sending to phoneNumber=%s the answer=%s", phoneNumber, answer)
-	if err = comms.SendMessage(ctx, phoneNumber, answer); err != nil {
-		return fmt.Errorf("error on send message: %v", err)
+	if decision.IsAllowed {
+		return false, nil
 	}
+	return true, sendRateLimited(ctx, recipient, decision, comms, logger)
+}
 
-	logger.Info("answered prompt=%s", prompt)
-	return nil
+func sendRateLimited(ctx context.Context, recipient core.Recipient, decision core.RateLimitDecision, comms core.Comms, logger core.Logger) error {
+	logger.Warn("recipient=%s is rate limited, isDailyBudget=%v", recipient.Key(), decision.IsDailyBudget)
+	if !decision.IsFirstRejection {
+		return nil
+	}
+	message := rateLimitedMessage
+	if decision.IsDailyBudget {
+		message = dailyBudgetMessage
+	}
+	return sendMessage(ctx, recipient, message, comms)
 }
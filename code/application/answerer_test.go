@@ -0,0 +1,468 @@
+package application_test
+
+import (
+	"code/application"
+	"code/core"
+	"code/helpers"
+	"code/infrastructure/loggers"
+	"code/infrastructure/memory"
+	"code/infrastructure/scrapers"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generous enough that answering tests are never limited
+var testRateLimits = core.RateLimits{Burst: 100, PerHour: 100}
+
+var recipient = core.Recipient{Channel: core.ChannelSMS, Identity: phoneNumber}
+
+const (
+	rawPathPrefix        = "raw"
+	chunkPathPrefix      = "chunk"
+	phoneNumber          = "15555550100"
+	sectionWithSubdsPath = "../infrastructure/scrapers/test_data/section_with_subsections.html"
+	definitionsPath      = "../infrastructure/scrapers/test_data/section_with_definitions.html"
+	rulePath             = "../infrastructure/scrapers/test_data/rule_with_subparts.html"
+)
+
+func TestAnswer(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	t.Run("test scrape, index and answer offline", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		urlQueue, _ := memory.InitializeQueue(0)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(testRateLimits)
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		scraper, _ := scrapers.InitializeScraper()
+
+		page, err := os.Open(sectionWithSubdsPath)
+		assert.NoError(err, "error on opening test page: %v", err)
+		defer page.Close()
+		dataStore.PutTextFile(ctx, "page.html", page)
+
+		err = application.ScrapeRawPage(ctx, dataStore.GetRawObjectKey("page.html"), dataStore, dataStore, referenceGraph, definitionStore, urlQueue, scraper, logger)
+		assert.NoError(err, "error on scrape raw page: %v", err)
+		references, err := referenceGraph.GetReferences(ctx, "1.142.1")
+		assert.NoError(err, "error on get references: %v", err)
+		assert.Empty(references, "the section links to no other statutes")
+		for _, chunkID := range []string{"1.142.1", "1.142.2"} {
+			err = application.Index(ctx, chunkID, dataStore, vectorizer, searchIndex, logger)
+			assert.NoError(err, "error on index: %v", err)
+		}
+
+		err = application.Answer(ctx, "Where is the photograph of the lady slipper preserved?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer: %v", err)
+		messages := comms.Messages()
+		if assert.Len(messages, 1, "one answer should be sent") {
+			assert.Equal(recipient, messages[0].To, "answer should be sent to the recipient")
+			assert.Contains(messages[0].Body, "§ 1.142, subd. 2", "answer should cite the photograph subdivision")
+		}
+
+		chunkIDs, err := dataStore.ListChunkIDs(ctx, "1.142.")
+		assert.NoError(err, "error on list chunk ids: %v", err)
+		assert.Equal([]string{"1.142.1", "1.142.2"}, chunkIDs, "both subdivisions should be listed")
+
+		err = application.Answer(ctx, "what does 1.142 subd 1 say?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer: %v", err)
+		messages = comms.Messages()
+		if assert.Len(messages, 2, "a second answer should be sent") {
+			assert.Contains(messages[1].Body, "§ 1.142, subd. 1:", "answer should quote the cited subdivision")
+			assert.NotContains(messages[1].Body, "§ 1.142, subd. 2:", "answer should not quote other subdivisions")
+		}
+
+		err = application.Answer(ctx, "§ 1.142", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer: %v", err)
+		messages = comms.Messages()
+		if assert.Len(messages, 3, "a third answer should be sent") {
+			assert.Contains(messages[2].Body, "§ 1.142, subd. 1:", "answer should quote every subdivision of the section")
+			assert.Contains(messages[2].Body, "§ 1.142, subd. 2:", "answer should quote every subdivision of the section")
+		}
+
+		err = application.Answer(ctx, "what about subdivision 2?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer: %v", err)
+		messages = comms.Messages()
+		if assert.Len(messages, 4, "a fourth answer should be sent") {
+			assert.Contains(messages[3].Body, "§ 1.142, subd. 2:", "follow-up should refer to the section of the previous turn")
+			assert.NotContains(messages[3].Body, "§ 1.142, subd. 1:", "follow-up should only quote the cited subdivision")
+		}
+		turns, _ := conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Len(turns, 4, "every answer should be added to the conversation")
+
+		result, err := application.Ask(ctx, "Where is the photograph of the lady slipper preserved?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		assert.Contains(helpers.FormatAnswer(result.Answer), "§ 1.142, subd. 2", "ask should answer like the answerer")
+		if assert.Len(result.Chunks, 1, "the searched chunk should be returned") {
+			assert.Equal("1.142.2", result.Chunks[0].ID)
+			assert.False(result.Chunks[0].IsCited)
+			assert.Greater(result.Chunks[0].Score, 0.0, "searched chunks should be scored")
+		}
+		result, err = application.Ask(ctx, "what does section 1.142 say?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		if assert.Len(result.Chunks, 2, "every subdivision of the cited section should be returned") {
+			assert.True(result.Chunks[0].IsCited && result.Chunks[1].IsCited, "cited chunks should be marked")
+		}
+		assert.Len(comms.Messages(), 4, "ask should not send messages")
+
+		result, err = application.AnswerWithResult(ctx, "and the photograph?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer with result: %v", err)
+		messages = comms.Messages()
+		if assert.Len(messages, 5, "the answer should be sent") {
+			assert.Equal(helpers.FormatAnswer(result.Answer), messages[4].Body, "the answer sent should be returned")
+		}
+		if assert.Len(result.Chunks, 1, "the searched chunk should be returned, the previous turn's chunk is found again") {
+			assert.Equal("1.142.2", result.Chunks[0].ID)
+			assert.Greater(result.Chunks[0].Score, 0.0, "searched chunks should be scored")
+		}
+
+		result, err = application.AnswerWithResult(ctx, "which is the official flower?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer with result: %v", err)
+		if assert.Len(result.Chunks, 2, "the previous turn's chunk should be added to the searched chunk") {
+			assert.Equal("1.142.1", result.Chunks[0].ID)
+			assert.True(result.Chunks[1].IsContext, "the previous turn's chunk should be marked context")
+		}
+		turns, _ = conversationStore.GetConversation(ctx, phoneNumber)
+		if assert.NotEmpty(turns) {
+			assert.Equal([]string{"1.142.1"}, turns[len(turns)-1].ChunkIDs, "only the searched chunks should be kept in the conversation")
+		}
+
+		scoredChunks, err := application.Search(ctx, "photograph of the lady slipper", dataStore, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on search: %v", err)
+		if assert.Len(scoredChunks, 1) {
+			assert.Equal("1.142.2", scoredChunks[0].ID)
+		}
+
+		questions := []core.EvalQuestion{
+			{Question: "Where is the photograph of the lady slipper preserved?", ExpectedChunkIDs: []string{"1.142.2"}},
+			{Question: "Where is the photograph of the lady slipper preserved?", ExpectedChunkIDs: []string{"1.142"}},
+			{Question: "Where is the photograph of the lady slipper preserved?", ExpectedChunkIDs: []string{"1.142.1"}},
+		}
+		report, err := application.Evaluate(ctx, questions, []int{1, 5}, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on evaluate: %v", err)
+		assert.Equal(3, report.QuestionCount)
+		assert.InDelta(2.0/3, report.RecallAtK[1], 1e-9, "the section and its subdivision should be found, the other subdivision not")
+		assert.InDelta(2.0/3, report.NDCGAtK[5], 1e-9)
+		assert.InDelta(2.0/3, report.MRR, 1e-9)
+		if assert.Len(report.Results, 3) {
+			assert.Equal([]string{"1.142.2"}, report.Results[0].FoundChunkIDs)
+			assert.Equal(1, report.Results[0].Rank)
+			assert.Equal(0, report.Results[2].Rank, "a question without relevant chunks found should have no rank")
+		}
+		_, err = application.Evaluate(ctx, []core.EvalQuestion{{Question: "no expected chunks"}}, []int{1}, searchIndex, vectorizer, logger)
+		assert.Error(err, "questions without expected chunk ids should fail")
+
+		chunks, err := application.GetStatute(ctx, "1", "142", dataStore, logger)
+		assert.NoError(err, "error on get statute: %v", err)
+		assert.Equal([]string{"1.142.1", "1.142.2"}, []string{chunks[0].ID, chunks[1].ID}, "chunks should be in subdivision order")
+		chunks, err = application.GetStatute(ctx, "1", "14", dataStore, logger)
+		assert.NoError(err, "error on get statute: %v", err)
+		assert.Empty(chunks, "other sections sharing the prefix should not be returned")
+		_, err = application.GetStatute(ctx, "1", "142/../1", dataStore, logger)
+		assert.Error(err, "invalid statutes should fail")
+	})
+
+	t.Run("test chunks referenced by the retrieved chunks are added", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		chunkIDs := []string{"609.5311.1", "609.5311.3", "609.5316", "609.02.1", "609.02.2", "609.02.3", "609.02.4", "609.02.5", "609.02.6", "609.02.7"}
+		for _, chunkID := range chunkIDs {
+			dataStore.PutChunk(ctx, core.Chunk{ID: chunkID, Body: "§ " + chunkID + "\n"})
+		}
+		referenceGraph.PutReferences(ctx, "609.5311.1", []string{"609.5311.3", "609.5316", "609.02.6"})
+		referenceGraph.PutReferences(ctx, "609.5316", []string{"609.02"})
+
+		result, err := application.Ask(ctx, "what does § 609.5311, subd. 1 say?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		resultChunkIDs := make([]string, 0)
+		for _, chunk := range result.Chunks {
+			resultChunkIDs = append(resultChunkIDs, chunk.ID)
+		}
+		assert.Equal([]string{"609.5311.1", "609.5311.3", "609.5316", "609.02.6"}, resultChunkIDs, "referenced chunks should follow the cited chunk")
+		assert.False(result.Chunks[1].IsCited, "referenced chunks should not be marked cited")
+		assert.True(result.Chunks[1].IsContext, "referenced chunks should be marked context")
+
+		result, err = application.Ask(ctx, "what does § 609.5316 say?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		if assert.Len(result.Chunks, 6, "at most 5 chunks of a referenced section should be added") {
+			assert.Equal("609.02.1", result.Chunks[1].ID, "referenced sections should be added in subdivision order")
+			assert.Equal("609.02.5", result.Chunks[5].ID)
+		}
+
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(testRateLimits)
+		_, err = application.AnswerWithResult(ctx, "what does § 609.5311, subd. 1 say?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer with result: %v", err)
+		result, err = application.AnswerWithResult(ctx, "what about subdivision 6?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer with result: %v", err)
+		for _, chunk := range result.Chunks {
+			assert.False(chunk.IsCited, "follow-ups should not refer to the sections of referenced chunks, chunkID=%s", chunk.ID)
+		}
+	})
+
+	t.Run("test definitions of the terms used by the retrieved chunks are added", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		urlQueue, _ := memory.InitializeQueue(0)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		scraper, _ := scrapers.InitializeScraper()
+
+		page, err := os.Open(definitionsPath)
+		assert.NoError(err, "error on opening test page: %v", err)
+		defer page.Close()
+		dataStore.PutTextFile(ctx, "definitions.html", page)
+		err = application.ScrapeRawPage(ctx, dataStore.GetRawObjectKey("definitions.html"), dataStore, dataStore, referenceGraph, definitionStore, urlQueue, scraper, logger)
+		assert.NoError(err, "error on scrape raw page: %v", err)
+		definitions, err := definitionStore.GetDefinitions(ctx, "609")
+		assert.NoError(err, "error on get definitions: %v", err)
+		assert.Equal(map[string]string{"crime": "609.02.1", "felony": "609.02.2", "misdemeanor": "609.02.3"}, helpers.SectionDefinitions(definitions, "52"))
+
+		dataStore.PutChunk(ctx, core.Chunk{ID: "609.52.3", Body: "§ 609.52, subd. 3: THEFT -- Sentence.\nWhoever commits theft may be sentenced for a felony, or for misdemeanors as follows.\n"})
+		dataStore.PutChunk(ctx, core.Chunk{ID: "1.12", Body: "§ 1.12: FEDERAL FLOWAGE EASEMENTS OVER HIGHWAYS.\nNo felony here, chapter 1 defines no terms.\n"})
+		result, err := application.Ask(ctx, "what does § 609.52, subd. 3 say?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		resultChunkIDs := make([]string, 0)
+		for _, chunk := range result.Chunks {
+			resultChunkIDs = append(resultChunkIDs, chunk.ID)
+		}
+		assert.Equal([]string{"609.52.3", "609.02.2", "609.02.3"}, resultChunkIDs, "definitions of the terms used should follow the cited chunk")
+		assert.True(result.Chunks[1].IsContext, "definitions should be marked context")
+
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(testRateLimits)
+		_, err = application.AnswerWithResult(ctx, "what does § 609.52, subd. 3 say?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer with result: %v", err)
+		turns, _ := conversationStore.GetConversation(ctx, phoneNumber)
+		if assert.Len(turns, 1) {
+			assert.Equal([]string{"609.52.3"}, turns[0].ChunkIDs, "definitions should not be kept in the conversation")
+		}
+
+		result, err = application.Ask(ctx, "what does § 1.12 say?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		assert.Len(result.Chunks, 1, "definitions of other chapters should not be added")
+
+		dataStore.PutChunk(ctx, core.Chunk{ID: "609.531.1", Body: "§ 609.531, subd. 1: FORFEITURES -- Definitions.\nFor the purpose of this section, \"crime\" means a designated offense.\n"})
+		dataStore.PutChunk(ctx, core.Chunk{ID: "609.531.2", Body: "§ 609.531, subd. 2: FORFEITURES -- Seizure.\nProperty used in a crime that is a felony may be seized.\n"})
+		err = definitionStore.PutDefinitions(ctx, "609", "531", []core.Definition{{Term: "crime", ChunkID: "609.531.1", IsSectionScoped: true}})
+		assert.NoError(err, "error on put definitions: %v", err)
+		result, err = application.Ask(ctx, "what does § 609.531, subd. 2 say?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		resultChunkIDs = make([]string, 0)
+		for _, chunk := range result.Chunks {
+			resultChunkIDs = append(resultChunkIDs, chunk.ID)
+		}
+		assert.Equal([]string{"609.531.2", "609.531.1", "609.02.2"}, resultChunkIDs, "the section's own definitions should win over the chapter's")
+		result, err = application.Ask(ctx, "what does § 609.52, subd. 3 say?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		assert.Len(result.Chunks, 3, "definitions for another section should not be added")
+
+		err = definitionStore.PutDefinitions(ctx, "609", "531", nil)
+		assert.NoError(err, "error on put definitions: %v", err)
+		definitions, err = definitionStore.GetDefinitions(ctx, "609")
+		assert.NoError(err, "error on get definitions: %v", err)
+		assert.Len(definitions, 3, "definitions removed from a section should be cleared")
+	})
+
+	t.Run("test rules are scraped apart from the statutes and cited as Minn. R.", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		urlQueue, _ := memory.InitializeQueue(0)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		scraper, _ := scrapers.InitializeScraper()
+
+		page, err := os.Open(rulePath)
+		assert.NoError(err, "error on opening test page: %v", err)
+		defer page.Close()
+		dataStore.PutTextFile(ctx, "rule.html", page)
+		err = application.ScrapeRawPage(ctx, dataStore.GetRawObjectKey("rule.html"), dataStore, dataStore, referenceGraph, definitionStore, urlQueue, scraper, logger)
+		assert.NoError(err, "error on scrape raw page: %v", err)
+		chunkIDs, err := dataStore.ListChunkIDs(ctx, "R7100.0200.")
+		assert.NoError(err, "error on list chunk ids: %v", err)
+		assert.Equal([]string{"R7100.0200.1", "R7100.0200.2"}, chunkIDs, "both subparts should be listed")
+		references, err := referenceGraph.GetReferences(ctx, "R7100.0200.2")
+		assert.NoError(err, "error on get references: %v", err)
+		assert.Equal([]string{"R7100.0100.3", "R7100.0300"}, references, "the subpart links to other parts")
+
+		result, err := application.Ask(ctx, "what does Minn. R. 7100.0200, subp. 2 say?", core.CitationPolicyAnnotate, dataStore, referenceGraph, definitionStore, agent, searchIndex, vectorizer, logger)
+		assert.NoError(err, "error on ask: %v", err)
+		if assert.Len(result.Chunks, 1, "the cited subpart should be retrieved") {
+			assert.Equal("R7100.0200.2", result.Chunks[0].ID)
+			assert.Contains(result.Chunks[0].Body, "Minn. R. 7100.0200, subp. 2: PERMITS. -- Issuance.\nThe board shall:\nA. issue a permit if:\n  (1) the fee")
+		}
+
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(testRateLimits)
+		_, err = application.AnswerWithResult(ctx, "what does Minn. R. 7100.0200, subp. 2 say?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer with result: %v", err)
+		result, err = application.AnswerWithResult(ctx, "what about subpart 1?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer with result: %v", err)
+		if assert.NotEmpty(result.Chunks) {
+			assert.Equal("R7100.0200.1", result.Chunks[0].ID, "follow-ups citing a subpart should refer to the part of the previous turn")
+			assert.True(result.Chunks[0].IsCited)
+		}
+	})
+
+	t.Run("test long answers are texted in parts", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(testRateLimits)
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		optOutStore, _ := memory.InitializeOptOutStore()
+		for _, chunk := range core.DCChunks {
+			dataStore.PutChunk(ctx, chunk)
+		}
+		smsOptions := core.SMSOptions{Segment: true, PartsPerReply: 2}
+
+		err := application.Answer(ctx, "§ 115B.49", recipient, core.CitationPolicyAnnotate, smsOptions, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer: %v", err)
+		messages := comms.Messages()
+		if assert.Len(messages, 2, "only the first parts should be sent") {
+			assert.True(strings.HasPrefix(messages[0].Body, "(1/"), "parts should be numbered")
+			assert.True(strings.HasSuffix(messages[1].Body, "Reply MORE for more."), "last part should ask to reply MORE")
+		}
+		for _, message := range messages {
+			assert.LessOrEqual(helpers.SMSLength(message.Body), 160, "part=%s should fit in one SMS", message.Body)
+		}
+		pendingParts, _ := conversationStore.GetPendingParts(ctx, phoneNumber)
+		assert.NotEmpty(pendingParts, "remaining parts should be pending")
+
+		for len(pendingParts) > 0 {
+			err = application.HandleMessage(ctx, " more ", recipient, core.CitationPolicyAnnotate, smsOptions, dataStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+			assert.NoError(err, "error on handle message: %v", err)
+			pendingParts, _ = conversationStore.GetPendingParts(ctx, phoneNumber)
+		}
+		messages = comms.Messages()
+		lastMessage := messages[len(messages)-1].Body
+		assert.False(strings.HasSuffix(lastMessage, "Reply MORE for more."), "last part should not ask to reply MORE")
+
+		err = application.HandleMessage(ctx, "MORE", recipient, core.CitationPolicyAnnotate, smsOptions, dataStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer: %v", err)
+		messages = comms.Messages()
+		assert.Equal("There is nothing more to send, text a new question.", messages[len(messages)-1].Body)
+		turns, _ := conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Len(turns, 1, "replying MORE should not add turns")
+
+		summaryOptions := core.SMSOptions{Segment: true, SummaryFirst: true}
+		err = application.Answer(ctx, "§ 115B.49", recipient, core.CitationPolicyAnnotate, summaryOptions, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on answer: %v", err)
+		messages = comms.Messages()
+		summary := messages[len(messages)-1].Body
+		assert.True(strings.HasPrefix(summary, "Relevant statutes:"), "summary should be the first sentence")
+		assert.True(strings.HasSuffix(summary, "Reply MORE for the full answer."), "summary should ask to reply MORE")
+		pendingParts, _ = conversationStore.GetPendingParts(ctx, phoneNumber)
+		assert.True(len(pendingParts) > 1 && strings.HasPrefix(pendingParts[0], "(1/"), "the full answer should be pending")
+	})
+
+	t.Run("test other channels get the whole answer in one message", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(testRateLimits)
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		for _, chunk := range core.DCChunks {
+			dataStore.PutChunk(ctx, chunk)
+		}
+		smsOptions := core.SMSOptions{Segment: true, PartsPerReply: 2}
+
+		for _, channel := range []core.Channel{core.ChannelWhatsApp, core.ChannelWeb} {
+			otherRecipient := core.Recipient{Channel: channel, Identity: phoneNumber}
+			err := application.Answer(ctx, "§ 115B.49", otherRecipient, core.CitationPolicyAnnotate, smsOptions, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+			assert.NoError(err, "error on answer: %v", err)
+			messages := comms.Messages()
+			lastMessage := messages[len(messages)-1]
+			assert.Equal(otherRecipient, lastMessage.To, "answer should be sent over the recipient's channel")
+			assert.False(strings.HasPrefix(lastMessage.Body, "(1/"), "answer should not be segmented")
+			pendingParts, _ := conversationStore.GetPendingParts(ctx, otherRecipient.Key())
+			assert.Empty(pendingParts, "no parts should be pending")
+			turns, _ := conversationStore.GetConversation(ctx, otherRecipient.Key())
+			assert.Len(turns, 1, "conversation should be kept per channel")
+		}
+		assert.Len(comms.Messages(), 2, "each channel should get one message")
+		turns, _ := conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Empty(turns, "SMS conversation should be separate")
+	})
+
+	t.Run("test rate limited senders are told once", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(core.RateLimits{Burst: 1, PerHour: 1})
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		for i := 0; i < 3; i++ {
+			err := application.Answer(ctx, "what is § 1.142?", recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+			assert.NoError(err, "error on answer: %v", err)
+		}
+		messages := comms.Messages()
+		if assert.Len(messages, 2, "one answer and one notice should be sent") {
+			assert.Contains(messages[1].Body, "please try again in a little while")
+		}
+		turns, _ := conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Len(turns, 1, "rate limited questions should not be answered")
+
+		for _, sessionID := range []string{"session-1", "session-2"} {
+			webRecipient := core.Recipient{Channel: core.ChannelWeb, Identity: sessionID, Source: "203.0.113.7"}
+			err := application.Answer(ctx, "what is § 1.142?", webRecipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+			assert.NoError(err, "error on answer: %v", err)
+		}
+		messages = comms.Messages()
+		if assert.Len(messages, 4, "one answer and one notice should be sent to the web chat") {
+			assert.Contains(messages[3].Body, "please try again in a little while", "a new session from the same source should share its rate limit")
+		}
+
+		optOutStore, _ := memory.InitializeOptOutStore()
+		commandRecipient := core.Recipient{Channel: core.ChannelSMS, Identity: "15555550104"}
+		for _, text := range []string{"NEW", "SOURCE 1.142", "MORE", "HELP"} {
+			err := application.HandleMessage(ctx, text, commandRecipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+			assert.NoError(err, "error on handle message=%s: %v", text, err)
+		}
+		messages = comms.Messages()[4:]
+		if assert.Len(messages, 3, "one reply, one notice and HELP should be sent") {
+			assert.Contains(messages[0].Body, "Started a new conversation")
+			assert.Contains(messages[1].Body, "please try again in a little while", "commands should be rate limited")
+			assert.Contains(messages[2].Body, "STOP to unsubscribe", "HELP should not be rate limited")
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// VerifyCitations checks every citation of the answer against the retrieved chunks and then the chunk store,
+// and applies the citation policy to the citations that could not be verified.
+func VerifyCitations(ctx context.Context, prompt string, history []core.ConversationTurn, answer core.Answer, chunks []core.Chunk, citationPolicy core.CitationPolicy, chunkStore core.ChunksDataStore, agent core.Agent, logger core.Logger) (core.Answer, error) {
+	answer, err := verifyCitations(ctx, answer, chunks, chunkStore, logger)
+	if err != nil {
+		return core.Answer{}, err
+	}
+	unverified := helpers.UnverifiedCitations(answer)
+	if len(unverified) == 0 {
+		return answer, nil
+	}
+	for _, citation := range unverified {
+		logger.Warn("answer cites chunkID=%s which is not in the chunk store", citation.ChunkID)
+	}
+
+	switch citationPolicy {
+	case core.CitationPolicyStrip:
+		logger.Info("stripping %d unverified citations", len(unverified))
+		return stripUnverifiedCitations(answer), nil
+	case core.CitationPolicyRegenerate:
+		logger.Info("regenerating answer without %d unverified citations", len(unverified))
+		regenerated, err := agent.AskWithChunks(ctx, getRegeneratePrompt(prompt, unverified), history, chunks)
+		if err != nil {
+			return core.Answer{}, fmt.Errorf("error on regenerating answer: %v", err)
+		}
+		regenerated.InputTokens += answer.InputTokens
+		regenerated.OutputTokens += answer.OutputTokens
+		// regenerate only once, whatever is still unverified gets annotated
+		return verifyCitations(ctx, regenerated, chunks, chunkStore, logger)
+	case core.CitationPolicyAnnotate, "":
+		return answer, nil
+	default:
+		return core.Answer{}, fmt.Errorf("unsupported citation policy=%s", citationPolicy)
+	}
+}
+
+func verifyCitations(ctx context.Context, answer core.Answer, chunks []core.Chunk, chunkStore core.ChunksDataStore, logger core.Logger) (core.Answer, error) {
+	answer = helpers.ValidateCitations(answer, chunks)
+	for i, citation := range answer.Citations {
+		if citation.IsVerified {
+			continue
+		}
+		isVerified, err := isChunkInStore(ctx, citation.ChunkID, chunkStore, logger)
+		if err != nil {
+			return core.Answer{}, err
+		}
+		answer.Citations[i].IsVerified = isVerified
+	}
+	return answer, nil
+}
+
+// isChunkInStore reports whether the chunk ID, or any subdivision of the section it names, is stored.
+// Errors other than the chunk not being stored are returned, so valid citations aren't dropped on a failing store.
+func isChunkInStore(ctx context.Context, chunkID string, chunkStore core.ChunksDataStore, logger core.Logger) (bool, error) {
+	_, err := chunkStore.GetChunk(ctx, chunkID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, core.ErrNotFound) {
+		return false, fmt.Errorf("error on getting chunk for chunkID=%s: %v", chunkID, err)
+	}
+	logger.Debug("chunkID=%s not found in chunk store: %v", chunkID, err)
+	if _, _, subdivision := helpers.SplitChunkID(chunkID); len(subdivision) > 0 {
+		return false, nil
+	}
+	// a section is stored as its subdivisions, which may not start at 1, e.g. "Subd. 0" or "Subd. 1a"
+	storedChunkIDs, err := listCitedChunkIDs(ctx, []string{chunkID}, chunkStore, logger)
+	if err != nil {
+		return false, err
+	}
+	return len(storedChunkIDs) > 0, nil
+}
+
+func stripUnverifiedCitations(answer core.Answer) core.Answer {
+	unverifiedIDs := make([]string, 0)
+	citations := make([]core.Citation, 0, len(answer.Citations))
+	for _, citation := range answer.Citations {
+		if citation.IsVerified {
+			citations = append(citations, citation)
+		} else {
+			unverifiedIDs = append(unverifiedIDs, citation.ChunkID)
+		}
+	}
+	answer.Text = helpers.StripCitations(answer.Text, unverifiedIDs)
+	answer.Citations = citations
+	return answer
+}
+
+func getRegeneratePrompt(prompt string, unverified []core.Citation) string {
+	var builder strings.Builder
+	builder.WriteString(prompt)
+	builder.WriteString("\n\nDo not cite the following statutes, they do not exist in the provided statutes: ")
+	for i, citation := range unverified {
+		if i > 0 {
+			builder.WriteString("; ")
+		}
+		builder.WriteString(helpers.ChunkIDToCitation(citation.ChunkID))
+	}
+	builder.WriteString(". Only cite the statutes provided above.")
+	return builder.String()
+}
@@ -0,0 +1,74 @@
+package application_test
+
+import (
+	"code/application"
+	"code/core"
+	"code/helpers"
+	"code/infrastructure/loggers"
+	"code/infrastructure/memory"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCitations(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	t.Run("test VerifyCitations policies", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		agent, _ := memory.InitializeAgent()
+		dataStore.PutChunk(ctx, core.Chunk11)
+		dataStore.PutChunk(ctx, core.Chunk12)
+		text := "See § 1a.34, subd. 1, § 1a.34 and § 1a.34, subd. 9."
+		answer := core.Answer{Text: text, Citations: helpers.ParseCitations(text)}
+
+		annotated, err := application.VerifyCitations(ctx, "prompt", nil, answer, nil, core.CitationPolicyAnnotate, dataStore, agent, logger)
+		assert.NoError(err, "error on verify citations: %v", err)
+		assert.Equal(text, annotated.Text, "annotate should keep the text")
+		if assert.Len(annotated.Citations, 3) {
+			assert.True(annotated.Citations[0].IsVerified, "stored subdivision should be verified")
+			assert.True(annotated.Citations[1].IsVerified, "section of a stored subdivision should be verified")
+			assert.False(annotated.Citations[2].IsVerified, "unknown subdivision should not be verified")
+		}
+
+		stripped, err := application.VerifyCitations(ctx, "prompt", nil, answer, nil, core.CitationPolicyStrip, dataStore, agent, logger)
+		assert.NoError(err, "error on verify citations: %v", err)
+		assert.NotContains(stripped.Text, "subd. 9", "unknown citation should be stripped from the text")
+		assert.Len(stripped.Citations, 2, "unknown citation should be stripped from the citations")
+
+		regenerated, err := application.VerifyCitations(ctx, "prompt", nil, answer, []core.Chunk{core.Chunk11}, core.CitationPolicyRegenerate, dataStore, agent, logger)
+		assert.NoError(err, "error on verify citations: %v", err)
+		assert.Empty(helpers.UnverifiedCitations(regenerated), "regenerated answer should only cite the chunks")
+		assert.Contains(regenerated.Text, "§ 1a.34, subd. 1", "regenerated answer should come from the agent")
+
+		dataStore.PutChunk(ctx, core.Chunk{ID: "1a.35.2", Body: "§ 1a.35, subd. 2: A section without a first subdivision.\n"})
+		sectionText := "See § 1a.35."
+		sectionAnswer := core.Answer{Text: sectionText, Citations: helpers.ParseCitations(sectionText)}
+		stripped, err = application.VerifyCitations(ctx, "prompt", nil, sectionAnswer, nil, core.CitationPolicyStrip, dataStore, agent, logger)
+		assert.NoError(err, "error on verify citations: %v", err)
+		assert.Equal(sectionText, stripped.Text, "a section stored without a subd. 1 should be verified")
+
+		_, err = application.VerifyCitations(ctx, "prompt", nil, answer, nil, "unknown", dataStore, agent, logger)
+		assert.Error(err, "unsupported citation policy should fail")
+
+		failingStore := &failingChunkStore{DataStore: dataStore, err: errors.New("connection reset")}
+		_, err = application.VerifyCitations(ctx, "prompt", nil, answer, nil, core.CitationPolicyStrip, failingStore, agent, logger)
+		assert.Error(err, "a failing chunk store should fail instead of stripping valid citations")
+		_, err = dataStore.GetChunk(ctx, "1a.34.9")
+		assert.ErrorIs(err, core.ErrNotFound, "missing chunks should be not found")
+	})
+}
+
+// failingChunkStore fails to get any chunk, like a chunk store that can't be reached.
+type failingChunkStore struct {
+	*memory.DataStore
+	err error
+}
+
+func (chunkStore *failingChunkStore) GetChunk(ctx context.Context, chunkID string) (core.Chunk, error) {
+	return core.Chunk{}, chunkStore.err
+}
@@ -0,0 +1,153 @@
+package application
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Command is a keyword texted instead of a question.
+type Command string
+
+const (
+	CommandNone   Command = ""
+	CommandStop   Command = "STOP"
+	CommandStart  Command = "START"
+	CommandHelp   Command = "HELP"
+	CommandMore   Command = "MORE"
+	CommandSource Command = "SOURCE"
+	CommandNew    Command = "NEW"
+)
+
+// keywords carriers expect to be honored as STOP and START
+var commandAliases = map[string]Command{
+	"STOPALL":     CommandStop,
+	"UNSUBSCRIBE": CommandStop,
+	"CANCEL":      CommandStop,
+	"END":         CommandStop,
+	"QUIT":        CommandStop,
+	"UNSTOP":      CommandStart,
+	"SUBSCRIBE":   CommandStart,
+	"INFO":        CommandHelp,
+}
+
+const (
+	stopMessage  = "You are unsubscribed and will not receive more messages. Reply START to resubscribe."
+	startMessage = "You are subscribed again. Text a question about Minnesota statutes."
+	helpMessage  = "Text a question about Minnesota statutes, e.g. \"Do I need to register my canoe?\". " +
+		"Reply SOURCE 609.52 for the text of a statute, MORE for the rest of an answer, NEW to start over, STOP to unsubscribe."
+	newMessage            = "Started a new conversation. Text a question about Minnesota statutes."
+	sourceUsageMessage    = "Reply SOURCE followed by a statute, e.g. SOURCE 609.52 or SOURCE 609.52, subd. 2."
+	sourceNotFoundMessage = "Could not find %s."
+)
+
+// ParseCommand returns the command texted and its arguments. Keywords are only commands when texted on
+// their own, except SOURCE which is followed by a statute, so questions like "can police stop my car?"
+// are still answered.
+func ParseCommand(text string) (Command, string) {
+	keyword, arguments, _ := strings.Cut(strings.TrimSpace(text), " ")
+	keyword = strings.ToUpper(strings.TrimRight(keyword, ".!"))
+	arguments = strings.TrimSpace(arguments)
+	if alias, ok := commandAliases[keyword]; ok {
+		keyword = string(alias)
+	}
+	switch command := Command(keyword); command {
+	case CommandSource:
+		return command, arguments
+	case CommandStop, CommandStart, CommandHelp, CommandMore, CommandNew:
+		if len(arguments) == 0 {
+			return command, ""
+		}
+	}
+	return CommandNone, ""
+}
+
+// HandleMessage runs the command sent by the recipient, or answers it as a question. Recipients that
+// opted out are ignored until they reply START, except for HELP which carriers require to always be answered.
+// Questions and the other commands are rate limited, STOP, START and HELP never are.
+func HandleMessage(ctx context.Context, text string, recipient core.Recipient, citationPolicy core.CitationPolicy, smsOptions core.SMSOptions, chunkStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, definitionStore core.DefinitionStore, conversationStore core.ConversationStore, optOutStore core.OptOutStore, rateLimiter core.RateLimiter, agent core.Agent, indexer core.SearchIndex, vectorizer core.Vectorizer, comms core.Comms, logger core.Logger) error {
+	command, arguments := ParseCommand(text)
+	logger.Info("received command='%s' from recipient=%s", command, recipient.Key())
+
+	switch command {
+	case CommandStop:
+		if err := optOutStore.SetOptedOut(ctx, recipient.Key(), true); err != nil {
+			return fmt.Errorf("error opting out: %v", err)
+		}
+		return sendMessage(ctx, recipient, stopMessage, comms)
+	case CommandStart:
+		if err := optOutStore.SetOptedOut(ctx, recipient.Key(), false); err != nil {
+			return fmt.Errorf("error opting in: %v", err)
+		}
+		return sendMessage(ctx, recipient, startMessage, comms)
+	case CommandHelp:
+		return sendMessage(ctx, recipient, helpMessage, comms)
+	}
+
+	isOptedOut, err := optOutStore.IsOptedOut(ctx, recipient.Key())
+	if err != nil {
+		return fmt.Errorf("error checking opt-out: %v", err)
+	}
+	if isOptedOut {
+		logger.Info("ignoring message from opted out recipient=%s", recipient.Key())
+		return nil
+	}
+	if command != CommandNone {
+		isRateLimited, err := checkRateLimit(ctx, recipient, rateLimiter, comms, logger)
+		if err != nil || isRateLimited {
+			return err
+		}
+	}
+
+	switch command {
+	case CommandMore:
+		return sendMore(ctx, recipient, smsOptions, conversationStore, comms, logger)
+	case CommandNew:
+		if err := conversationStore.DeleteConversation(ctx, recipient.Key()); err != nil {
+			return fmt.Errorf("error deleting conversation: %v", err)
+		}
+		return sendMessage(ctx, recipient, newMessage, comms)
+	case CommandSource:
+		return sendSource(ctx, recipient, arguments, smsOptions, chunkStore, conversationStore, comms, logger)
+	default:
+		return Answer(ctx, text, recipient, citationPolicy, smsOptions, chunkStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, indexer, vectorizer, comms, logger)
+	}
+}
+
+// sendSource texts the text of the cited statutes as stored, without asking the agent.
+func sendSource(ctx context.Context, recipient core.Recipient, arguments string, smsOptions core.SMSOptions, chunkStore core.ChunksDataStore, conversationStore core.ConversationStore, comms core.Comms, logger core.Logger) error {
+	citedChunkIDs := make([]string, 0)
+	for _, citation := range helpers.ParseCommandCitations(arguments) {
+		citedChunkIDs = append(citedChunkIDs, citation.ChunkID)
+	}
+	if len(citedChunkIDs) == 0 {
+		return sendMessage(ctx, recipient, sourceUsageMessage, comms)
+	}
+
+	chunks, err := getCitedChunks(ctx, citedChunkIDs, chunkStore, logger)
+	if err != nil {
+		return fmt.Errorf("error getting cited chunks: %v", err)
+	}
+	if len(chunks) == 0 {
+		return sendMessage(ctx, recipient, fmt.Sprintf(sourceNotFoundMessage, arguments), comms)
+	}
+
+	var builder strings.Builder
+	for _, chunk := range chunks {
+		builder.WriteString(chunk.Body)
+		builder.WriteString("\n")
+	}
+	if err := sendReply(ctx, recipient, builder.String(), smsOptions, conversationStore, comms, logger); err != nil {
+		return fmt.Errorf("error on send reply: %v", err)
+	}
+	return nil
+}
+
+func sendMessage(ctx context.Context, recipient core.Recipient, message string, comms core.Comms) error {
+	if err := comms.SendMessage(ctx, recipient, message); err != nil {
+		return fmt.Errorf("error on send message: %v", err)
+	}
+	return nil
+}
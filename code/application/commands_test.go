@@ -0,0 +1,97 @@
+package application_test
+
+import (
+	"code/application"
+	"code/core"
+	"code/infrastructure/loggers"
+	"code/infrastructure/memory"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommands(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	t.Run("test commands", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(testRateLimits)
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		optOutStore, _ := memory.InitializeOptOutStore()
+		for _, chunk := range core.DCChunks {
+			dataStore.PutChunk(ctx, chunk)
+		}
+		handleMessage := func(text string) string {
+			sentCount := len(comms.Messages())
+			err := application.HandleMessage(ctx, text, recipient, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger)
+			assert.NoError(err, "error on handle message=%s: %v", text, err)
+			var builder strings.Builder
+			for _, message := range comms.Messages()[sentCount:] {
+				builder.WriteString(message.Body)
+			}
+			return builder.String()
+		}
+
+		assert.Contains(handleMessage("help"), "SOURCE 609.52", "HELP should explain the commands")
+
+		source := handleMessage("SOURCE 115B.49 subd 4b")
+		assert.True(strings.HasPrefix(source, core.DCChunk2.Body), "SOURCE should text the statute as stored")
+		assert.NotContains(source, core.DCChunk1.Body, "SOURCE should only text the cited subdivision")
+		source = handleMessage("source § 115B.49")
+		assert.Contains(source, core.DCChunk1.Body, "SOURCE of a section should text every subdivision")
+		assert.Contains(source, core.DCChunk2.Body, "SOURCE of a section should text every subdivision")
+		assert.Contains(handleMessage("SOURCE 609.52"), "Could not find 609.52.")
+		assert.Contains(handleMessage("SOURCE"), "Reply SOURCE followed by a statute")
+		turns, _ := conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Empty(turns, "commands should not add turns")
+
+		handleMessage("what is § 115B.49?")
+		turns, _ = conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Len(turns, 1, "questions should add turns")
+		assert.Contains(handleMessage("NEW"), "Started a new conversation")
+		turns, _ = conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Empty(turns, "NEW should reset the conversation")
+
+		assert.Contains(handleMessage("Stop"), "Reply START to resubscribe")
+		isOptedOut, _ := optOutStore.IsOptedOut(ctx, phoneNumber)
+		assert.True(isOptedOut, "STOP should opt out")
+		assert.Contains(handleMessage("HELP"), "STOP to unsubscribe", "opted out numbers should still get HELP")
+		assert.Empty(handleMessage("what is § 115B.49?"), "opted out numbers should not be answered")
+		assert.Contains(handleMessage("START"), "subscribed again")
+		assert.NotEmpty(handleMessage("can police stop my car?"), "keywords inside questions should be answered")
+		turns, _ = conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Len(turns, 1, "only the question after START should be answered")
+	})
+
+	t.Run("test ParseCommand", func(t *testing.T) {
+		for text, expected := range map[string]application.Command{
+			" stop ":           application.CommandStop,
+			"UNSUBSCRIBE":      application.CommandStop,
+			"unstop":           application.CommandStart,
+			"Help!":            application.CommandHelp,
+			"more":             application.CommandMore,
+			"NEW":              application.CommandNew,
+			"stop the car":     application.CommandNone,
+			"what is new?":     application.CommandNone,
+			"source 609.52":    application.CommandSource,
+			"sources of law":   application.CommandNone,
+			"more about 86B.3": application.CommandNone,
+		} {
+			command, _ := application.ParseCommand(text)
+			assert.Equal(expected, command, "unexpected command for text=%s", text)
+		}
+		_, arguments := application.ParseCommand("SOURCE  609.52, subd. 2 ")
+		assert.Equal("609.52, subd. 2", arguments)
+	})
+}
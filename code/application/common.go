@@ -3,6 +3,7 @@ package application
 import "code/helpers"
 
 const MNRevisorStatutesURL = "https://www.revisor.mn.gov/statutes/"
+const MNRevisorRulesURL = "https://www.revisor.mn.gov/rules/"
 
 func getURLFileName(url string) string {
 	return "url=" + helpers.Base64Encode(url)
@@ -35,7 +35,7 @@ func TriggerCrawler(ctx context.Context, seedURLs []string, urlQueue core.URLQue
 	}
 	logger.Info("received seedURLs=%v", seedURLs)
 	if len(seedURLs) == 0 {
-		seedURLs = []string{MNRevisorStatutesURL}
+		seedURLs = []string{MNRevisorStatutesURL, MNRevisorRulesURL}
 	}
 	for _, seedURL := range seedURLs {
 		logger.Info("sending '%s' to url queue", seedURL)
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"fmt"
+)
+
+// Evaluate searches the index for every question of the golden set the way the answerer does, and reports
+// recall and nDCG at each of the cutoffs ks, and the mean reciprocal rank.
+func Evaluate(ctx context.Context, questions []core.EvalQuestion, ks []int, indexer core.SearchIndex, vectorizer core.Vectorizer, logger core.Logger) (core.EvalReport, error) {
+	report := core.EvalReport{
+		QuestionCount: len(questions),
+		RecallAtK:     make(map[int]float64),
+		NDCGAtK:       make(map[int]float64),
+		Results:       make([]core.EvalResult, 0, len(questions)),
+	}
+	if len(questions) == 0 {
+		return report, fmt.Errorf("no questions to evaluate")
+	}
+	for _, k := range ks {
+		if k <= 0 {
+			return report, fmt.Errorf("k must be positive, got k=%d", k)
+		}
+	}
+
+	for i, question := range questions {
+		if len(question.Question) == 0 || len(question.ExpectedChunkIDs) == 0 {
+			return report, fmt.Errorf("question %d is missing its text or expected chunk ids", i+1)
+		}
+		logger.Info("evaluating question=%s", question.Question)
+		questionVD, err := vectorizer.Vectorize(ctx, question.Question)
+		if err != nil {
+			return report, fmt.Errorf("error vectorizing question=%s: %v", question.Question, err)
+		}
+		foundChunkIDs, err := indexer.FindMatchingChunkIDs(ctx, questionVD)
+		if err != nil {
+			return report, fmt.Errorf("error finding matching chunk ids for question=%s: %v", question.Question, err)
+		}
+
+		matches := helpers.MatchExpectedChunkIDs(foundChunkIDs, question.ExpectedChunkIDs)
+		for _, k := range ks {
+			report.RecallAtK[k] += helpers.RecallAtK(matches, len(question.ExpectedChunkIDs), k)
+			report.NDCGAtK[k] += helpers.NDCGAtK(matches, len(question.ExpectedChunkIDs), k)
+		}
+		report.MRR += helpers.ReciprocalRank(matches)
+		report.Results = append(report.Results, core.EvalResult{
+			Question:         question.Question,
+			ExpectedChunkIDs: question.ExpectedChunkIDs,
+			FoundChunkIDs:    foundChunkIDs,
+			Rank:             helpers.FirstMatchRank(matches),
+		})
+	}
+
+	questionCount := float64(len(questions))
+	for _, k := range ks {
+		report.RecallAtK[k] /= questionCount
+		report.NDCGAtK[k] /= questionCount
+	}
+	report.MRR /= questionCount
+	logger.Info("evaluated %d questions, mrr=%.4f", len(questions), report.MRR)
+	return report, nil
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"code/core"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EnqueueMessage queues the inbound message to be handled by the answer worker. The message is
+// deduplicated on its ID, so a callback delivered again is only answered once, and messages from the
+// same recipient are handled in the order received.
+func EnqueueMessage(ctx context.Context, inboundMessage core.InboundMessage, inboundQueue core.Queue, logger core.Logger) error {
+	if len(inboundMessage.ID) == 0 {
+		return fmt.Errorf("inbound message has no ID")
+	}
+	body, err := json.Marshal(inboundMessage)
+	if err != nil {
+		return fmt.Errorf("error on marshalling inbound message: %v", err)
+	}
+	logger.Info("enqueueing message with ID=%s from recipient=%s", inboundMessage.ID, inboundMessage.From.Key())
+	queueMessage := core.QueueMessage{Body: string(body), DeduplicationID: inboundMessage.ID, GroupID: inboundMessage.From.Key()}
+	if err := inboundQueue.SendMessage(ctx, queueMessage); err != nil {
+		return fmt.Errorf("error on send inbound message: %v", err)
+	}
+	return nil
+}
+
+// ParseInboundMessage returns the inbound message queued by EnqueueMessage.
+func ParseInboundMessage(body string) (core.InboundMessage, error) {
+	var inboundMessage core.InboundMessage
+	if err := json.Unmarshal([]byte(body), &inboundMessage); err != nil {
+		return inboundMessage, fmt.Errorf("error on unmarshalling inbound message: %v", err)
+	}
+	if len(inboundMessage.ID) == 0 || len(inboundMessage.From.Channel) == 0 || len(inboundMessage.From.Identity) == 0 {
+		return inboundMessage, fmt.Errorf("inbound message is missing its ID or sender")
+	}
+	return inboundMessage, nil
+}
+
+// HandleInboundMessage handles the inbound message unless a message with the same ID was handled before,
+// so a message delivered more than once is only answered once. The ID is forgotten when handling fails
+// before anything was sent, so the message is answered when it is retried, but not texted twice.
+func HandleInboundMessage(ctx context.Context, inboundMessage core.InboundMessage, citationPolicy core.CitationPolicy, smsOptions core.SMSOptions, chunkStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, definitionStore core.DefinitionStore, conversationStore core.ConversationStore, optOutStore core.OptOutStore, rateLimiter core.RateLimiter, dedupStore core.DedupStore, agent core.Agent, indexer core.SearchIndex, vectorizer core.Vectorizer, comms core.Comms, logger core.Logger) error {
+	_, err := HandleInboundMessageOnce(ctx, inboundMessage, citationPolicy, smsOptions, chunkStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, dedupStore, agent, indexer, vectorizer, comms, logger)
+	return err
+}
+
+// HandleInboundMessageOnce is HandleInboundMessage reporting whether the message was handled, it is not
+// when a message with the same ID was handled before.
+func HandleInboundMessageOnce(ctx context.Context, inboundMessage core.InboundMessage, citationPolicy core.CitationPolicy, smsOptions core.SMSOptions, chunkStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, definitionStore core.DefinitionStore, conversationStore core.ConversationStore, optOutStore core.OptOutStore, rateLimiter core.RateLimiter, dedupStore core.DedupStore, agent core.Agent, indexer core.SearchIndex, vectorizer core.Vectorizer, comms core.Comms, logger core.Logger) (bool, error) {
+	isNew, err := dedupStore.PutMessageID(ctx, inboundMessage.ID)
+	if err != nil {
+		return false, fmt.Errorf("error putting message ID: %v", err)
+	}
+	if !isNew {
+		logger.Info("ignoring duplicate message with ID=%s", inboundMessage.ID)
+		return false, nil
+	}
+	if !inboundMessage.ReceivedAt.IsZero() {
+		logger.Info("handling message with ID=%s received %v ago", inboundMessage.ID, time.Since(inboundMessage.ReceivedAt))
+	}
+
+	trackingComms := &sendTrackingComms{Comms: comms}
+	err = HandleMessage(ctx, inboundMessage.Text, inboundMessage.From, citationPolicy, smsOptions, chunkStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, agent, indexer, vectorizer, trackingComms, logger)
+	if err != nil {
+		if trackingComms.isSent {
+			logger.Error("keeping message ID=%s after failing to handle it, a reply was already sent", inboundMessage.ID)
+			return true, err
+		}
+		if deleteErr := dedupStore.DeleteMessageID(ctx, inboundMessage.ID); deleteErr != nil {
+			logger.Error("error deleting message ID=%s: %v", inboundMessage.ID, deleteErr)
+		}
+		return true, err
+	}
+	return true, nil
+}
+
+// sendTrackingComms remembers whether any message was sent successfully.
+type sendTrackingComms struct {
+	core.Comms
+	isSent bool
+}
+
+func (comms *sendTrackingComms) SendMessage(ctx context.Context, recipient core.Recipient, message string) error {
+	if err := comms.Comms.SendMessage(ctx, recipient, message); err != nil {
+		return err
+	}
+	comms.isSent = true
+	return nil
+}
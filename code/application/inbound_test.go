@@ -0,0 +1,83 @@
+package application_test
+
+import (
+	"code/application"
+	"code/core"
+	"code/infrastructure/loggers"
+	"code/infrastructure/memory"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInbound(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	t.Run("test inbound messages are queued once and answered from the queue", func(t *testing.T) {
+		logger, _ := loggers.InitializeMultiLogger(false)
+		dataStore, _ := memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		searchIndex, _ := memory.InitializeSearchIndex(1)
+		vectorizer, _ := memory.InitializeVectorizer(0)
+		agent, _ := memory.InitializeAgent()
+		comms, _ := memory.InitializeComms()
+		conversationStore, _ := memory.InitializeConversationStore(time.Hour)
+		rateLimiter, _ := memory.InitializeRateLimiter(testRateLimits)
+		referenceGraph, _ := memory.InitializeReferenceGraph()
+		definitionStore, _ := memory.InitializeDefinitionStore()
+		optOutStore, _ := memory.InitializeOptOutStore()
+		inboundQueue, _ := memory.InitializeQueue(0)
+		dedupStore, _ := memory.InitializeDedupStore()
+		for _, chunk := range core.DCChunks {
+			dataStore.PutChunk(ctx, chunk)
+		}
+
+		inboundMessage := core.InboundMessage{ID: "01HXY", From: recipient, Text: "what is § 115B.49?"}
+		for i := 0; i < 2; i++ {
+			err := application.EnqueueMessage(ctx, inboundMessage, inboundQueue, logger)
+			assert.NoError(err, "error on enqueue message: %v", err)
+		}
+		assert.Equal(1, inboundQueue.Len(), "retried callbacks should be queued once")
+		err := application.EnqueueMessage(ctx, core.InboundMessage{From: recipient, Text: "hi"}, inboundQueue, logger)
+		assert.Error(err, "messages without an ID should not be queued")
+
+		queueMessage, _ := inboundQueue.ReceiveMessage(ctx)
+		received, err := application.ParseInboundMessage(queueMessage.Body)
+		assert.NoError(err, "error on parse inbound message: %v", err)
+		assert.Equal(inboundMessage, received, "inbound messages should be equal")
+		err = application.HandleInboundMessage(ctx, received, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, dedupStore, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on handle inbound message: %v", err)
+		sentCount := len(comms.Messages())
+		assert.NotZero(sentCount, "queued message should be answered")
+		isHandled, err := application.HandleInboundMessageOnce(ctx, received, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, dedupStore, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on handle inbound message: %v", err)
+		assert.False(isHandled, "redelivered message should be reported as not handled")
+		assert.Len(comms.Messages(), sentCount, "redelivered message should not be answered again")
+		turns, _ := conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Len(turns, 1, "redelivered message should not add a turn")
+
+		failingStore := &failingConversationStore{ConversationStore: conversationStore, err: errors.New("throttled")}
+		failedMessage := core.InboundMessage{ID: "01HXZ", From: recipient, Text: "what is § 115B.49?"}
+		err = application.HandleInboundMessage(ctx, failedMessage, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, failingStore, optOutStore, rateLimiter, dedupStore, agent, searchIndex, vectorizer, comms, logger)
+		assert.Error(err, "failing to add the turn should fail")
+		assert.Len(comms.Messages(), sentCount+1, "the answer should be sent before adding the turn")
+		err = application.HandleInboundMessage(ctx, failedMessage, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, failingStore, optOutStore, rateLimiter, dedupStore, agent, searchIndex, vectorizer, comms, logger)
+		assert.NoError(err, "error on handle inbound message: %v", err)
+		assert.Len(comms.Messages(), sentCount+1, "a message answered before failing should not be answered again when retried")
+		_, err = application.ParseInboundMessage("{}")
+		assert.Error(err, "messages without an ID should not be parsed")
+	})
+}
+
+// failingConversationStore fails to add turns, like a throttled conversation store.
+type failingConversationStore struct {
+	*memory.ConversationStore
+	err error
+}
+
+func (conversationStore *failingConversationStore) AddConversationTurn(ctx context.Context, key string, turn core.ConversationTurn) error {
+	return conversationStore.err
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Ask answers a single prompt without a conversation, rate limit or comms, for programmatic access. The
+// answer is returned along with the chunks it was generated from.
+func Ask(ctx context.Context, prompt string, citationPolicy core.CitationPolicy, chunkStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, definitionStore core.DefinitionStore, agent core.Agent, indexer core.SearchIndex, vectorizer core.Vectorizer, logger core.Logger) (core.AskResult, error) {
+	logger.Info("received prompt='%s'", prompt)
+
+	result, err := generateAnswer(ctx, prompt, nil, citationPolicy, chunkStore, referenceGraph, definitionStore, agent, indexer, vectorizer, logger)
+	if err != nil {
+		return core.AskResult{}, err
+	}
+	logger.Info("answered prompt=%s", prompt)
+	return result, nil
+}
+
+// Search returns the chunks best matching the query with their scores, without generating an answer.
+func Search(ctx context.Context, query string, chunkStore core.ChunksDataStore, indexer core.SearchIndex, vectorizer core.Vectorizer, logger core.Logger) ([]core.ScoredChunk, error) {
+	logger.Info("searching for query='%s'", query)
+	scoredChunks, err := searchScoredChunks(ctx, query, chunkStore, indexer, vectorizer, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error on searching chunks: %v", err)
+	}
+	return scoredChunks, nil
+}
+
+// GetStatute returns the chunks of the section, one per subdivision in subdivision order. No chunks are
+// returned for sections that were not scraped.
+func GetStatute(ctx context.Context, chapter, section string, chunkStore core.ChunksDataStore, logger core.Logger) ([]core.Chunk, error) {
+	statute := chapter + "." + section
+	if _, _, ok := helpers.ParseStatuteNumber(statute); !ok {
+		return nil, fmt.Errorf("invalid statute=%s", statute)
+	}
+	storedChunkIDs, err := chunkStore.ListChunkIDs(ctx, statute)
+	if err != nil {
+		return nil, fmt.Errorf("error listing chunk ids with prefix=%s: %v", statute, err)
+	}
+	// the prefix "609.52" also lists "609.521", keep only the section and its subdivisions
+	chunkIDs := make([]string, 0, len(storedChunkIDs))
+	for _, storedChunkID := range storedChunkIDs {
+		if storedChunkID == statute || strings.HasPrefix(storedChunkID, statute+".") {
+			chunkIDs = append(chunkIDs, storedChunkID)
+		}
+	}
+	helpers.SortChunkIDs(chunkIDs)
+	return getChunks(ctx, chunkIDs, chunkStore, logger)
+}
@@ -8,7 +8,7 @@ import (
 	"strings"
 )
 
-func ScrapeRawPage(ctx context.Context, objectKey string, rawDataStore core.RawDataStore, chunksDataStore core.ChunksDataStore, urlQueue core.URLQueue, scraper core.MNRevisorStatutesScraper, logger core.Logger) error {
+func ScrapeRawPage(ctx context.Context, objectKey string, rawDataStore core.RawDataStore, chunksDataStore core.ChunksDataStore, referenceGraph core.ReferenceGraph, definitionStore core.DefinitionStore, urlQueue core.URLQueue, scraper core.MNRevisorStatutesScraper, logger core.Logger) error {
 
 	// get text file
 	logger.Info("getting text file \"%s\"", objectKey)
@@ -28,7 +28,7 @@ func ScrapeRawPage(ctx context.Context, objectKey string, rawDataStore core.RawD
 
 	// parse page
 	switch pageKind {
-	case core.StatutesChaptersTable, core.StatutesChaptersShortTable, core.StatutesSectionsTable:
+	case core.StatutesChaptersTable, core.StatutesChaptersShortTable, core.StatutesSectionsTable, core.RulesChaptersTable, core.RulesPartsTable:
 		// extract urls
 		logger.Info("found page kind %v, extracting urls", pageKind)
 		urls, err := scraper.ExtractURLs(strings.NewReader(contents), pageKind)
@@ -63,6 +63,59 @@ func ScrapeRawPage(ctx context.Context, objectKey string, rawDataStore core.RawD
 				return fmt.Errorf("error on putting chunk into chunk store: %v", err)
 			}
 		}
+
+		// put the statutes each chunk references into the reference graph
+		for chunkID, referencedChunkIDs := range helpers.Statute2References(statute) {
+			logger.Info("putting references of chunkID=%s, referencedChunkIDs=%v", chunkID, referencedChunkIDs)
+			if err := referenceGraph.PutReferences(ctx, chunkID, referencedChunkIDs); err != nil {
+				return fmt.Errorf("error on putting references into reference graph: %v", err)
+			}
+		}
+
+		// replace the terms defined by the statute in the definitions of its chapter, clearing the removed ones
+		if len(statute.Title) > 0 {
+			definitions := helpers.Statute2Definitions(statute)
+			logger.Info("putting definitions of chapter=%s, section=%s, definitions=%v", statute.Chapter, statute.Section, definitions)
+			if err := definitionStore.PutDefinitions(ctx, statute.Chapter, statute.Section, definitions); err != nil {
+				return fmt.Errorf("error on putting definitions into definition store: %v", err)
+			}
+		}
+	case core.Rules:
+		// extract rule
+		logger.Info("found page kind %v, extracting rule", pageKind)
+		rule, err := scraper.ExtractRule(strings.NewReader(contents))
+		if err != nil {
+			return fmt.Errorf("error on extracting rule: %v", err)
+		}
+		if len(rule.Title) == 0 {
+			logger.Info("rule is empty, skipping...")
+		}
+
+		// put subpart chunks into data store
+		for _, chunk := range helpers.Rule2SubpartChunks(rule) {
+			logger.Info("putting chunk in data store, chunk=%s", chunk)
+			if err := chunksDataStore.PutChunk(ctx, chunk); err != nil {
+				return fmt.Errorf("error on putting chunk into chunk store: %v", err)
+			}
+		}
+
+		// put the statutes and rules each chunk references into the reference graph
+		for chunkID, referencedChunkIDs := range helpers.Rule2References(rule) {
+			logger.Info("putting references of chunkID=%s, referencedChunkIDs=%v", chunkID, referencedChunkIDs)
+			if err := referenceGraph.PutReferences(ctx, chunkID, referencedChunkIDs); err != nil {
+				return fmt.Errorf("error on putting references into reference graph: %v", err)
+			}
+		}
+
+		// replace the terms defined by the rule in the definitions of its chapter, apart from the statutes chapters
+		if len(rule.Title) > 0 {
+			definitions := helpers.Rule2Definitions(rule)
+			chapter := helpers.RuleChunkIDPrefix + rule.Chapter
+			logger.Info("putting definitions of chapter=%s, part=%s, definitions=%v", chapter, rule.Part, definitions)
+			if err := definitionStore.PutDefinitions(ctx, chapter, rule.Part, definitions); err != nil {
+				return fmt.Errorf("error on putting definitions into definition store: %v", err)
+			}
+		}
 	default:
 		return fmt.Errorf("unsupported page kind: %v", pageKind)
 	}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"fmt"
+)
+
+// replies texted around the parts of long answers
+const (
+	moreHint           = "Reply MORE for more."
+	summaryHint        = "Reply MORE for the full answer."
+	nothingMoreMessage = "There is nothing more to send, text a new question."
+)
+
+// sendMore texts the next parts of the last answer.
+func sendMore(ctx context.Context, recipient core.Recipient, smsOptions core.SMSOptions, conversationStore core.ConversationStore, comms core.Comms, logger core.Logger) error {
+	logger.Info("getting pending parts with recipient=%s", recipient.Key())
+	parts, err := conversationStore.GetPendingParts(ctx, recipient.Key())
+	if err != nil {
+		return fmt.Errorf("error getting pending parts: %v", err)
+	}
+	if len(parts) == 0 {
+		logger.Info("no pending parts for recipient=%s", recipient.Key())
+		return sendMessage(ctx, recipient, nothingMoreMessage, comms)
+	}
+	return sendParts(ctx, recipient, parts, smsOptions, conversationStore, comms, logger)
+}
+
+// sendReply sends the message formatted for the recipient's channel, split into SMS parts when segmenting
+// on SMS. Parts that are held back are kept in the conversation store until the user replies MORE.
+func sendReply(ctx context.Context, recipient core.Recipient, message string, smsOptions core.SMSOptions, conversationStore core.ConversationStore, comms core.Comms, logger core.Logger) error {
+	message = helpers.FormatForChannel(message, recipient.Channel)
+	if recipient.Channel != core.ChannelSMS || !smsOptions.Segment {
+		return sendMessage(ctx, recipient, message, comms)
+	}
+
+	message = helpers.NormalizeSMS(message)
+	reserve := 0
+	if smsOptions.PartsPerReply > 0 {
+		reserve = len("\n" + moreHint)
+	}
+	parts := helpers.SplitSMS(message, reserve)
+	logger.Info("split message into %d parts", len(parts))
+	if smsOptions.SummaryFirst && len(parts) > 1 {
+		summary := helpers.SMSSummary(message, len("\n"+summaryHint)) + "\n" + summaryHint
+		logger.Info("sending summary to recipient=%s, holding back %d parts", recipient.Key(), len(parts))
+		if err := comms.SendMessage(ctx, recipient, summary); err != nil {
+			return fmt.Errorf("error on send summary: %v", err)
+		}
+		if err := conversationStore.SetPendingParts(ctx, recipient.Key(), parts); err != nil {
+			return fmt.Errorf("error setting pending parts: %v", err)
+		}
+		return nil
+	}
+	return sendParts(ctx, recipient, parts, smsOptions, conversationStore, comms, logger)
+}
+
+// sendParts texts up to PartsPerReply parts and keeps the rest as pending.
+func sendParts(ctx context.Context, recipient core.Recipient, parts []string, smsOptions core.SMSOptions, conversationStore core.ConversationStore, comms core.Comms, logger core.Logger) error {
+	toSend := append(make([]string, 0, len(parts)), parts...)
+	pending := make([]string, 0)
+	if smsOptions.PartsPerReply > 0 && len(toSend) > smsOptions.PartsPerReply {
+		pending = toSend[smsOptions.PartsPerReply:]
+		toSend = toSend[:smsOptions.PartsPerReply]
+		toSend[len(toSend)-1] += "\n" + moreHint
+	}
+	for i, part := range toSend {
+		logger.Info("sending part %d of %d to recipient=%s", i+1, len(toSend), recipient.Key())
+		if err := comms.SendMessage(ctx, recipient, part); err != nil {
+			return fmt.Errorf("error on send part %d: %v", i+1, err)
+		}
+	}
+	logger.Info("holding back %d parts for recipient=%s", len(pending), recipient.Key())
+	if err := conversationStore.SetPendingParts(ctx, recipient.Key(), pending); err != nil {
+		return fmt.Errorf("error setting pending parts: %v", err)
+	}
+	return nil
+}
@@ -8,7 +8,9 @@ import (
 
 func Index(ctx context.Context, chunkID string, chunksDataStore core.ChunksDataStore, vectorizer core.Vectorizer, searchIndex core.SearchIndex, logger core.Logger) error {
 	logger.Info("initializing index")
-	searchIndex.SetupIndexIfNecessary(ctx)
+	if err := searchIndex.SetupIndexIfNecessary(ctx); err != nil {
+		return fmt.Errorf("error on setting up index: %v", err)
+	}
 
 	logger.Info("getting chunk with chunkID=%s", chunkID)
 	chunk, err := chunksDataStore.GetChunk(ctx, chunkID)
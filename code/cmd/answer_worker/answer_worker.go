@@ -0,0 +1,148 @@
+package main
+
+import (
+	"code/application"
+	"code/core"
+	"code/infrastructure/factories"
+	"code/infrastructure/loggers"
+	"code/infrastructure/settings"
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+var (
+	agent           core.Agent
+	chunkStore      core.ChunksDataStore
+	referenceGraph  core.ReferenceGraph
+	definitionStore core.DefinitionStore
+	conversations   core.ConversationStore
+	optOuts         core.OptOutStore
+	rateLimiter     core.RateLimiter
+	dedupStore      core.DedupStore
+	indexer         core.SearchIndex
+	logger          core.Logger
+	vectorizer      core.Vectorizer
+	comm            core.Comms
+	inboundQueue    core.Queue
+	citationPolicy  core.CitationPolicy
+	smsOptions      core.SMSOptions
+)
+
+func init() {
+	ctx := context.Background()
+
+	var err error
+
+	log.Println("initializing settings")
+	mySettings, err := settings.GetSettings()
+	if err != nil {
+		log.Fatalf("error on get settings: %v\n", err)
+	}
+
+	log.Println("initializing loggers")
+	logger, err = loggers.InitializeMultiLogger(mySettings.DoLogToStdout)
+	if err != nil {
+		log.Fatalf("error on initializing multilogger: %v\n", err)
+	}
+	citationPolicy = core.CitationPolicy(mySettings.CitationPolicy)
+	smsOptions = core.SMSOptions{Segment: mySettings.SMSSegment, PartsPerReply: mySettings.SMSPartsPerReply, SummaryFirst: mySettings.SMSSummaryFirst}
+
+	logger.Info("initializing vectorizer")
+	vectorizer, err = factories.InitializeVectorizer(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing vectorizer: %v", err)
+	}
+
+	logger.Info("initializing agent")
+	agent, err = factories.InitializeAgent(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing agent: %v", err)
+	}
+
+	logger.Info("initializing data store")
+	chunkStore, err = factories.InitializeDataStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing data store: %v", err)
+	}
+
+	logger.Info("initializing reference graph")
+	referenceGraph, err = factories.InitializeReferenceGraph(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing reference graph: %v", err)
+	}
+
+	logger.Info("initializing definition store")
+	definitionStore, err = factories.InitializeDefinitionStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing definition store: %v", err)
+	}
+
+	logger.Info("initializing conversation store")
+	conversations, err = factories.InitializeConversationStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing conversation store: %v", err)
+	}
+
+	logger.Info("initializing opt-out store")
+	optOuts, err = factories.InitializeOptOutStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing opt-out store: %v", err)
+	}
+
+	logger.Info("initializing rate limiter")
+	rateLimiter, err = factories.InitializeRateLimiter(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing rate limiter: %v", err)
+	}
+
+	logger.Info("initializing dedup store")
+	dedupStore, err = factories.InitializeDedupStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing dedup store: %v", err)
+	}
+
+	logger.Info("initializing search index")
+	indexer, err = factories.InitializeSearchIndex(ctx, mySettings, logger)
+	if err != nil {
+		logger.Fatal("error on initializing search index: %v", err)
+	}
+
+	logger.Info("initializing comms")
+	comm, err = factories.InitializeComms(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing comms: %v", err)
+	}
+
+	logger.Info("initializing inbound queue")
+	inboundQueue, err = factories.InitializeInboundQueue(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing inbound queue: %v", err)
+	}
+}
+
+func HandleRequest(ctx context.Context, sqsEvent events.SQSEvent) error {
+	for _, record := range sqsEvent.Records {
+		logger.Info("processing record with messageID=%s", record.MessageId)
+		inboundMessage, err := application.ParseInboundMessage(record.Body)
+		if err != nil {
+			// retrying will not fix a malformed message, so it is dropped
+			logger.Error("dropping record with messageID=%s: %v", record.MessageId, err)
+		} else if err := application.HandleInboundMessage(ctx, inboundMessage, citationPolicy, smsOptions, chunkStore, referenceGraph, definitionStore, conversations, optOuts, rateLimiter, dedupStore, agent, indexer, vectorizer, comm, logger); err != nil {
+			return fmt.Errorf("error on handling message with ID=%s in application: %v", inboundMessage.ID, err)
+		}
+		logger.Info("deleting message by handle=%s", record.ReceiptHandle)
+		if err := inboundQueue.DeleteMessageByHandle(ctx, record.ReceiptHandle); err != nil {
+			return fmt.Errorf("error deleting message by handle: %v", err)
+		}
+		logger.Info("done processing record with messageID=%s", record.MessageId)
+	}
+	return nil
+}
+
+func main() {
+	lambda.Start(HandleRequest)
+}
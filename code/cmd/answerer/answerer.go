@@ -4,14 +4,11 @@ import (
 	"code/application"
 	"code/core"
 	"code/infrastructure/comms"
-	"code/infrastructure/indexers"
+	"code/infrastructure/factories"
 	"code/infrastructure/loggers"
 	"code/infrastructure/settings"
-	"code/infrastructure/stores"
-	"code/infrastructure/types"
-	"code/infrastructure/vectorizers"
 	"context"
-	"encoding/json"
+	"encoding/base64"
 	"fmt"
 	"log"
 
@@ -20,12 +17,9 @@ import (
 )
 
 var (
-	agent      core.Agent
-	chunkStore core.ChunksDataStore
-	indexer    core.SearchIndex
-	logger     core.Logger
-	vectorizer core.Vectorizer
-	comm       core.Comms
+	logger       core.Logger
+	inboundQueue core.Queue
+	verifier     *comms.SinchWebhookVerifier
 )
 
 var internalErrorResponse = events.APIGatewayProxyResponse{
@@ -33,6 +27,21 @@ var internalErrorResponse = events.APIGatewayProxyResponse{
 	Body:       "internal error",
 }
 
+var unauthorizedResponse = events.APIGatewayProxyResponse{
+	StatusCode: 401,
+	Body:       "unauthorized",
+}
+
+var badRequestResponse = events.APIGatewayProxyResponse{
+	StatusCode: 400,
+	Body:       "bad request",
+}
+
+var successResponse = events.APIGatewayProxyResponse{
+	StatusCode: 200,
+	Body:       "success!",
+}
+
 func init() {
 	ctx := context.Background()
 
@@ -50,54 +59,52 @@ func init() {
 		log.Fatalf("error on initializing multilogger: %v\n", err)
 	}
 
-	logger.Info("initializing bedrock helpers")
-	var bedrockHelper *vectorizers.BedrockHelper
-	bedrockHelper, err = vectorizers.InitializeBedrockHelper(ctx, mySettings.EmbeddingModelID, mySettings.FoundationModelID, mySettings.ContextTimeout)
+	logger.Info("initializing inbound queue")
+	inboundQueue, err = factories.InitializeInboundQueue(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error on initializing bedrock helper: %v", err)
+		logger.Fatal("error on initializing inbound queue: %v", err)
 	}
-	vectorizer = bedrockHelper
-	agent = bedrockHelper
 
-	logger.Info("initializing s3 helpers")
-	chunkStore, err = stores.InitializeS3Helper(ctx, mySettings.MainBucketName, mySettings.RawPathPrefix, mySettings.ChunkPathPrefix, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	logger.Info("initializing sinch webhook verifier")
+	verifier, err = comms.InitializeSinchWebhookVerifier(mySettings.SinchWebhookSecret, mySettings.SinchWebhookReplayWindow)
 	if err != nil {
-		logger.Fatal("error on initializing s3 helpers: %v", err)
+		logger.Fatal("error on initializing sinch webhook verifier: %v", err)
 	}
-
-	logger.Info("initializing opensearch helpers")
-	indexer, err = indexers.InitializeOpenSearchIndexerHelper(ctx, mySettings.OpensearchUsername, mySettings.OpensearchPassword, mySettings.OpensearchDomain, mySettings.DoAllowOpensearchInsecure, mySettings.OpensearchIndexName, mySettings.ContextTimeout, logger)
-	if err != nil {
-		logger.Fatal("error on initializing opensearch indexer helper: %v", err)
-	}
-
-	logger.Info("initializing comms helpers")
-	comm, err = comms.InitializeSinchHelper(ctx, mySettings.SinchAPIToken, mySettings.SinchServiceID, mySettings.SinchVirtualPhoneNumber, mySettings.ContextTimeout)
-	if err != nil {
-		logger.Fatal("error on initializing sinch helper: %v", err)
-	}
-
 }
 
+// HandleRequest only validates and queues the message, it is answered by the answer worker so Sinch gets
+// its response before it times out and retries.
 func HandleRequest(ctx context.Context, payload events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	var err error
 	logger.Info("processing payload %+v", payload)
-	var whp types.SinchWebhookPayload
-	if err := json.Unmarshal([]byte(payload.Body), &whp); err != nil {
-		err = fmt.Errorf("error on unmarshalling json: %v", err)
-		return internalErrorResponse, err
+	body := payload.Body
+	if payload.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			logger.Warn("rejecting payload with invalid base64 body: %v", err)
+			return badRequestResponse, nil
+		}
+		body = string(decoded)
+	}
+	// reject before spending tokens or texts on callbacks Sinch did not send
+	if err := verifier.Verify(payload.Headers, body); err != nil {
+		logger.Warn("rejecting payload with invalid signature: %v", err)
+		return unauthorizedResponse, nil
+	}
+	inboundMessage, isText, err := comms.ParseSinchInboundMessage(body)
+	if err != nil {
+		logger.Warn("rejecting payload: %v", err)
+		return badRequestResponse, nil
+	}
+	if !isText {
+		logger.Info("ignoring payload without a text message")
+		return successResponse, nil
 	}
-	prompt := whp.Message.ContactMessage.TextMessage.Text
-	phoneNumber := whp.Message.ChannelIdentity.Identity
-	if err = application.Answer(ctx, prompt, phoneNumber, chunkStore, agent, indexer, vectorizer, comm, logger); err != nil {
-		err = fmt.Errorf("error on getting answer from application: %v", err)
+	if err := application.EnqueueMessage(ctx, inboundMessage, inboundQueue, logger); err != nil {
+		err = fmt.Errorf("error on enqueueing message in application: %v", err)
 		return internalErrorResponse, err
 	}
 	logger.Info("done processing payload %+v", payload)
-	return events.APIGatewayProxyResponse{
-		StatusCode: 200,
-		Body:       "success!",
-	}, nil
+	return successResponse, nil
 }
 
 func main() {
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"code/application"
+	"code/core"
+	"code/helpers"
+	"code/infrastructure/factories"
+	"code/infrastructure/loggers"
+	"code/infrastructure/settings"
+	"code/infrastructure/types"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+const (
+	statutesPathPrefix = "/v1/statutes/"
+	maxPromptLength    = 1000
+	maxRequestBytes    = 16 * 1024
+	readHeaderTimeout  = 10 * time.Second
+)
+
+var (
+	agent           core.Agent
+	chunkStore      core.ChunksDataStore
+	referenceGraph  core.ReferenceGraph
+	definitionStore core.DefinitionStore
+	indexer         core.SearchIndex
+	logger          core.Logger
+	vectorizer      core.Vectorizer
+	citationPolicy  core.CitationPolicy
+)
+
+func init() {
+	ctx := context.Background()
+
+	var err error
+
+	log.Println("initializing settings")
+	mySettings, err := settings.GetSettings()
+	if err != nil {
+		log.Fatalf("error on get settings: %v\n", err)
+	}
+
+	log.Println("initializing loggers")
+	logger, err = loggers.InitializeMultiLogger(mySettings.DoLogToStdout)
+	if err != nil {
+		log.Fatalf("error on initializing multilogger: %v\n", err)
+	}
+	citationPolicy = core.CitationPolicy(mySettings.CitationPolicy)
+
+	logger.Info("initializing vectorizer")
+	vectorizer, err = factories.InitializeVectorizer(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing vectorizer: %v", err)
+	}
+
+	logger.Info("initializing agent")
+	agent, err = factories.InitializeAgent(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing agent: %v", err)
+	}
+
+	logger.Info("initializing data store")
+	chunkStore, err = factories.InitializeDataStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing data store: %v", err)
+	}
+
+	logger.Info("initializing reference graph")
+	referenceGraph, err = factories.InitializeReferenceGraph(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing reference graph: %v", err)
+	}
+
+	logger.Info("initializing definition store")
+	definitionStore, err = factories.InitializeDefinitionStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing definition store: %v", err)
+	}
+
+	logger.Info("initializing search index")
+	indexer, err = factories.InitializeSearchIndex(ctx, mySettings, logger)
+	if err != nil {
+		logger.Fatal("error on initializing search index: %v", err)
+	}
+}
+
+func newHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/ask", handleAsk)
+	mux.HandleFunc(statutesPathPrefix, handleStatute)
+	mux.HandleFunc("/v1/search", handleSearch)
+	return mux
+}
+
+// handleAsk answers POST /v1/ask {"prompt": "..."} with the answer, its citations and the chunks it was
+// generated from.
+func handleAsk(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var request types.AskRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	prompt := strings.TrimSpace(request.Prompt)
+	if len(prompt) == 0 || utf8.RuneCountInString(prompt) > maxPromptLength {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("prompt must be 1 to %d characters", maxPromptLength))
+		return
+	}
+	result, err := application.Ask(r.Context(), prompt, citationPolicy, chunkStore, referenceGraph, definitionStore, agent, indexer, vectorizer, logger)
+	if err != nil {
+		logger.Error("error on asking in application: %v", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	citations := make([]types.APICitation, 0, len(result.Answer.Citations))
+	for _, citation := range result.Answer.Citations {
+		citations = append(citations, types.APICitation{ChunkID: citation.ChunkID, URL: citation.URL, IsVerified: citation.IsVerified})
+	}
+	writeJSON(w, http.StatusOK, types.AskResponse{Answer: result.Answer.Text, Citations: citations, Chunks: newAPIChunks(result.Chunks)})
+}
+
+// handleStatute answers GET /v1/statutes/{chapter}.{section} with the chunks of the section.
+func handleStatute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	statute := strings.TrimPrefix(r.URL.Path, statutesPathPrefix)
+	chapter, section, ok := helpers.ParseStatuteNumber(statute)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "statute must be {chapter}.{section}, e.g. 86B.33")
+		return
+	}
+	chunks, err := application.GetStatute(r.Context(), chapter, section, chunkStore, logger)
+	if err != nil {
+		logger.Error("error on getting statute in application: %v", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if len(chunks) == 0 {
+		writeError(w, http.StatusNotFound, "statute not found")
+		return
+	}
+	scoredChunks := make([]core.ScoredChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		scoredChunks = append(scoredChunks, core.ScoredChunk{Chunk: chunk})
+	}
+	writeJSON(w, http.StatusOK, types.StatuteResponse{Statute: statute, URL: helpers.ChunkIDToURL(statute), Chunks: newAPIChunks(scoredChunks)})
+}
+
+// handleSearch answers GET /v1/search?q= with the best matching chunks and their scores, no answer is
+// generated.
+func handleSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if len(query) == 0 || utf8.RuneCountInString(query) > maxPromptLength {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("q must be 1 to %d characters", maxPromptLength))
+		return
+	}
+	scoredChunks, err := application.Search(r.Context(), query, chunkStore, indexer, vectorizer, logger)
+	if err != nil {
+		logger.Error("error on searching in application: %v", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, types.SearchResponse{Query: query, Chunks: newAPIChunks(scoredChunks)})
+}
+
+func newAPIChunks(scoredChunks []core.ScoredChunk) []types.APIChunk {
+	apiChunks := make([]types.APIChunk, 0, len(scoredChunks))
+	for _, scoredChunk := range scoredChunks {
+		apiChunks = append(apiChunks, types.APIChunk{
+			ID:      scoredChunk.ID,
+			Body:    scoredChunk.Body,
+			URL:     helpers.ChunkIDToURL(scoredChunk.ID),
+			Score:   scoredChunk.Score,
+			IsCited: scoredChunk.IsCited,
+		})
+	}
+	return apiChunks
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("error on writing response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, types.ErrorResponse{Error: message})
+}
+
+// HandleRequest serves an API Gateway HTTP API request with the same handler as the server.
+func HandleRequest(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	logger.Info("processing %s %s", request.RequestContext.HTTP.Method, request.RawPath)
+	var body io.Reader = strings.NewReader(request.Body)
+	if request.IsBase64Encoded {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	target := request.RawPath
+	if len(request.RawQueryString) > 0 {
+		target = target + "?" + request.RawQueryString
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, request.RequestContext.HTTP.Method, target, body)
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, fmt.Errorf("error on creating request: %v", err)
+	}
+	for name, value := range request.Headers {
+		httpRequest.Header.Set(name, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	newHandler().ServeHTTP(recorder, httpRequest)
+	headers := make(map[string]string)
+	for name := range recorder.Header() {
+		headers[name] = recorder.Header().Get(name)
+	}
+	return events.APIGatewayV2HTTPResponse{StatusCode: recorder.Code, Headers: headers, Body: recorder.Body.String()}, nil
+}
+
+func main() {
+	// the lambda runtime sets AWS_LAMBDA_RUNTIME_API, anywhere else the api is served over http
+	if len(os.Getenv("AWS_LAMBDA_RUNTIME_API")) > 0 {
+		lambda.Start(HandleRequest)
+		return
+	}
+	addr := flag.String("addr", ":8080", "address to serve the api on")
+	flag.Parse()
+	server := &http.Server{Addr: *addr, Handler: newHandler(), ReadHeaderTimeout: readHeaderTimeout}
+	logger.Info("serving api on addr=%s", *addr)
+	if err := server.ListenAndServe(); err != nil {
+		logger.Fatal("error on serving api: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"code/application"
+	"code/core"
+	"code/infrastructure/comms"
+	"code/infrastructure/factories"
+	"code/infrastructure/loggers"
+	"code/infrastructure/memory"
+	"code/infrastructure/settings"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// typed instead of a question to start a new conversation
+const newConversationCommand = "/new"
+
+// questions asked in the terminal are not worth limiting
+var chatRateLimits = core.RateLimits{Burst: 1000, PerHour: 1000}
+
+// the chat keeps the model's markdown, like the web chat
+var chatRecipient = core.Recipient{Channel: core.ChannelWeb, Identity: "chat"}
+
+var (
+	agent             core.Agent
+	chunkStore        core.ChunksDataStore
+	referenceGraph    core.ReferenceGraph
+	definitionStore   core.DefinitionStore
+	conversationStore core.ConversationStore
+	rateLimiter       core.RateLimiter
+	indexer           core.SearchIndex
+	logger            core.Logger
+	vectorizer        core.Vectorizer
+	console           core.Comms
+	citationPolicy    core.CitationPolicy
+)
+
+func initialize(ctx context.Context, isVerbose bool, out io.Writer) {
+	var err error
+
+	mySettings, err := settings.GetSettings()
+	if err != nil {
+		log.Fatalf("error on get settings: %v\n", err)
+	}
+	// logs would be mixed with the answers, so they are only printed when asked for
+	if logger, err = loggers.InitializeMultiLogger(isVerbose); err != nil {
+		log.Fatalf("error on initializing multilogger: %v\n", err)
+	}
+	citationPolicy = core.CitationPolicy(mySettings.CitationPolicy)
+
+	if vectorizer, err = factories.InitializeVectorizer(ctx, mySettings); err != nil {
+		log.Fatalf("error on initializing vectorizer: %v\n", err)
+	}
+	if agent, err = factories.InitializeAgent(ctx, mySettings); err != nil {
+		log.Fatalf("error on initializing agent: %v\n", err)
+	}
+	if chunkStore, err = factories.InitializeDataStore(ctx, mySettings); err != nil {
+		log.Fatalf("error on initializing data store: %v\n", err)
+	}
+	if referenceGraph, err = factories.InitializeReferenceGraph(ctx, mySettings); err != nil {
+		log.Fatalf("error on initializing reference graph: %v\n", err)
+	}
+	if definitionStore, err = factories.InitializeDefinitionStore(ctx, mySettings); err != nil {
+		log.Fatalf("error on initializing definition store: %v\n", err)
+	}
+	if indexer, err = factories.InitializeSearchIndex(ctx, mySettings, logger); err != nil {
+		log.Fatalf("error on initializing search index: %v\n", err)
+	}
+	// the conversation only lasts as long as the chat
+	if conversationStore, err = memory.InitializeConversationStore(mySettings.ConversationTTL); err != nil {
+		log.Fatalf("error on initializing conversation store: %v\n", err)
+	}
+	if rateLimiter, err = memory.InitializeRateLimiter(chatRateLimits); err != nil {
+		log.Fatalf("error on initializing rate limiter: %v\n", err)
+	}
+	if console, err = comms.InitializeConsole(out); err != nil {
+		log.Fatalf("error on initializing console: %v\n", err)
+	}
+}
+
+// chat answers every question read from in, printing the answer followed by the chunks it was generated from.
+func chat(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(out, "> ")
+	for scanner.Scan() {
+		prompt := strings.TrimSpace(scanner.Text())
+		switch {
+		case len(prompt) == 0:
+		case prompt == newConversationCommand:
+			if err := conversationStore.DeleteConversation(ctx, chatRecipient.Key()); err != nil {
+				return fmt.Errorf("error on deleting conversation: %v", err)
+			}
+			fmt.Fprintln(out, "started a new conversation")
+		default:
+			result, err := application.AnswerWithResult(ctx, prompt, chatRecipient, citationPolicy, core.SMSOptions{}, chunkStore, referenceGraph, definitionStore, conversationStore, rateLimiter, agent, indexer, vectorizer, console, logger)
+			if err != nil {
+				fmt.Fprintf(out, "error on answer: %v\n", err)
+			} else {
+				printChunks(out, result.Chunks)
+			}
+		}
+		fmt.Fprint(out, "> ")
+	}
+	return scanner.Err()
+}
+
+func printChunks(out io.Writer, scoredChunks []core.ScoredChunk) {
+	if len(scoredChunks) == 0 {
+		fmt.Fprintln(out, "\nno chunks retrieved")
+		return
+	}
+	fmt.Fprintln(out, "\nretrieved chunks:")
+	for _, scoredChunk := range scoredChunks {
+		if scoredChunk.IsCited {
+			fmt.Fprintf(out, "  %-16s cited\n", scoredChunk.ID)
+		} else if scoredChunk.IsContext {
+			fmt.Fprintf(out, "  %-16s context\n", scoredChunk.ID)
+		} else {
+			fmt.Fprintf(out, "  %-16s score=%.4f\n", scoredChunk.ID, scoredChunk.Score)
+		}
+	}
+}
+
+func main() {
+	isVerbose := flag.Bool("verbose", false, "print logs along with the answers")
+	flag.Parse()
+
+	ctx := context.Background()
+	initialize(ctx, *isVerbose, os.Stdout)
+
+	fmt.Printf("ask a question about the statutes, type %s to start a new conversation, ctrl+D to quit\n", newConversationCommand)
+	if err := chat(ctx, os.Stdin, os.Stdout); err != nil {
+		log.Fatalf("error on chat: %v\n", err)
+	}
+}
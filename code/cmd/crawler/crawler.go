@@ -4,10 +4,9 @@ import (
 	"code/application"
 	"code/core"
 	"code/infrastructure/clients"
+	"code/infrastructure/factories"
 	"code/infrastructure/loggers"
-	"code/infrastructure/queues"
 	"code/infrastructure/settings"
-	"code/infrastructure/stores"
 	"code/infrastructure/watchers"
 	"context"
 	"log"
@@ -36,23 +35,23 @@ func Crawl() error {
 		log.Fatalf("error initializing logger: %v\n", err)
 	}
 	log.Println(mySettings)
-	table1, err := stores.InitializeTable1(ctx, mySettings.Table1ARN, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	seenURLStore, err := factories.InitializeSeenURLStore(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error initializing table1: %v", err)
+		logger.Fatal("error initializing seen url store: %v", err)
 	}
-	if urlQueue, err = queues.InitializeSQSHelper(ctx, mySettings.URLSQSARN, mySettings.ContextTimeout, mySettings.LocalEndpoint); err != nil {
+	if urlQueue, err = factories.InitializeURLQueue(ctx, mySettings); err != nil {
 		logger.Fatal("error initializing url queue: %v", err)
 	}
-	rawDataStore, err = stores.InitializeS3Helper(ctx, mySettings.MainBucketName, mySettings.RawPathPrefix, mySettings.ChunkPathPrefix, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	rawDataStore, err = factories.InitializeDataStore(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error initializing s3: %v", err)
+		logger.Fatal("error initializing data store: %v", err)
 	}
 	bgInterruptWatcher := watchers.InitializeBackgroundInterruptWatcher()
 	webClient, err = clients.InitializeHTTPClientHelper()
 	if err != nil {
 		logger.Fatal("error initializing client: %v", err)
 	}
-	if err := application.Crawl(ctx, urlQueue, table1, rawDataStore, webClient, bgInterruptWatcher, logger); err != nil {
+	if err := application.Crawl(ctx, urlQueue, seenURLStore, rawDataStore, webClient, bgInterruptWatcher, logger); err != nil {
 		logger.Fatal("error on crawl: %v", err)
 	}
 	return nil
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bufio"
+	"code/application"
+	"code/core"
+	"code/infrastructure/factories"
+	"code/infrastructure/loggers"
+	"code/infrastructure/settings"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// evalRun is the report of a run along with what it was run against, so runs can be compared over time.
+type evalRun struct {
+	StartedAt        time.Time `json:"started_at"`
+	QuestionsPath    string    `json:"questions_path"`
+	Ks               []int     `json:"ks"`
+	EmbeddingModelID string    `json:"embedding_model_id"`
+	IndexBackend     string    `json:"index_backend"`
+	IndexName        string    `json:"index_name"`
+	LexicalWeight    float64   `json:"lexical_weight"`
+	VectorWeight     float64   `json:"vector_weight"`
+	core.EvalReport
+}
+
+// readQuestions reads the golden set from a .jsonl file with a question per line, or a .yaml/.yml file
+// with a list of questions.
+func readQuestions(path string) ([]core.EvalQuestion, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error on opening questions file: %v", err)
+	}
+	defer file.Close()
+
+	questions := make([]core.EvalQuestion, 0)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl":
+		scanner := bufio.NewScanner(file)
+		for lineNumber := 1; scanner.Scan(); lineNumber++ {
+			line := strings.TrimSpace(scanner.Text())
+			if len(line) == 0 {
+				continue
+			}
+			var question core.EvalQuestion
+			if err := json.Unmarshal([]byte(line), &question); err != nil {
+				return nil, fmt.Errorf("error on unmarshalling line %d: %v", lineNumber, err)
+			}
+			questions = append(questions, question)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error on reading questions file: %v", err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.NewDecoder(file).Decode(&questions); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("error on unmarshalling questions file: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("questions file must be .jsonl, .yaml or .yml, got path=%s", path)
+	}
+	return questions, nil
+}
+
+func parseKs(value string) ([]int, error) {
+	ks := make([]int, 0)
+	for _, part := range strings.Split(value, ",") {
+		k, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || k <= 0 {
+			return nil, fmt.Errorf("k must be a positive integer, got k=%s", part)
+		}
+		ks = append(ks, k)
+	}
+	return ks, nil
+}
+
+func main() {
+	questionsPath := flag.String("questions", "", "golden set of questions with expected chunk ids, .jsonl, .yaml or .yml")
+	ksValue := flag.String("k", "1,3,5,10", "comma separated cutoffs to report recall and nDCG at")
+	outPath := flag.String("out", "", "file to write the json report to, stdout by default")
+	isVerbose := flag.Bool("verbose", false, "print logs")
+	flag.Parse()
+	if len(*questionsPath) == 0 {
+		log.Fatalf("-questions is required\n")
+	}
+	ks, err := parseKs(*ksValue)
+	if err != nil {
+		log.Fatalf("error on parsing -k: %v\n", err)
+	}
+
+	ctx := context.Background()
+	startedAt := time.Now().UTC()
+
+	mySettings, err := settings.GetSettings()
+	if err != nil {
+		log.Fatalf("error on get settings: %v\n", err)
+	}
+	// the report is written to stdout, so logs are only printed when asked for
+	logger, err := loggers.InitializeMultiLogger(*isVerbose)
+	if err != nil {
+		log.Fatalf("error on initializing multilogger: %v\n", err)
+	}
+	vectorizer, err := factories.InitializeVectorizer(ctx, mySettings)
+	if err != nil {
+		log.Fatalf("error on initializing vectorizer: %v\n", err)
+	}
+	indexer, err := factories.InitializeSearchIndex(ctx, mySettings, logger)
+	if err != nil {
+		log.Fatalf("error on initializing search index: %v\n", err)
+	}
+
+	questions, err := readQuestions(*questionsPath)
+	if err != nil {
+		log.Fatalf("error on reading questions: %v\n", err)
+	}
+	report, err := application.Evaluate(ctx, questions, ks, indexer, vectorizer, logger)
+	if err != nil {
+		log.Fatalf("error on evaluating: %v\n", err)
+	}
+
+	run := evalRun{
+		StartedAt:        startedAt,
+		QuestionsPath:    *questionsPath,
+		Ks:               ks,
+		EmbeddingModelID: mySettings.EmbeddingModelID,
+		IndexBackend:     mySettings.IndexBackend,
+		IndexName:        mySettings.OpensearchIndexName,
+		LexicalWeight:    mySettings.OpensearchLexicalWeight,
+		VectorWeight:     mySettings.OpensearchVectorWeight,
+		EvalReport:       report,
+	}
+	var out io.Writer = os.Stdout
+	if len(*outPath) > 0 {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			log.Fatalf("error on creating report file: %v\n", err)
+		}
+		defer file.Close()
+		out = file
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(run); err != nil {
+		log.Fatalf("error on writing report: %v\n", err)
+	}
+}
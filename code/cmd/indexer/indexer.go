@@ -4,13 +4,10 @@ import (
 	"code/application"
 	"code/core"
 	"code/helpers"
-	"code/infrastructure/indexers"
+	"code/infrastructure/factories"
 	"code/infrastructure/loggers"
-	"code/infrastructure/queues"
 	"code/infrastructure/settings"
-	"code/infrastructure/stores"
 	"code/infrastructure/types"
-	"code/infrastructure/vectorizers"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -44,24 +41,24 @@ func init() {
 		log.Fatalf("error initializing logger: %v\n", err)
 	}
 
-	logger.Info("initializing s3 helper")
-	if chunksDataStore, err = stores.InitializeS3Helper(ctx, mySettings.MainBucketName, mySettings.RawPathPrefix, mySettings.ChunkPathPrefix, mySettings.ContextTimeout, mySettings.LocalEndpoint); err != nil {
-		logger.Fatal("error initializing s3 helper: %v", err)
+	logger.Info("initializing data store")
+	if chunksDataStore, err = factories.InitializeDataStore(ctx, mySettings); err != nil {
+		logger.Fatal("error initializing data store: %v", err)
 	}
 
-	logger.Info("initializing sqs helper")
-	if toIndexQueue, err = queues.InitializeSQSHelper(ctx, mySettings.ToIndexSQSARN, mySettings.ContextTimeout, mySettings.LocalEndpoint); err != nil {
-		logger.Fatal("error initializing url queue: %v", err)
+	logger.Info("initializing to-index queue")
+	if toIndexQueue, err = factories.InitializeToIndexQueue(ctx, mySettings); err != nil {
+		logger.Fatal("error initializing to-index queue: %v", err)
 	}
 
-	logger.Info("initializing bedrock helper")
-	if vectorizer, err = vectorizers.InitializeBedrockHelper(ctx, mySettings.EmbeddingModelID, mySettings.FoundationModelID, mySettings.ContextTimeout); err != nil {
-		logger.Fatal("error initializing bedrock helper: %v", err)
+	logger.Info("initializing vectorizer")
+	if vectorizer, err = factories.InitializeVectorizer(ctx, mySettings); err != nil {
+		logger.Fatal("error initializing vectorizer: %v", err)
 	}
 
-	logger.Info("initializing opensearch helper")
-	if searchIndex, err = indexers.InitializeOpenSearchIndexerHelper(ctx, mySettings.OpensearchUsername, mySettings.OpensearchPassword, mySettings.OpensearchDomain, mySettings.DoAllowOpensearchInsecure, mySettings.OpensearchIndexName, mySettings.ContextTimeout, logger); err != nil {
-		logger.Fatal("error initializing opensearch indexer helper: %v", err)
+	logger.Info("initializing search index")
+	if searchIndex, err = factories.InitializeSearchIndex(ctx, mySettings, logger); err != nil {
+		logger.Fatal("error initializing search index: %v", err)
 	}
 }
 
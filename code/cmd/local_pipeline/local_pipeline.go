@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bufio"
+	"code/application"
+	"code/core"
+	"code/infrastructure/clients"
+	"code/infrastructure/loggers"
+	"code/infrastructure/memory"
+	"code/infrastructure/scrapers"
+	"code/infrastructure/watchers"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	rawPathPrefix     = "raw"
+	chunkPathPrefix   = "chunk"
+	localPhoneNumber  = "local"
+	idlePollInterval  = 500 * time.Millisecond
+	channelBufferSize = 64
+	conversationTTL   = time.Hour
+)
+
+// questions typed locally are not worth limiting
+var localRateLimits = core.RateLimits{Burst: 1000, PerHour: 1000}
+
+var (
+	logger            core.Logger
+	urlQueue          *memory.Queue
+	seenURLStore      core.SeenURLStore
+	dataStore         *memory.DataStore
+	referenceGraph    core.ReferenceGraph
+	definitionStore   core.DefinitionStore
+	conversationStore core.ConversationStore
+	optOutStore       core.OptOutStore
+	rateLimiter       core.RateLimiter
+	searchIndex       core.SearchIndex
+	vectorizer        core.Vectorizer
+	agent             core.Agent
+	comms             *memory.Comms
+	scraper           core.MNRevisorStatutesScraper
+)
+
+// pipelineWatcher stops the crawler on ctrl+C or once every stage of the pipeline is idle.
+type pipelineWatcher struct {
+	backgroundWatcher *watchers.BackgroundWatcher
+	isDone            atomic.Bool
+}
+
+func (pw *pipelineWatcher) StartBackgroundWatcher() {
+	pw.backgroundWatcher.StartBackgroundWatcher()
+}
+
+func (pw *pipelineWatcher) IsInterrupted() bool {
+	return pw.isDone.Load() || pw.backgroundWatcher.IsInterrupted()
+}
+
+// notifyingDataStore stands in for the s3 -> eventbridge -> sqs notifications: every raw page or
+// chunk put into the data store is handed to the next stage over a channel.
+type notifyingDataStore struct {
+	*memory.DataStore
+	rawKeys  chan string
+	chunkIDs chan string
+	pending  *atomic.Int64
+}
+
+func (nds *notifyingDataStore) PutTextFile(ctx context.Context, fileName string, body io.Reader) error {
+	if err := nds.DataStore.PutTextFile(ctx, fileName, body); err != nil {
+		return err
+	}
+	nds.pending.Add(1)
+	nds.rawKeys <- nds.GetRawObjectKey(fileName)
+	return nil
+}
+
+func (nds *notifyingDataStore) PutChunk(ctx context.Context, chunk core.Chunk) error {
+	if err := nds.DataStore.PutChunk(ctx, chunk); err != nil {
+		return err
+	}
+	nds.pending.Add(1)
+	nds.chunkIDs <- chunk.ID
+	return nil
+}
+
+// savedPagesURLQueue drops urls whose pages are not saved locally, otherwise the crawler would
+// retry them forever.
+type savedPagesURLQueue struct {
+	*memory.Queue
+	fsClientHelper *clients.FileSystemClientHelper
+}
+
+func (spq *savedPagesURLQueue) SendURL(ctx context.Context, url string) error {
+	if !spq.fsClientHelper.CanGet(url) {
+		logger.Info("url='%s' is not saved locally, skipping...", url)
+		return nil
+	}
+	return spq.Queue.SendURL(ctx, url)
+}
+
+func main() {
+	dir := flag.String("dir", "", "directory of saved revisor html pages")
+	online := flag.Bool("online", false, "crawl revisor.mn.gov for pages linked from the saved pages")
+	flag.Parse()
+	if len(*dir) == 0 {
+		log.Fatalf("-dir is required\n")
+	}
+
+	ctx := context.Background()
+	initialize()
+
+	fsClientHelper, err := clients.InitializeFileSystemClientHelper(*dir)
+	if err != nil {
+		logger.Fatal("error on initializing filesystem client: %v", err)
+	}
+	var webClient core.WebClient = fsClientHelper
+	var scrapedURLQueue core.URLQueue = &savedPagesURLQueue{Queue: urlQueue, fsClientHelper: fsClientHelper}
+	if *online {
+		if webClient, err = clients.InitializeHTTPClientHelper(); err != nil {
+			logger.Fatal("error on initializing http client: %v", err)
+		}
+		scrapedURLQueue = urlQueue
+	}
+
+	seedURLs, err := fsClientHelper.URLs()
+	if err != nil {
+		logger.Fatal("error on listing saved pages: %v", err)
+	}
+	if len(seedURLs) == 0 {
+		logger.Fatal("no saved html pages found in dir='%s'", *dir)
+	}
+	for _, seedURL := range seedURLs {
+		if err := urlQueue.SendURL(ctx, seedURL); err != nil {
+			logger.Fatal("error on sending seed url: %v", err)
+		}
+	}
+
+	if err := runPipeline(ctx, webClient, scrapedURLQueue); err != nil {
+		logger.Fatal("error on running pipeline: %v", err)
+	}
+	logger.Info("pipeline done, indexed %d chunks", len(dataStore.Keys(chunkPathPrefix+"/")))
+
+	if err := answerPrompts(ctx, os.Stdin, os.Stdout); err != nil {
+		logger.Fatal("error on answering prompts: %v", err)
+	}
+}
+
+func initialize() {
+	var err error
+	if logger, err = loggers.InitializeMultiLogger(true); err != nil {
+		log.Fatalf("error initializing logger: %v\n", err)
+	}
+	if urlQueue, err = memory.InitializeQueue(0); err != nil {
+		logger.Fatal("error initializing url queue: %v", err)
+	}
+	if seenURLStore, err = memory.InitializeSeenURLStore(); err != nil {
+		logger.Fatal("error initializing seen url store: %v", err)
+	}
+	if dataStore, err = memory.InitializeDataStore(rawPathPrefix, chunkPathPrefix); err != nil {
+		logger.Fatal("error initializing data store: %v", err)
+	}
+	if referenceGraph, err = memory.InitializeReferenceGraph(); err != nil {
+		logger.Fatal("error initializing reference graph: %v", err)
+	}
+	if definitionStore, err = memory.InitializeDefinitionStore(); err != nil {
+		logger.Fatal("error initializing definition store: %v", err)
+	}
+	if conversationStore, err = memory.InitializeConversationStore(conversationTTL); err != nil {
+		logger.Fatal("error initializing conversation store: %v", err)
+	}
+	if searchIndex, err = memory.InitializeSearchIndex(0); err != nil {
+		logger.Fatal("error initializing search index: %v", err)
+	}
+	if vectorizer, err = memory.InitializeVectorizer(0); err != nil {
+		logger.Fatal("error initializing vectorizer: %v", err)
+	}
+	if agent, err = memory.InitializeAgent(); err != nil {
+		logger.Fatal("error initializing agent: %v", err)
+	}
+	if optOutStore, err = memory.InitializeOptOutStore(); err != nil {
+		logger.Fatal("error initializing opt-out store: %v", err)
+	}
+	if rateLimiter, err = memory.InitializeRateLimiter(localRateLimits); err != nil {
+		logger.Fatal("error initializing rate limiter: %v", err)
+	}
+	if comms, err = memory.InitializeComms(); err != nil {
+		logger.Fatal("error initializing comms: %v", err)
+	}
+	if scraper, err = scrapers.InitializeScraper(); err != nil {
+		logger.Fatal("error initializing scraper: %v", err)
+	}
+}
+
+// runPipeline crawls, scrapes and indexes until every queue and channel is drained.
+func runPipeline(ctx context.Context, webClient core.WebClient, scrapedURLQueue core.URLQueue) error {
+	var pending atomic.Int64
+	store := &notifyingDataStore{
+		DataStore: dataStore,
+		rawKeys:   make(chan string, channelBufferSize),
+		chunkIDs:  make(chan string, channelBufferSize),
+		pending:   &pending,
+	}
+	watcher := &pipelineWatcher{backgroundWatcher: watchers.InitializeBackgroundInterruptWatcher()}
+	watcher.StartBackgroundWatcher()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer close(store.chunkIDs)
+		for objectKey := range store.rawKeys {
+			if err := application.ScrapeRawPage(ctx, objectKey, store, store, referenceGraph, definitionStore, scrapedURLQueue, scraper, logger); err != nil {
+				logger.Error("error on scraping raw page: %v", err)
+			}
+			pending.Add(-1)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for chunkID := range store.chunkIDs {
+			if err := application.Index(ctx, chunkID, store, vectorizer, searchIndex, logger); err != nil {
+				logger.Error("error on indexing chunk: %v", err)
+			}
+			pending.Add(-1)
+		}
+	}()
+	go func() {
+		for !watcher.IsInterrupted() {
+			time.Sleep(idlePollInterval)
+			if urlQueue.Len() == 0 && pending.Load() == 0 {
+				watcher.isDone.Store(true)
+			}
+		}
+	}()
+
+	err := application.Crawl(ctx, urlQueue, seenURLStore, store, webClient, watcher, logger)
+	close(store.rawKeys)
+	wg.Wait()
+	if err != nil {
+		return fmt.Errorf("error on crawl: %v", err)
+	}
+	return nil
+}
+
+func answerPrompts(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(out, "> ")
+	for scanner.Scan() {
+		prompt := strings.TrimSpace(scanner.Text())
+		if len(prompt) > 0 {
+			sentCount := len(comms.Messages())
+			if err := application.HandleMessage(ctx, prompt, core.Recipient{Channel: core.ChannelSMS, Identity: localPhoneNumber}, core.CitationPolicyAnnotate, core.SMSOptions{}, dataStore, referenceGraph, definitionStore, conversationStore, optOutStore, rateLimiter, agent, searchIndex, vectorizer, comms, logger); err != nil {
+				logger.Error("error on handle message: %v", err)
+			} else {
+				for _, message := range comms.Messages()[sentCount:] {
+					fmt.Fprintln(out, message.Body)
+				}
+			}
+		}
+		fmt.Fprint(out, "> ")
+	}
+	return scanner.Err()
+}
@@ -3,11 +3,10 @@ package main
 import (
 	"code/application"
 	"code/core"
+	"code/infrastructure/factories"
 	"code/infrastructure/loggers"
-	"code/infrastructure/queues"
 	"code/infrastructure/scrapers"
 	"code/infrastructure/settings"
-	"code/infrastructure/stores"
 	"code/infrastructure/types"
 	"context"
 	"encoding/json"
@@ -18,12 +17,14 @@ import (
 )
 
 var (
-	urlQueue       core.URLQueue
-	rawEventsQueue core.RawEventsQueue
-	logger         core.Logger
-	rawStore       core.RawDataStore
-	chunksStore    core.ChunksDataStore
-	scraper        core.MNRevisorStatutesScraper
+	urlQueue        core.URLQueue
+	rawEventsQueue  core.RawEventsQueue
+	logger          core.Logger
+	rawStore        core.RawDataStore
+	chunksStore     core.ChunksDataStore
+	referenceGraph  core.ReferenceGraph
+	definitionStore core.DefinitionStore
+	scraper         core.MNRevisorStatutesScraper
 )
 
 func init() {
@@ -39,22 +40,32 @@ func init() {
 		log.Fatalf("error on initialize multilogger: %v\n", err)
 	}
 
-	urlQueue, err = queues.InitializeSQSHelper(ctx, mySettings.URLSQSARN, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	urlQueue, err = factories.InitializeURLQueue(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error on initialize-sqs: %v", err)
+		logger.Fatal("error on initializing url queue: %v", err)
 	}
 
-	rawEventsQueue, err = queues.InitializeSQSHelper(ctx, mySettings.RawEventsSQSARN, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	rawEventsQueue, err = factories.InitializeRawEventsQueue(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error on initialize-sqs: %v", err)
+		logger.Fatal("error on initializing raw-events queue: %v", err)
 	}
 
-	s3Helper, err := stores.InitializeS3Helper(ctx, mySettings.MainBucketName, mySettings.RawPathPrefix, mySettings.ChunkPathPrefix, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	dataStore, err := factories.InitializeDataStore(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error on initializing s3-helper: %v", err)
+		logger.Fatal("error on initializing data store: %v", err)
+	}
+	rawStore = dataStore
+	chunksStore = dataStore
+
+	referenceGraph, err = factories.InitializeReferenceGraph(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing reference graph: %v", err)
+	}
+
+	definitionStore, err = factories.InitializeDefinitionStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing definition store: %v", err)
 	}
-	rawStore = s3Helper
-	chunksStore = s3Helper
 
 	scraper, err = scrapers.InitializeScraper()
 	if err != nil {
@@ -69,7 +80,7 @@ func HandleRequest(ctx context.Context, sqsEvent events.SQSEvent) error {
 		logger.Info("processing", record)
 		var event types.S3EventMessage
 		json.Unmarshal([]byte(record.Body), &event)
-		err = application.ScrapeRawPage(ctx, event.Detail.Object.Key, rawStore, chunksStore, urlQueue, scraper, logger)
+		err = application.ScrapeRawPage(ctx, event.Detail.Object.Key, rawStore, chunksStore, referenceGraph, definitionStore, urlQueue, scraper, logger)
 		if err != nil {
 			logger.Fatal("error on scraping raw page: %v", err)
 		}
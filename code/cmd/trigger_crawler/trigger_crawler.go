@@ -3,10 +3,9 @@ package main
 import (
 	"code/application"
 	"code/core"
+	"code/infrastructure/factories"
 	"code/infrastructure/loggers"
-	"code/infrastructure/queues"
 	"code/infrastructure/settings"
-	"code/infrastructure/stores"
 	"context"
 	"log"
 )
@@ -35,19 +34,19 @@ func init() {
 		log.Fatalf("error on initialize multilogger: %v\n", err)
 	}
 
-	urlQueue, err = queues.InitializeSQSHelper(ctx, mySettings.URLSQSARN, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	urlQueue, err = factories.InitializeURLQueue(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error on url initialize-sqs: %v", err)
+		logger.Fatal("error on initializing url queue: %v", err)
 	}
 
-	rawEventsQueue, err = queues.InitializeSQSHelper(ctx, mySettings.RawEventsSQSARN, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	rawEventsQueue, err = factories.InitializeRawEventsQueue(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error on raw-events initialize-sqs: %v", err)
+		logger.Fatal("error on initializing raw-events queue: %v", err)
 	}
 
-	seenURLStore, err = stores.InitializeTable1(ctx, mySettings.Table1ARN, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	seenURLStore, err = factories.InitializeSeenURLStore(ctx, mySettings)
 	if err != nil {
-		logger.Fatal("error on initialize-table1: %v", err)
+		logger.Fatal("error on initializing seen url store: %v", err)
 	}
 
 }
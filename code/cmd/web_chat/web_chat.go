@@ -0,0 +1,212 @@
+package main
+
+import (
+	"code/application"
+	"code/core"
+	"code/infrastructure/comms"
+	"code/infrastructure/factories"
+	"code/infrastructure/loggers"
+	"code/infrastructure/settings"
+	"code/infrastructure/types"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// limits on what the web widget may send
+const (
+	maxSessionIDLength = 128
+	maxMessageIDLength = 128
+	maxTextLength      = 1000
+)
+
+var (
+	agent           core.Agent
+	chunkStore      core.ChunksDataStore
+	referenceGraph  core.ReferenceGraph
+	definitionStore core.DefinitionStore
+	conversations   core.ConversationStore
+	optOuts         core.OptOutStore
+	rateLimiter     core.RateLimiter
+	dedupStore      core.DedupStore
+	indexer         core.SearchIndex
+	logger          core.Logger
+	vectorizer      core.Vectorizer
+	citationPolicy  core.CitationPolicy
+)
+
+// the widget is served from another origin, api gateway answers the preflight requests
+var headers = map[string]string{"Content-Type": "application/json", "Access-Control-Allow-Origin": "*"}
+
+var internalErrorResponse = events.APIGatewayProxyResponse{
+	StatusCode: 500,
+	Headers:    headers,
+	Body:       `{"error":"internal error"}`,
+}
+
+var badRequestResponse = events.APIGatewayProxyResponse{
+	StatusCode: 400,
+	Headers:    headers,
+	Body:       `{"error":"bad request"}`,
+}
+
+// a retried request whose message was already answered, or is still being answered, gets no messages
+var duplicateMessageResponse = events.APIGatewayProxyResponse{
+	StatusCode: 409,
+	Headers:    headers,
+	Body:       `{"error":"message already received"}`,
+}
+
+func init() {
+	ctx := context.Background()
+
+	var err error
+
+	log.Println("initializing settings")
+	mySettings, err := settings.GetSettings()
+	if err != nil {
+		log.Fatalf("error on get settings: %v\n", err)
+	}
+
+	log.Println("initializing loggers")
+	logger, err = loggers.InitializeMultiLogger(mySettings.DoLogToStdout)
+	if err != nil {
+		log.Fatalf("error on initializing multilogger: %v\n", err)
+	}
+	citationPolicy = core.CitationPolicy(mySettings.CitationPolicy)
+
+	logger.Info("initializing vectorizer")
+	vectorizer, err = factories.InitializeVectorizer(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing vectorizer: %v", err)
+	}
+
+	logger.Info("initializing agent")
+	agent, err = factories.InitializeAgent(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing agent: %v", err)
+	}
+
+	logger.Info("initializing data store")
+	chunkStore, err = factories.InitializeDataStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing data store: %v", err)
+	}
+
+	logger.Info("initializing reference graph")
+	referenceGraph, err = factories.InitializeReferenceGraph(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing reference graph: %v", err)
+	}
+
+	logger.Info("initializing definition store")
+	definitionStore, err = factories.InitializeDefinitionStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing definition store: %v", err)
+	}
+
+	logger.Info("initializing conversation store")
+	conversations, err = factories.InitializeConversationStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing conversation store: %v", err)
+	}
+
+	logger.Info("initializing opt-out store")
+	optOuts, err = factories.InitializeOptOutStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing opt-out store: %v", err)
+	}
+
+	logger.Info("initializing rate limiter")
+	rateLimiter, err = factories.InitializeRateLimiter(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing rate limiter: %v", err)
+	}
+
+	logger.Info("initializing dedup store")
+	dedupStore, err = factories.InitializeDedupStore(ctx, mySettings)
+	if err != nil {
+		logger.Fatal("error on initializing dedup store: %v", err)
+	}
+
+	logger.Info("initializing search index")
+	indexer, err = factories.InitializeSearchIndex(ctx, mySettings, logger)
+	if err != nil {
+		logger.Fatal("error on initializing search index: %v", err)
+	}
+}
+
+// parseWebChatRequest returns the inbound message of a web chat request sent from the source IP, or an error if
+// it is invalid. The message ID is namespaced by the session, since the widget chooses both, so it never matches
+// the ID of another session or channel in the dedup store, and the sender is rate limited by the source IP.
+func parseWebChatRequest(body string, sourceIP string) (core.InboundMessage, error) {
+	var request types.WebChatRequest
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		return core.InboundMessage{}, fmt.Errorf("error on unmarshalling request: %v", err)
+	}
+	if len(request.SessionID) == 0 || len(request.SessionID) > maxSessionIDLength {
+		return core.InboundMessage{}, fmt.Errorf("session_id must be 1 to %d characters", maxSessionIDLength)
+	}
+	if len(request.MessageID) == 0 || len(request.MessageID) > maxMessageIDLength {
+		return core.InboundMessage{}, fmt.Errorf("message_id must be 1 to %d characters", maxMessageIDLength)
+	}
+	if len(request.Text) == 0 || utf8.RuneCountInString(request.Text) > maxTextLength {
+		return core.InboundMessage{}, fmt.Errorf("text must be 1 to %d characters", maxTextLength)
+	}
+	return core.InboundMessage{
+		ID:         "web:" + request.SessionID + ":" + request.MessageID,
+		From:       core.Recipient{Channel: core.ChannelWeb, Identity: request.SessionID, Source: sourceIP},
+		Text:       request.Text,
+		ReceivedAt: time.Now(),
+	}, nil
+}
+
+// HandleRequest answers a web chat message while the widget waits, the replies are collected in an
+// outbox and returned as markdown in the response.
+func HandleRequest(ctx context.Context, payload events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger.Info("processing web chat request with requestID=%s", payload.RequestContext.RequestID)
+	body := payload.Body
+	if payload.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			logger.Warn("rejecting request with invalid base64 body: %v", err)
+			return badRequestResponse, nil
+		}
+		body = string(decoded)
+	}
+	inboundMessage, err := parseWebChatRequest(body, payload.RequestContext.Identity.SourceIP)
+	if err != nil {
+		logger.Warn("rejecting request: %v", err)
+		return badRequestResponse, nil
+	}
+	outbox, err := comms.InitializeOutbox()
+	if err != nil {
+		return internalErrorResponse, fmt.Errorf("error on initializing outbox: %v", err)
+	}
+	// web chat replies are never segmented, so sms options are left empty
+	isHandled, err := application.HandleInboundMessageOnce(ctx, inboundMessage, citationPolicy, core.SMSOptions{}, chunkStore, referenceGraph, definitionStore, conversations, optOuts, rateLimiter, dedupStore, agent, indexer, vectorizer, outbox, logger)
+	if err != nil {
+		err = fmt.Errorf("error on handling message with ID=%s in application: %v", inboundMessage.ID, err)
+		return internalErrorResponse, err
+	}
+	if !isHandled {
+		return duplicateMessageResponse, nil
+	}
+	responseBody, err := json.Marshal(types.WebChatResponse{Messages: outbox.Messages(), Format: "markdown"})
+	if err != nil {
+		return internalErrorResponse, fmt.Errorf("error on marshalling response: %v", err)
+	}
+	logger.Info("done processing web chat request with requestID=%s", payload.RequestContext.RequestID)
+	return events.APIGatewayProxyResponse{StatusCode: 200, Headers: headers, Body: string(responseBody)}, nil
+}
+
+func main() {
+	lambda.Start(HandleRequest)
+}
@@ -2,30 +2,265 @@ package core
 
 import (
 	"context"
+	"errors"
 	"io"
+	"strings"
+	"time"
 )
 
+// QueueMessage is a message of a queue. Messages sent with the DeduplicationID of a message sent in the
+// last few minutes are dropped, and messages with the same GroupID are received in the order sent.
 type QueueMessage struct {
-	Body    string
-	Handle  string
-	IsEmpty bool
+	Body            string
+	Handle          string
+	IsEmpty         bool
+	DeduplicationID string
+	GroupID         string
 }
 
+// Channel is what a user chats with us over.
+type Channel string
+
+const (
+	ChannelSMS       Channel = "SMS"
+	ChannelWhatsApp  Channel = "WHATSAPP"
+	ChannelMessenger Channel = "MESSENGER"
+	ChannelRCS       Channel = "RCS"
+	ChannelWeb       Channel = "WEB"
+)
+
+// Recipient is a user on a channel. Identity is the phone number on SMS, WhatsApp and RCS, the page-scoped
+// ID on Messenger and the session ID of the web chat. Source is where the messages come from on channels whose
+// identity the sender chooses, e.g. the IP address of the web chat requests, the recipient is rate limited by it.
+type Recipient struct {
+	Channel  Channel `json:"channel"`
+	Identity string  `json:"identity"`
+	Source   string  `json:"source,omitempty"`
+}
+
+// Key identifies the recipient in stores. SMS recipients are keyed by their phone number alone, as they
+// were before there were other channels.
+func (recipient Recipient) Key() string {
+	if recipient.Channel == ChannelSMS {
+		return recipient.Identity
+	}
+	return strings.ToLower(string(recipient.Channel)) + ":" + recipient.Identity
+}
+
+// RateLimitKey identifies the recipient's rate limit bucket, by its source when it has one so that a sender
+// can't get a fresh bucket by choosing another identity.
+func (recipient Recipient) RateLimitKey() string {
+	if len(recipient.Source) == 0 {
+		return recipient.Key()
+	}
+	return strings.ToLower(string(recipient.Channel)) + "-source:" + recipient.Source
+}
+
+// InboundMessage is a text received from a recipient, ID is the message ID given by the channel and
+// ReceivedAt is when the channel received it.
+type InboundMessage struct {
+	ID         string    `json:"id"`
+	From       Recipient `json:"from"`
+	Text       string    `json:"text"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// DedupTTL is how long the IDs of handled inbound messages are remembered, well beyond how long Sinch and
+// the inbound queue retry a message.
+const DedupTTL = 24 * time.Hour
+
 type MNRevisorPageKind int
 
+// ErrNotFound is wrapped by the errors of stores getting an item that is not stored, tell it apart from other
+// errors with errors.Is.
+var ErrNotFound = errors.New("not found")
+
 type Chunk struct {
 	ID   string
 	Body string
 }
 
+// ScoredChunkID is a chunk ID matching a search with its relevance score, higher scores are more relevant.
+type ScoredChunkID struct {
+	ChunkID string
+	Score   float64
+}
+
+// ScoredChunk is a chunk retrieved to answer a prompt. Chunks cited in the prompt are looked up rather than
+// searched, they are marked IsCited and have no score. Chunks added as context of the retrieved ones, e.g. the
+// chunks of the previous turn, are marked IsContext and have no score either.
+type ScoredChunk struct {
+	Chunk
+	Score     float64
+	IsCited   bool
+	IsContext bool
+}
+
+// AskResult is an answer along with the chunks it was generated from.
+type AskResult struct {
+	Answer Answer
+	Chunks []ScoredChunk
+}
+
+// EvalQuestion is a question of the golden set with the chunk IDs that should be retrieved for it. An
+// expected section, e.g. "86B.33", is matched by any of its subdivisions.
+type EvalQuestion struct {
+	Question         string   `json:"question" yaml:"question"`
+	ExpectedChunkIDs []string `json:"expected_chunk_ids" yaml:"expected_chunk_ids"`
+}
+
+// EvalResult is what the search retrieved for a question, Rank is the position of the first relevant
+// chunk starting at 1, 0 if none was retrieved.
+type EvalResult struct {
+	Question         string   `json:"question"`
+	ExpectedChunkIDs []string `json:"expected_chunk_ids"`
+	FoundChunkIDs    []string `json:"found_chunk_ids"`
+	Rank             int      `json:"rank"`
+}
+
+// EvalReport averages the retrieval metrics over the questions, recall and nDCG at each of the cutoffs.
+type EvalReport struct {
+	QuestionCount int             `json:"question_count"`
+	RecallAtK     map[int]float64 `json:"recall_at_k"`
+	NDCGAtK       map[int]float64 `json:"ndcg_at_k"`
+	MRR           float64         `json:"mrr"`
+	Results       []EvalResult    `json:"results"`
+}
+
+// Subdivision is a subdivision of a statute, References lists the statutes its text links to and
+// DefinedTerms the lower case terms it defines, if it is a definition. Content is the whole body, Clauses its
+// labeled paragraphs nested by level, nil when none of them is labeled.
 type Subdivision struct {
-	Number  string
-	Heading string
+	Number       string
+	Heading      string
+	Content      string
+	Clauses      []Clause
+	References   []Reference
+	DefinedTerms []string
+}
+
+// ClauseKind is the level of a labeled paragraph in a subdivision, as the statutes name them, or in a subpart,
+// as the rules name them.
+type ClauseKind string
+
+const (
+	ClauseKindParagraph ClauseKind = "paragraph" // (a)
+	ClauseKindClause    ClauseKind = "clause"    // (1)
+	ClauseKindItem      ClauseKind = "item"      // (i) in statutes, A. in rules
+	ClauseKindSubitem   ClauseKind = "subitem"   // (A) in statutes, (1) in rules
+	ClauseKindUnit      ClauseKind = "unit"      // (a) in rules
+	ClauseKindSubunit   ClauseKind = "subunit"   // (i) in rules
+)
+
+// Clause is a paragraph of a subdivision body and the clauses nested under it. Unlabeled paragraphs, like a
+// table or the text closing a list, have an empty Kind and Label.
+type Clause struct {
+	Kind    ClauseKind
+	Label   string
 	Content string
+	Clauses []Clause
+}
+
+// FormattedLabel returns the label as the text writes it, e.g. "(3)", or "A." for the items of rules.
+func (clause Clause) FormattedLabel() string {
+	if len(clause.Label) == 0 {
+		return ""
+	}
+	if clause.Kind == ClauseKindItem && clause.Label[0] >= 'A' && clause.Label[0] <= 'Z' {
+		return clause.Label + "."
+	}
+	return "(" + clause.Label + ")"
+}
+
+// Reference is a link to a section of the statutes, or to one of its subdivisions when Subdivision is set. Links
+// to a part of the rules have the chapter in the rules chunk ID namespace, e.g. "R7100", and the part as Section.
+type Reference struct {
+	Chapter     string
+	Section     string
+	Subdivision string
+}
+
+// ChunkID returns the chunk ID of the referenced statute, chapter.section[.subd].
+func (reference Reference) ChunkID() string {
+	chunkID := reference.Chapter + "." + reference.Section
+	if len(reference.Subdivision) > 0 {
+		chunkID = chunkID + "." + reference.Subdivision
+	}
+	return chunkID
 }
 
+// Citation is a statute cited in an answer, identified by its chunk ID (chapter.section[.subd]).
+type Citation struct {
+	ChunkID    string
+	URL        string
+	IsVerified bool
+}
+
+// CitationPolicy decides what happens to citations that do not correspond to any known chunk.
+type CitationPolicy string
+
+const (
+	CitationPolicyAnnotate   CitationPolicy = "annotate"
+	CitationPolicyStrip      CitationPolicy = "strip"
+	CitationPolicyRegenerate CitationPolicy = "regenerate"
+)
+
+// ConversationTurn is a prompt, its answer and the chunks retrieved to answer it.
+type ConversationTurn struct {
+	Prompt   string
+	Answer   string
+	ChunkIDs []string
+}
+
+// ConversationMaxTurns is the number of most recent turns kept per conversation.
+const ConversationMaxTurns = 5
+
+// SMSOptions decides how answers are texted. When Segment is set answers are split into numbered parts
+// that each fit in a single SMS. When PartsPerReply is positive only that many parts are sent at once and
+// the rest is sent when the user replies "MORE". When SummaryFirst is set a long answer starts with a
+// one-sentence summary instead.
+type SMSOptions struct {
+	Segment       bool
+	PartsPerReply int
+	SummaryFirst  bool
+}
+
+// RateLimits bound how often questions are answered, and commands other than STOP, START and HELP replied
+// to, which count as answers. Every phone number has a bucket of Burst answers,
+// refilled at PerHour answers an hour, and all phone numbers share DailyBudget answers a UTC day, 0 for
+// no daily budget.
+type RateLimits struct {
+	Burst       int
+	PerHour     float64
+	DailyBudget int
+}
+
+// RateLimitDecision tells whether a question may be answered. IsFirstRejection is only set for the first
+// rejection since the phone number was last allowed, so senders are told about the limit once.
+type RateLimitDecision struct {
+	IsAllowed        bool
+	IsFirstRejection bool
+	IsDailyBudget    bool
+}
+
+// stop reasons of an answer
+const (
+	StopReasonEndTurn   = "end_turn"
+	StopReasonMaxTokens = "max_tokens"
+)
+
+type Answer struct {
+	Text         string
+	Citations    []Citation
+	StopReason   string
+	InputTokens  int
+	OutputTokens int
+}
+
+// VectorDocument is the embedding of a chunk, or of a prompt when searching. Text holds the embedded text.
 type VectorDocument struct {
 	ID     string
+	Text   string
 	Vector []float64
 }
 
@@ -35,13 +270,38 @@ const (
 	StatutesChaptersShortTable
 	StatutesSectionsTable
 	Statutes
+	RulesChaptersTable
+	RulesPartsTable
+	Rules
 )
 
+// SessionLaw is a section of a session law that enacted or amended a statute, e.g. "2023 c 43 art 2 s 116".
+// SpecialSession is the number of the special session the law was passed in, 0 for a regular session, and
+// Article is empty for laws without articles.
+type SessionLaw struct {
+	Year           int
+	SpecialSession int
+	Chapter        string
+	Article        string
+	Section        string
+}
+
+// Statute is a section of the statutes, History lists the session laws that enacted and amended it, oldest first.
 type Statute struct {
 	Chapter      string
 	Section      string
 	Title        string
 	Subdivisions []Subdivision
+	History      []SessionLaw
+}
+
+// Rule is a part of the Minnesota Rules, e.g. 7100.0100 is part 0100 of chapter 7100. Its subparts are parsed
+// like the subdivisions of a statute.
+type Rule struct {
+	Chapter  string
+	Part     string
+	Title    string
+	Subparts []Subdivision
 }
 
 type Logger interface {
@@ -80,6 +340,60 @@ type SeenURLStore interface {
 	DeleteAll(context.Context) error
 }
 
+// ConversationStore keeps the recent turns of the conversation with each recipient, keyed by Recipient.Key,
+// conversations expire after a period without new turns.
+type ConversationStore interface {
+	GetConversation(context.Context, string) ([]ConversationTurn, error)
+	AddConversationTurn(context.Context, string, ConversationTurn) error
+	// parts of the last answer that have not been texted yet
+	GetPendingParts(context.Context, string) ([]string, error)
+	SetPendingParts(context.Context, string, []string) error
+	DeleteConversation(context.Context, string) error
+}
+
+// ReferenceGraph keeps the chunk IDs each chunk references, keyed by chunk ID. A reference to a whole section
+// is kept as the chapter.section of the section.
+type ReferenceGraph interface {
+	PutReferences(context.Context, string, []string) error
+	GetReferences(context.Context, string) ([]string, error)
+}
+
+// Definition is the chunk ID of the subdivision defining a lower case term, for its whole chapter or, when
+// IsSectionScoped, e.g. "For purposes of this section", only for the section of the subdivision.
+type Definition struct {
+	Term            string
+	ChunkID         string
+	IsSectionScoped bool
+}
+
+// DefinitionStore keeps the definitions of the terms of each chapter, by the section defining them.
+// PutDefinitions replaces the definitions of a section of the chapter, so the ones it no longer defines are
+// cleared, and GetDefinitions returns the definitions of every section of the chapter.
+type DefinitionStore interface {
+	PutDefinitions(context.Context, string, string, []Definition) error
+	GetDefinitions(context.Context, string) ([]Definition, error)
+}
+
+// DedupStore remembers the IDs of inbound messages being handled or already handled. PutMessageID returns
+// false if the ID was already put.
+type DedupStore interface {
+	PutMessageID(context.Context, string) (bool, error)
+	DeleteMessageID(context.Context, string) error
+}
+
+// RateLimiter takes an answer from the daily budget and then the recipient's bucket, keyed by
+// Recipient.RateLimitKey, so that a rejection by the daily budget leaves the bucket untouched.
+type RateLimiter interface {
+	Allow(context.Context, string) (RateLimitDecision, error)
+}
+
+// OptOutStore remembers the recipients that replied STOP, keyed by Recipient.Key, they are not texted until
+// they reply START.
+type OptOutStore interface {
+	IsOptedOut(context.Context, string) (bool, error)
+	SetOptedOut(context.Context, string, bool) error
+}
+
 type RawDataStore interface {
 	GetTextFile(context.Context, string) (string, error)
 	PutTextFile(context.Context, string, io.Reader) error
@@ -89,6 +403,8 @@ type RawDataStore interface {
 type ChunksDataStore interface {
 	PutChunk(context.Context, Chunk) error
 	GetChunk(context.Context, string) (Chunk, error)
+	// ListChunkIDs returns the IDs of the stored chunks starting with the prefix
+	ListChunkIDs(context.Context, string) ([]string, error)
 }
 
 type WebClient interface {
@@ -99,6 +415,7 @@ type MNRevisorStatutesScraper interface {
 	GetPageKind(io.Reader) (MNRevisorPageKind, error)
 	ExtractURLs(io.Reader, MNRevisorPageKind) ([]string, error)
 	ExtractStatute(io.Reader) (Statute, error)
+	ExtractRule(io.Reader) (Rule, error)
 }
 
 type Invoker interface {
@@ -107,11 +424,12 @@ type Invoker interface {
 }
 
 type Agent interface {
-	AskWithChunks(context.Context, string, []Chunk) (string, error)
+	AskWithChunks(context.Context, string, []ConversationTurn, []Chunk) (Answer, error)
 }
 
+// Comms sends a message to a recipient over the recipient's channel.
 type Comms interface {
-	SendMessage(context.Context, string, string) error
+	SendMessage(context.Context, Recipient, string) error
 }
 
 type Vectorizer interface {
@@ -123,4 +441,6 @@ type SearchIndex interface {
 	SetupIndexIfNecessary(context.Context) error
 	AddVectorDocument(context.Context, VectorDocument) error
 	FindMatchingChunkIDs(context.Context, VectorDocument) ([]string, error)
+	// FindScoredChunkIDs is FindMatchingChunkIDs with the score of every match
+	FindScoredChunkIDs(context.Context, VectorDocument) ([]ScoredChunkID, error)
 }
@@ -20,13 +20,14 @@ var TestStatute2 = Statute{
 
 var Chunk21 = Chunk{ID: "2b.34", Body: "§ 2b.34: statute without any subdivisions\nnot really a subdivision\n"}
 
-var VectorDocument11 = VectorDocument{ID: "1a.34.1", Vector: []float64{-0.04542897, 0.041699726, 0.016612086, -0.06814346, 0.014408442, -0.0054031657, 0.02644373, -0.0041318326, 0.041191194, 0.040004615, -0.043733858, 0.011865776, 0.0034537883, -0.024748618, -0.07865314, -0.0047463104, -0.023223018, 0.006992332, -0.005678621, 0.0104249315, -0.017374886, 0.023053506, 0.019069996, -0.032037593, 0.058650833, -0.007585621, -0.050853323, -0.024579106, 0.038648527, -0.022544974, 0.055599634, 0.04915821, -0.041191194, -0.041021682, 0.042716794, 0.035597328, 0.019069996, -0.028477862, -0.008390798, 0.008178909, 0.038479015, 0.051531367, -0.0013296026, 0.0075008655, -0.000741611, -0.009492621, -0.024579106, 0.044750925, 0.039665595, 0.027799817, 0.020595597, 0.019239508, -0.04712408, 0.086789675, 0.06610932, -0.018561464, -0.037970483, 0.033902217, 0.008348421, -0.026952261, -0.052548435, -0.02356204, 0.04881919, 0.030511994, -0.012882842, -0.036953416, -0.054243546, -0.05492159, -0.020510841, -0.039665595, -0.04254728, 0.014408442, 0.029494928, -0.01593404, -0.041360702, -0.050853323, 0.12001385, -0.025765684, -0.005678621, 0.033224173, -0.020680351, -0.0017374886, -0.018730974, -0.023053506, 0.042716794, -0.082721405, -0.012713331, 0.023901062, 0.064753234, -0.0007469082, 0.0030511995, -0.004619177, 0.058989856, -0.05288746, 0.022714484, -0.030003462, -0.014238931, -0.07085563, -0.021697419, 0.02644373, -5.065468e-05, -0.0070347097, 0.019832797, 0.01576453, 0.061702035, 0.008306043, -0.02644373, 0.004004699, 0.016188309, 0.06678737, 0.032715637, 0.02661324, -0.03729244, -0.04254728, 0.020849863, -0.03932657, -0.013984664, 0.023901062, 0.030003462, -0.008263665, 0.014747464, -0.02017182, -0.023053506, -0.0048734434, 0.027460795, -0.020002307, 0.058650833, 0.036444884, -0.012459065, 0.056955725, -0.033563193, 0.006441421, 0.060345944, -0.0029240663, -0.051531367, 0.020934619, -0.038648527, -0.023901062, -0.01101822, -0.020510841, 0.023053506, 0.03458026, -0.02508764, 0.044411905, -0.006526177, 0.013730397, -0.018307196, 0.004047077, 0.012628576, -0.0006515582, 0.024240084, 0.07458488, 0.04542897, 0.021612663, 0.013137109, 0.00665331, -0.019239508, -0.029494928, 0.024409596, 0.0048734434, 0.048141148, 0.0013560887, 0.05288746, -0.008178909, -0.0072889766, 0.03407173, -0.044411905, -0.0029240663, -0.026274217, -0.006822821, -0.035088792, -0.015425509, -0.024579106, 0.073228784, -0.015086486, 0.027121773, 0.023731552, -0.029494928, 0.004640366, -0.0036444883, 0.025765684, 0.027799817, -0.019324264, -0.004004699, 0.047463104, 0.0012183608, -0.01940902, -0.005678621, -0.017544396, -0.01559502, 0.027630307, 0.049497236, 0.00550911, 0.025765684, 0.016951108, 0.05458257, -0.00398351, -0.011526753, 0.06848247, 0.071194656, -0.0018540275, -0.020510841, 0.006102399, 0.013984664, -0.0020765108, -0.036444884, 0.0105096875, -0.03458026, -0.007161843, -0.06238008, 0.027630307, 0.0069075767, -0.01576453, -0.015255997, 0.010043532, 0.021019375, 0.03254613, 0.016018797, -0.035088792, 0.033563193, -0.03254613, 0.052209415, -0.016357819, 0.06780443, -0.0034325994, -0.0022672107, -0.029494928, 0.012120042, 0.0003496166, -0.020595597, 0.011187731, 0.033224173, -0.026274217, 0.073228784, 0.0035173548, 0.029155906, -0.03186808, -0.03932657, -0.0027121773, 0.028477862, 0.041699726, -0.06238008, -0.007204221, -0.0027015829, 0.041699726, 0.03576684, 0.0107215755, 0.02017182, 0.007755132, -0.050514303, -0.011357242, -0.020510841, -0.028647372, -0.023053506, 0.0041530216, 0.003686866, -0.05763377, 0.0075008655, 0.03729244, 0.014238931, -0.00627191, 0.008094154, 0.043225326, -0.027799817, -0.008899332, -0.041360702, 0.008136532, -0.016357819, -0.028477862, -0.011696265, 0.051531367, 0.01940902, 0.012289553, -0.017968174, 0.07153368, -0.033393685, 0.05288746, -0.0075008655, -0.005678621, -0.013730397, 0.013899908, -0.038479015, -0.05017528, -0.018307196, 0.021019375, 0.00550911, -0.0036444883, 0.0007575026, -0.012120042, 0.00796702, -0.023053506, -0.041360702, -0.010933464, -0.0009482026, 0.043733858, 0.027460795, -0.061023988, -0.009111221, -0.04983626, -0.011526753, -0.00550911, -0.028138839, -0.044750925, -0.022205953, 0.04678506, -0.008687443, -0.0005164791, -0.034919284, -0.018900486, -0.003877566, -0.01008591, -0.0015573831, 0.0033054661, 0.055599634, 0.031698573, 0.020510841, 0.03813999, 0.009280732, 0.023223018, 0.011102976, -0.006822821, 0.0004741013, 0.00012382255, 0.018730974, -0.018137686, 0.041021682, -0.051531367, -0.011526753, -0.05763377, -0.036953416, 0.005042955, -0.01864622, 0.03576684, 0.026952261, 0.00894171, 0.044411905, 0.044411905, 0.034241237, -0.011357242, -0.012459065, -0.028816884, 0.024070574, -0.027291285, -0.01559502, -0.055938657, 0.0011389026, -0.036614392, 0.007755132, 0.02644373, -0.0052336548, -0.0011706859, -0.014323686, 0.057294745, -0.018307196, 0.020595597, -0.07763608, 0.09899447, 0.029325416, -0.016696842, -0.033393685, -0.01576453, 0.006695688, -0.0024261274, 0.024240084, 0.015510264, -0.022544974, -0.044750925, 0.024240084, -0.042716794, 0.0043437215, -0.05492159, 0.004852255, 0.011950531, 0.0047251214, 0.0011706859, 0.0049158214, 0.008306043, -0.021697419, -0.0055938656, 0.05322648, 0.038648527, -0.0009693915, 0.01593404, -0.035088792, -0.036444884, -0.0017374886, 0.0071194656, -0.041869238, -0.048141148, -0.009916399, -0.016612086, -0.03729244, 0.04712408, 0.017713908, 0.054243546, 0.011272487, 0.006314288, -0.0005164791, 0.030172972, -0.041021682, 0.00741611, 0.078992166, 0.012459065, -0.010933464, -0.03593635, 0.055938657, -0.014238931, -0.014408442, -0.044750925, -0.023053506, 0.028816884, -0.043733858, 0.016951108, -0.00627191, -0.05017528, -0.0029240663, 0.014069419, -0.004470855, -0.020849863, -0.0018222441, -0.01483222, -0.001483222, 0.014662708, 0.00029134718, 0.00894171, -0.009111221, 0.03729244, 0.01330662, 0.027969329, 0.019493774, -0.052209415, 0.05017528, -0.007543243, -0.053565502, 0.024240084, -0.022883996, 0.012289553, -0.003474977, -0.030342484, 0.0052972212, -0.0071194656, 0.047802124, -0.012204798, -0.003496166, 0.025426662, 0.061023988, -0.025765684, 0.028138839, 0.009619754, -0.01254382, 0.008687443, -0.020426085, 0.0007575026, 0.028477862, 0.002034133, -0.0038139992, 0.023053506, 0.012628576, -0.04034364, -0.018985242, -0.02661324, 0.0061447765, -0.00796702, -0.025765684, 0.08170434, 0.016188309, -0.004661555, 0.052209415, 0.009746887, -0.005551488, 0.040513147, 0.022544974, -0.0066109323, 0.017713908, 0.025426662, 0.03729244, 0.015849287, 0.024579106, -0.06916052, 0.037970483, -0.052209415, -0.013899908, 0.032037593, -0.011102976, -0.023053506, 0.051192347, 0.06373616, 0.0018752164, -0.018137686, -0.017035864, -0.03813999, 0.05322648, -0.04407288, -0.005551488, -0.08475554, 0.01652733, 0.022883996, 0.033393685, -0.023731552, 0.055260614, -0.044750925, 0.0023095885, -0.024240084, 0.029325416, 0.06780443, 0.0035597328, 0.0031783327, 0.0049158214, 0.04915821, 0.027121773, -0.006949954, 0.0036444883, 0.035088792, 0.030851018, -0.039496083, -0.00070453045, 0.028647372, 0.017459642, 0.020849863, 0.039496083, 0.028816884, 0.030511994, 0.037122928, -0.025596173, -0.0135608865, -0.020510841, -0.036275372, -0.02661324, -0.013899908, -0.00063036935, -0.037970483, 0.062041055, 0.0058057546, -0.012882842, 0.005932888, -0.034241237, 0.013052354, -0.038987547, -0.008687443, 0.00078398874, -0.0059752655, -0.012882842, 0.017798664, 0.02491813, -0.030851018, -0.029494928, -0.010848709, -0.021866929, 0.0038139992, -0.017459642, 0.050853323, -0.00779751, 0.031020528, -0.038479015, -0.041699726, -0.0031995217, -0.072550744, -0.020934619, 0.010806331, -0.032715637, -0.0044920435, 0.013899908, -0.027969329, -0.027460795, 0.0027969328, -0.022714484, -0.0039623217, -0.0031783327, -0.008856954, -0.030172972, 0.03254613, 0.008856954, -0.043394838, -0.044750925, 0.056277677, -0.022205953, 0.015001731, 0.013899908, 0.003898755, -0.002320183, 0.020595597, -0.026782751, -0.020595597, -0.047463104, -0.027799817, 0.0038139992, 0.007924643, 0.015255997, 0.005000577, -0.015510264, -0.01864622, 0.041699726, -0.042716794, 0.02491813, -0.01788342, -0.005466732, -0.017968174, 0.058311813, 0.0071194656, 0.01483222, -0.0051065213, 0.005000577, 0.013984664, -0.009874021, -0.010340176, -0.014577953, -0.008475554, 0.009619754, -0.033224173, 0.036953416, -0.0049581993, 0.06305812, 0.04983626, -0.031020528, 0.02966444, -0.022205953, 0.018900486, -0.033563193, -0.018222442, 0.019748041, -0.015171242, -0.00741611, -0.017120618, 0.0051277103, -0.018561464, -0.0010594443, -0.056955725, 0.04542897, -0.03576684, 0.01729013, 0.017713908, 0.025426662, 0.02508764, 0.008475554, 0.03729244, -0.031020528, -0.011441998, 0.013984664, -0.02966444, 0.028647372, -0.011357242, 0.010933464, 0.009831643, -0.011102976, 0.028477862, 0.0055938656, 0.048141148, -0.055938657, -0.011187731, 0.008517932, -0.0135608865, 0.018985242, -0.005021766, -0.022714484, -0.056277677, 0.036275372, -0.016273065, -0.019324264, -0.048141148, 0.041869238, 0.008390798, -0.0013296026, 0.044750925, 0.0019493775, -0.017544396, 0.032715637, -0.038987547, -0.020934619, 0.0019281886, 0.043394838, -0.008560309, -0.015255997, 0.026274217, -0.04983626, 0.0049370104, 0.0045344215, 0.0010329582, -7.5485405e-05, 0.0051065213, -0.0024367217, 0.0063990434, -0.022205953, 0.043225326, -0.005466732, 0.03576684, 0.077297054, 0.0025532607, -0.056277677, 0.0046827435, 0.027291285, -0.046446037, -0.007882265, -0.116623625, -0.019324264, 0.009874021, -0.020087063, -0.03932657, -0.020510841, 0.005932888, -0.0038139992, -0.023731552, -0.005000577, -0.040004615, 0.006441421, -0.026952261, -0.050853323, 0.052548435, 0.011950531, 0.018476708, -0.02339253, 0.04203875, -0.05932888, -0.02644373, -0.07153368, 0.0024367217, -0.037461948, -0.0020871053, -0.012374309, 0.04085217, 0.01729013, 0.050514303, 0.0033902216, 0.020510841, -0.05932888, -0.0053607877, -0.01008591, -0.0004979388, 0.06373616, 0.033563193, -0.019324264, 0.013899908, 0.047463104, 0.005466732, -0.024070574, -0.008984087, -0.02017182, -0.014238931, -0.01576453, -0.02491813, 0.025765684, 0.04508995, 0.0061447765, 0.010255421, -0.03305466, 0.030342484, -0.058311813, -0.036614392, 0.01008591, -0.005551488, -0.0001119038, 0.008306043, -0.02644373, 0.06610932, -0.067126386, 0.05763377, -0.0077127544, -0.0006330179, 0.005932888, -0.021866929, 0.027291285, 0.0039411327, 0.024240084, -0.018900486, -0.0063990434, -0.008390798, 0.028816884, 0.0003218062, -0.042716794, 0.026104707, -0.005466732, 0.019832797, 0.0009217165, -0.0055938656, -0.04508995, 0.00970451, -0.0055938656, 0.029155906, 0.082043365, 0.033902217, -0.008560309, 0.015255997, -0.015425509, 0.023053506, -0.03119004, -0.017035864, 0.023731552, -0.030851018, 0.07085563, -0.066448346, -0.022714484, 0.003708055, -0.06305812, 0.034749772, 0.020934619, -0.01178102, -0.01593404, 0.006441421, -0.053904522, 0.04678506, 0.003135955, 0.013984664, -0.031020528, 0.005720999, -0.0009217165, 0.025426662, -0.005021766, -0.021612663, 0.017120618, -0.052209415, -0.041869238, 0.02127364, -0.011187731, 0.0011124165, 0.040513147, -0.020256573, 0.01940902, 0.01729013, -0.04678506, -0.01254382, 0.032037593, 0.02339253, 0.010297799, 0.0007469082, -0.029494928, -0.011272487, -0.015086486, 0.005678621, 0.074923895, -0.00015626803, -0.027969329, 0.020426085, -0.024579106, 0.025596173, 0.012035287, -0.002510883, 0.00627191, 0.067126386, -0.02339253, -0.058311813, 0.03305466, 0.021358397, 0.009153598, -0.028816884, 0.021697419, -0.022375463, -0.014069419, 0.012459065, 0.069499545, 0.030851018, -0.061023988, 0.00741611, 0.10441883, 0.041869238, 0.03780097, 0.025596173, 0.06814346, 0.011526753, -0.033563193, 0.020680351, -0.0047463104, -0.008687443, 0.029155906, 0.035088792, 0.018307196, -0.018476708, -0.007331354, -0.031020528, 0.0012554415, 0.056955725, 0.012628576, 0.033224173, -0.036275372, 0.03186808, -0.008390798, -0.07627998, 0.0006250721, -0.062041055, 0.04237777, -0.01729013, 0.040004615, -0.051531367, -0.009874021, -0.029325416, -0.005466732, 0.028138839, -0.028647372, -0.025426662, -0.0017268942, 0.015425509, -0.00894171, -0.00063036935, 0.024409596, 0.020934619, 0.032715637, 0.00627191, -0.0044920435, -0.07085563, -0.014069419, 0.04203875, -0.019832797, -0.023901062, -0.00741611, 0.025426662, 0.020680351, 0.016273065, -0.056277677, 0.004386099, -0.025935195, 0.041869238, 0.009450243, -0.044411905, -0.012374309, 0.006526177, -0.00045026382, -0.0006171263, -0.0018752164, 0.019069996, 0.06916052, -0.04712408, 0.005932888, 0.011187731, -0.046107013, 0.038987547, -0.0057633766, 0.00894171, 0.0005164791, 0.023731552, 0.01063682, -0.0015573831, 0.051531367, -0.008517932, 0.013391376, 0.018985242, 0.02127364, 0.029494928, 0.032037593, 0.008517932, 0.0015361941, 0.021019375, -0.011187731, -0.0042801546, 0.019069996, 0.021866929, -0.017459642, -0.022714484, -0.018222442, 0.0026486106, -0.021443151, 0.018222442, 0.0028181218, -0.046107013, -0.004068266, 0.035597328, 0.03881804, -0.018307196, -0.074245855, 0.03881804, -0.01864622, 0.022883996, -0.0038139992, -0.03458026, 0.002966444, 0.039496083, -0.026952261, 0.04085217, 0.0076279985, 0.012035287, -0.022205953, 0.006822821, 0.03729244, 0.026782751, -0.0075008655, -0.008984087, -0.02017182, 0.0030723882, 0.036444884, -0.038479015, 0.0039411327, -0.02508764, -0.024070574, -0.07288977, -0.005551488, 0.02491813, 0.033224173, -0.033393685, -0.0026062329, 0.0104673095, 0.024409596, -0.065431274, -0.050853323, -0.043225326, 0.022205953, -0.026952261, 0.007161843, 0.003877566, 0.023053506, -0.018561464, 0.019663285, 0.008263665, -0.012289553, -0.012204798, 0.024240084, -0.0010912276, -0.016696842, -0.07390683, -0.0015255997, -0.029494928, 0.061702035, 0.03729244, -0.0077127544, 0.050514303, -0.017035864, -0.03152906, -0.020087063, -0.016612086, 0.026952261, 0.027630307, 0.0026168274, -0.0027969328, -0.006822821, 0.029494928, 0.018476708, 0.053904522, -0.016018797}}
+var VectorDocument11 = VectorDocument{ID: "1a.34.1", Text: Chunk11.Body, Vector: []float64{-0.04542897, 0.041699726, 0.016612086, -0.06814346, 0.014408442, -0.0054031657, 0.02644373, -0.0041318326, 0.041191194, 0.040004615, -0.043733858, 0.011865776, 0.0034537883, -0.024748618, -0.07865314, -0.0047463104, -0.023223018, 0.006992332, -0.005678621, 0.0104249315, -0.017374886, 0.023053506, 0.019069996, -0.032037593, 0.058650833, -0.007585621, -0.050853323, -0.024579106, 0.038648527, -0.022544974, 0.055599634, 0.04915821, -0.041191194, -0.041021682, 0.042716794, 0.035597328, 0.019069996, -0.028477862, -0.008390798, 0.008178909, 0.038479015, 0.051531367, -0.0013296026, 0.0075008655, -0.000741611, -0.009492621, -0.024579106, 0.044750925, 0.039665595, 0.027799817, 0.020595597, 0.019239508, -0.04712408, 0.086789675, 0.06610932, -0.018561464, -0.037970483, 0.033902217, 0.008348421, -0.026952261, -0.052548435, -0.02356204, 0.04881919, 0.030511994, -0.012882842, -0.036953416, -0.054243546, -0.05492159, -0.020510841, -0.039665595, -0.04254728, 0.014408442, 0.029494928, -0.01593404, -0.041360702, -0.050853323, 0.12001385, -0.025765684, -0.005678621, 0.033224173, -0.020680351, -0.0017374886, -0.018730974, -0.023053506, 0.042716794, -0.082721405, -0.012713331, 0.023901062, 0.064753234, -0.0007469082, 0.0030511995, -0.004619177, 0.058989856, -0.05288746, 0.022714484, -0.030003462, -0.014238931, -0.07085563, -0.021697419, 0.02644373, -5.065468e-05, -0.0070347097, 0.019832797, 0.01576453, 0.061702035, 0.008306043, -0.02644373, 0.004004699, 0.016188309, 0.06678737, 0.032715637, 0.02661324, -0.03729244, -0.04254728, 0.020849863, -0.03932657, -0.013984664, 0.023901062, 0.030003462, -0.008263665, 0.014747464, -0.02017182, -0.023053506, -0.0048734434, 0.027460795, -0.020002307, 0.058650833, 0.036444884, -0.012459065, 0.056955725, -0.033563193, 0.006441421, 0.060345944, -0.0029240663, -0.051531367, 0.020934619, -0.038648527, -0.023901062, -0.01101822, -0.020510841, 0.023053506, 0.03458026, -0.02508764, 0.044411905, -0.006526177, 0.013730397, -0.018307196, 0.004047077, 0.012628576, -0.0006515582, 0.024240084, 0.07458488, 0.04542897, 0.021612663, 0.013137109, 0.00665331, -0.019239508, -0.029494928, 0.024409596, 0.0048734434, 0.048141148, 0.0013560887, 0.05288746, -0.008178909, -0.0072889766, 0.03407173, -0.044411905, -0.0029240663, -0.026274217, -0.006822821, -0.035088792, -0.015425509, -0.024579106, 0.073228784, -0.015086486, 0.027121773, 0.023731552, -0.029494928, 0.004640366, -0.0036444883, 0.025765684, 0.027799817, -0.019324264, -0.004004699, 0.047463104, 0.0012183608, -0.01940902, -0.005678621, -0.017544396, -0.01559502, 0.027630307, 0.049497236, 0.00550911, 0.025765684, 0.016951108, 0.05458257, -0.00398351, -0.011526753, 0.06848247, 0.071194656, -0.0018540275, -0.020510841, 0.006102399, 0.013984664, -0.0020765108, -0.036444884, 0.0105096875, -0.03458026, -0.007161843, -0.06238008, 0.027630307, 0.0069075767, -0.01576453, -0.015255997, 0.010043532, 0.021019375, 0.03254613, 0.016018797, -0.035088792, 0.033563193, -0.03254613, 0.052209415, -0.016357819, 0.06780443, -0.0034325994, -0.0022672107, -0.029494928, 0.012120042, 0.0003496166, -0.020595597, 0.011187731, 0.033224173, -0.026274217, 0.073228784, 0.0035173548, 0.029155906, -0.03186808, -0.03932657, -0.0027121773, 0.028477862, 0.041699726, -0.06238008, -0.007204221, -0.0027015829, 0.041699726, 0.03576684, 0.0107215755, 0.02017182, 0.007755132, -0.050514303, -0.011357242, -0.020510841, -0.028647372, -0.023053506, 0.0041530216, 0.003686866, -0.05763377, 0.0075008655, 0.03729244, 0.014238931, -0.00627191, 0.008094154, 0.043225326, -0.027799817, -0.008899332, -0.041360702, 0.008136532, -0.016357819, -0.028477862, -0.011696265, 0.051531367, 0.01940902, 0.012289553, -0.017968174, 0.07153368, -0.033393685, 0.05288746, -0.0075008655, -0.005678621, -0.013730397, 0.013899908, -0.038479015, -0.05017528, -0.018307196, 0.021019375, 0.00550911, -0.0036444883, 0.0007575026, -0.012120042, 0.00796702, -0.023053506, -0.041360702, -0.010933464, -0.0009482026, 0.043733858, 0.027460795, -0.061023988, -0.009111221, -0.04983626, -0.011526753, -0.00550911, -0.028138839, -0.044750925, -0.022205953, 0.04678506, -0.008687443, -0.0005164791, -0.034919284, -0.018900486, -0.003877566, -0.01008591, -0.0015573831, 0.0033054661, 0.055599634, 0.031698573, 0.020510841, 0.03813999, 0.009280732, 0.023223018, 0.011102976, -0.006822821, 0.0004741013, 0.00012382255, 0.018730974, -0.018137686, 0.041021682, -0.051531367, -0.011526753, -0.05763377, -0.036953416, 0.005042955, -0.01864622, 0.03576684, 0.026952261, 0.00894171, 0.044411905, 0.044411905, 0.034241237, -0.011357242, -0.012459065, -0.028816884, 0.024070574, -0.027291285, -0.01559502, -0.055938657, 0.0011389026, -0.036614392, 0.007755132, 0.02644373, -0.0052336548, -0.0011706859, -0.014323686, 0.057294745, -0.018307196, 0.020595597, -0.07763608, 0.09899447, 0.029325416, -0.016696842, -0.033393685, -0.01576453, 0.006695688, -0.0024261274, 0.024240084, 0.015510264, -0.022544974, -0.044750925, 0.024240084, -0.042716794, 0.0043437215, -0.05492159, 0.004852255, 0.011950531, 0.0047251214, 0.0011706859, 0.0049158214, 0.008306043, -0.021697419, -0.0055938656, 0.05322648, 0.038648527, -0.0009693915, 0.01593404, -0.035088792, -0.036444884, -0.0017374886, 0.0071194656, -0.041869238, -0.048141148, -0.009916399, -0.016612086, -0.03729244, 0.04712408, 0.017713908, 0.054243546, 0.011272487, 0.006314288, -0.0005164791, 0.030172972, -0.041021682, 0.00741611, 0.078992166, 0.012459065, -0.010933464, -0.03593635, 0.055938657, -0.014238931, -0.014408442, -0.044750925, -0.023053506, 0.028816884, -0.043733858, 0.016951108, -0.00627191, -0.05017528, -0.0029240663, 0.014069419, -0.004470855, -0.020849863, -0.0018222441, -0.01483222, -0.001483222, 0.014662708, 0.00029134718, 0.00894171, -0.009111221, 0.03729244, 0.01330662, 0.027969329, 0.019493774, -0.052209415, 0.05017528, -0.007543243, -0.053565502, 0.024240084, -0.022883996, 0.012289553, -0.003474977, -0.030342484, 0.0052972212, -0.0071194656, 0.047802124, -0.012204798, -0.003496166, 0.025426662, 0.061023988, -0.025765684, 0.028138839, 0.009619754, -0.01254382, 0.008687443, -0.020426085, 0.0007575026, 0.028477862, 0.002034133, -0.0038139992, 0.023053506, 0.012628576, -0.04034364, -0.018985242, -0.02661324, 0.0061447765, -0.00796702, -0.025765684, 0.08170434, 0.016188309, -0.004661555, 0.052209415, 0.009746887, -0.005551488, 0.040513147, 0.022544974, -0.0066109323, 0.017713908, 0.025426662, 0.03729244, 0.015849287, 0.024579106, -0.06916052, 0.037970483, -0.052209415, -0.013899908, 0.032037593, -0.011102976, -0.023053506, 0.051192347, 0.06373616, 0.0018752164, -0.018137686, -0.017035864, -0.03813999, 0.05322648, -0.04407288, -0.005551488, -0.08475554, 0.01652733, 0.022883996, 0.033393685, -0.023731552, 0.055260614, -0.044750925, 0.0023095885, -0.024240084, 0.029325416, 0.06780443, 0.0035597328, 0.0031783327, 0.0049158214, 0.04915821, 0.027121773, -0.006949954, 0.0036444883, 0.035088792, 0.030851018, -0.039496083, -0.00070453045, 0.028647372, 0.017459642, 0.020849863, 0.039496083, 0.028816884, 0.030511994, 0.037122928, -0.025596173, -0.0135608865, -0.020510841, -0.036275372, -0.02661324, -0.013899908, -0.00063036935, -0.037970483, 0.062041055, 0.0058057546, -0.012882842, 0.005932888, -0.034241237, 0.013052354, -0.038987547, -0.008687443, 0.00078398874, -0.0059752655, -0.012882842, 0.017798664, 0.02491813, -0.030851018, -0.029494928, -0.010848709, -0.021866929, 0.0038139992, -0.017459642, 0.050853323, -0.00779751, 0.031020528, -0.038479015, -0.041699726, -0.0031995217, -0.072550744, -0.020934619, 0.010806331, -0.032715637, -0.0044920435, 0.013899908, -0.027969329, -0.027460795, 0.0027969328, -0.022714484, -0.0039623217, -0.0031783327, -0.008856954, -0.030172972, 0.03254613, 0.008856954, -0.043394838, -0.044750925, 0.056277677, -0.022205953, 0.015001731, 0.013899908, 0.003898755, -0.002320183, 0.020595597, -0.026782751, -0.020595597, -0.047463104, -0.027799817, 0.0038139992, 0.007924643, 0.015255997, 0.005000577, -0.015510264, -0.01864622, 0.041699726, -0.042716794, 0.02491813, -0.01788342, -0.005466732, -0.017968174, 0.058311813, 0.0071194656, 0.01483222, -0.0051065213, 0.005000577, 0.013984664, -0.009874021, -0.010340176, -0.014577953, -0.008475554, 0.009619754, -0.033224173, 0.036953416, -0.0049581993, 0.06305812, 0.04983626, -0.031020528, 0.02966444, -0.022205953, 0.018900486, -0.033563193, -0.018222442, 0.019748041, -0.015171242, -0.00741611, -0.017120618, 0.0051277103, -0.018561464, -0.0010594443, -0.056955725, 0.04542897, -0.03576684, 0.01729013, 0.017713908, 0.025426662, 0.02508764, 0.008475554, 0.03729244, -0.031020528, -0.011441998, 0.013984664, -0.02966444, 0.028647372, -0.011357242, 0.010933464, 0.009831643, -0.011102976, 0.028477862, 0.0055938656, 0.048141148, -0.055938657, -0.011187731, 0.008517932, -0.0135608865, 0.018985242, -0.005021766, -0.022714484, -0.056277677, 0.036275372, -0.016273065, -0.019324264, -0.048141148, 0.041869238, 0.008390798, -0.0013296026, 0.044750925, 0.0019493775, -0.017544396, 0.032715637, -0.038987547, -0.020934619, 0.0019281886, 0.043394838, -0.008560309, -0.015255997, 0.026274217, -0.04983626, 0.0049370104, 0.0045344215, 0.0010329582, -7.5485405e-05, 0.0051065213, -0.0024367217, 0.0063990434, -0.022205953, 0.043225326, -0.005466732, 0.03576684, 0.077297054, 0.0025532607, -0.056277677, 0.0046827435, 0.027291285, -0.046446037, -0.007882265, -0.116623625, -0.019324264, 0.009874021, -0.020087063, -0.03932657, -0.020510841, 0.005932888, -0.0038139992, -0.023731552, -0.005000577, -0.040004615, 0.006441421, -0.026952261, -0.050853323, 0.052548435, 0.011950531, 0.018476708, -0.02339253, 0.04203875, -0.05932888, -0.02644373, -0.07153368, 0.0024367217, -0.037461948, -0.0020871053, -0.012374309, 0.04085217, 0.01729013, 0.050514303, 0.0033902216, 0.020510841, -0.05932888, -0.0053607877, -0.01008591, -0.0004979388, 0.06373616, 0.033563193, -0.019324264, 0.013899908, 0.047463104, 0.005466732, -0.024070574, -0.008984087, -0.02017182, -0.014238931, -0.01576453, -0.02491813, 0.025765684, 0.04508995, 0.0061447765, 0.010255421, -0.03305466, 0.030342484, -0.058311813, -0.036614392, 0.01008591, -0.005551488, -0.0001119038, 0.008306043, -0.02644373, 0.06610932, -0.067126386, 0.05763377, -0.0077127544, -0.0006330179, 0.005932888, -0.021866929, 0.027291285, 0.0039411327, 0.024240084, -0.018900486, -0.0063990434, -0.008390798, 0.028816884, 0.0003218062, -0.042716794, 0.026104707, -0.005466732, 0.019832797, 0.0009217165, -0.0055938656, -0.04508995, 0.00970451, -0.0055938656, 0.029155906, 0.082043365, 0.033902217, -0.008560309, 0.015255997, -0.015425509, 0.023053506, -0.03119004, -0.017035864, 0.023731552, -0.030851018, 0.07085563, -0.066448346, -0.022714484, 0.003708055, -0.06305812, 0.034749772, 0.020934619, -0.01178102, -0.01593404, 0.006441421, -0.053904522, 0.04678506, 0.003135955, 0.013984664, -0.031020528, 0.005720999, -0.0009217165, 0.025426662, -0.005021766, -0.021612663, 0.017120618, -0.052209415, -0.041869238, 0.02127364, -0.011187731, 0.0011124165, 0.040513147, -0.020256573, 0.01940902, 0.01729013, -0.04678506, -0.01254382, 0.032037593, 0.02339253, 0.010297799, 0.0007469082, -0.029494928, -0.011272487, -0.015086486, 0.005678621, 0.074923895, -0.00015626803, -0.027969329, 0.020426085, -0.024579106, 0.025596173, 0.012035287, -0.002510883, 0.00627191, 0.067126386, -0.02339253, -0.058311813, 0.03305466, 0.021358397, 0.009153598, -0.028816884, 0.021697419, -0.022375463, -0.014069419, 0.012459065, 0.069499545, 0.030851018, -0.061023988, 0.00741611, 0.10441883, 0.041869238, 0.03780097, 0.025596173, 0.06814346, 0.011526753, -0.033563193, 0.020680351, -0.0047463104, -0.008687443, 0.029155906, 0.035088792, 0.018307196, -0.018476708, -0.007331354, -0.031020528, 0.0012554415, 0.056955725, 0.012628576, 0.033224173, -0.036275372, 0.03186808, -0.008390798, -0.07627998, 0.0006250721, -0.062041055, 0.04237777, -0.01729013, 0.040004615, -0.051531367, -0.009874021, -0.029325416, -0.005466732, 0.028138839, -0.028647372, -0.025426662, -0.0017268942, 0.015425509, -0.00894171, -0.00063036935, 0.024409596, 0.020934619, 0.032715637, 0.00627191, -0.0044920435, -0.07085563, -0.014069419, 0.04203875, -0.019832797, -0.023901062, -0.00741611, 0.025426662, 0.020680351, 0.016273065, -0.056277677, 0.004386099, -0.025935195, 0.041869238, 0.009450243, -0.044411905, -0.012374309, 0.006526177, -0.00045026382, -0.0006171263, -0.0018752164, 0.019069996, 0.06916052, -0.04712408, 0.005932888, 0.011187731, -0.046107013, 0.038987547, -0.0057633766, 0.00894171, 0.0005164791, 0.023731552, 0.01063682, -0.0015573831, 0.051531367, -0.008517932, 0.013391376, 0.018985242, 0.02127364, 0.029494928, 0.032037593, 0.008517932, 0.0015361941, 0.021019375, -0.011187731, -0.0042801546, 0.019069996, 0.021866929, -0.017459642, -0.022714484, -0.018222442, 0.0026486106, -0.021443151, 0.018222442, 0.0028181218, -0.046107013, -0.004068266, 0.035597328, 0.03881804, -0.018307196, -0.074245855, 0.03881804, -0.01864622, 0.022883996, -0.0038139992, -0.03458026, 0.002966444, 0.039496083, -0.026952261, 0.04085217, 0.0076279985, 0.012035287, -0.022205953, 0.006822821, 0.03729244, 0.026782751, -0.0075008655, -0.008984087, -0.02017182, 0.0030723882, 0.036444884, -0.038479015, 0.0039411327, -0.02508764, -0.024070574, -0.07288977, -0.005551488, 0.02491813, 0.033224173, -0.033393685, -0.0026062329, 0.0104673095, 0.024409596, -0.065431274, -0.050853323, -0.043225326, 0.022205953, -0.026952261, 0.007161843, 0.003877566, 0.023053506, -0.018561464, 0.019663285, 0.008263665, -0.012289553, -0.012204798, 0.024240084, -0.0010912276, -0.016696842, -0.07390683, -0.0015255997, -0.029494928, 0.061702035, 0.03729244, -0.0077127544, 0.050514303, -0.017035864, -0.03152906, -0.020087063, -0.016612086, 0.026952261, 0.027630307, 0.0026168274, -0.0027969328, -0.006822821, 0.029494928, 0.018476708, 0.053904522, -0.016018797}}
 
-var VectorDocument12 = VectorDocument{ID: "1a.34.2a", Vector: []float64{-0.009865484, 0.016885156, -0.011478112, -0.061090115, 0.0034149755, 0.056157373, 0.010150066, -0.004932742, 0.0056679104, 0.028268408, -0.014608506, 0.04591245, 0.014608506, 0.0012391143, -0.02694036, 0.0040315683, -0.02864785, 0.022576781, 0.0055256197, 0.018118342, -0.024663711, 0.022956224, 0.015082808, -0.010624368, 0.018213201, -0.0005543406, 0.0042924345, -0.04041054, 0.042687193, -0.021153875, 0.034529194, 0.018877225, -0.024853433, -0.05122463, 0.060710672, 0.014987947, 0.014703366, 0.01574683, 0.0063556484, -0.03756473, 0.047430214, 0.00053951866, -0.001274687, 0.015367389, -0.022671642, 0.036236685, -0.06526397, 0.011857553, 0.05122463, 0.050845187, 0.022956224, 0.07209393, -0.027888965, 0.05312184, 0.056916256, 0.027888965, -0.008063137, 0.030545058, 0.025043152, 0.024094548, -0.010908949, 0.009818054, 0.043256354, 0.06526397, -0.011810123, -0.05122463, -0.030355336, -0.0028458128, -0.02902729, 0.016885156, -0.020679573, -0.02902729, 0.04230775, -0.026181478, -0.02011041, -0.015082808, 0.101690374, -0.00547819, -0.025422594, 0.04439468, 0.02011041, -0.0045058704, -0.030165616, -0.018782364, 0.015651971, -0.037375007, 0.022956224, 0.028078686, 0.039461937, -0.0036758415, -0.009011741, -0.02020527, 0.042687193, -0.03775445, 0.021628177, -0.030734777, -0.008584869, -0.056157373, 0.021723038, 0.050086305, -0.014987947, 0.010197496, -0.02864785, -0.01574683, 0.050465748, -0.0023003654, -0.025802037, 0.0067825206, -0.0021106445, 0.0050276024, -0.017359458, 0.012426716, -0.036236685, -0.037375007, 0.045533005, -0.030734777, 0.019351527, -0.010292356, 0.036616124, -0.017264597, -0.0177389, -0.027130082, -0.018402923, -0.028458128, 0.012711297, -0.025802037, 0.050465748, 0.021059014, -0.03358059, 0.019636108, -0.038513333, -0.010861519, 0.029596454, 0.0043161493, 0.0045770155, -0.014039343, -0.009675764, -0.041169424, -0.025043152, -0.061090115, 0.041359145, 0.019256666, 0.020300131, 0.048189096, -0.043256354, 0.0023833683, -0.021153875, -0.027509524, -0.011572972, 0.032442264, 0.014134204, -0.0028458128, 0.08575383, 0.038513333, 0.025422594, -0.0028458128, 0.0021462173, -0.003320115, 0.034718916, -0.04173859, -0.0027983827, 0.0017549179, 0.028268408, 0.01992069, 0.002869528, 0.02011041, -0.01109867, 0.0112883905, -0.011857553, 0.02219734, -0.02020527, -0.034339476, -0.023145944, 0.08082108, -0.02447399, 0.06829951, 0.03775445, 0.0031541092, -0.034718916, -0.038513333, 0.0065453695, 0.026750641, -0.012094704, 0.005786486, 0.024189409, -0.009343752, -0.017359458, 0.013090739, 0.018782364, 0.016315993, 0.04591245, 0.050086305, 0.002976246, 0.0445844, 0.039082497, 0.08727159, -0.00047430213, -0.010434647, -0.0067825206, 0.047619935, -0.004648161, -0.02694036, 0.0072093923, 0.019636108, 0.025422594, -0.02694036, 0.00664023, -0.019636108, -0.004909027, -0.06526397, -0.0040315683, -0.0009604618, 0.018877225, -0.050465748, 0.011620402, -0.009391182, 0.07247337, -0.010624368, -0.02902729, 0.05539849, -0.04894798, 0.002869528, -0.013849623, 0.038133893, 0.00441101, -0.012995878, 0.0041264286, 0.009154031, -0.0063556484, -0.016126273, 0.023620246, 0.06222844, 0.0027983827, 0.018592644, 0.01982583, 0.038513333, -0.018213201, -0.0073991134, -0.004932742, 0.0024900862, 0.036616124, -0.06222844, -0.0039367075, -0.0047904514, 0.019730968, 0.029975895, 0.010529507, -0.004434725, 0.030355336, -0.07854443, -0.030165616, -0.014134204, -0.05388072, -0.04022082, -0.0007084888, 0.0015296243, -0.02428427, 0.012995878, 0.02656092, 0.011193531, 0.0013576898, -0.015082808, 0.025043152, -0.03301143, 0.017264597, -0.02902729, 0.0066876602, -0.047619935, 0.0018497783, 0.0018972085, 0.020679573, -0.024663711, 0.04648161, -0.039082497, 0.05577793, -0.008300288, 0.045343284, 0.011383251, -0.005146178, 0.011620402, 0.046861053, -0.038133893, -0.019161806, -0.020584712, 0.03718529, 0.02694036, -0.0030592487, -0.00111461, -0.025612315, -0.02219734, 0.012142135, -0.047430214, -0.01574683, 0.007351683, 0.024663711, -0.00664023, -0.042687193, 0.004434725, -0.05577793, 0.011383251, 0.01982583, -0.041548867, -0.044204958, -0.029596454, -0.002739095, 0.011667833, -0.02447399, -0.054639608, -0.023240805, 0.023620246, 0.009154031, 0.020774433, 0.027509524, 0.058434024, 0.07209393, -0.023904828, 0.07133504, 0.009011741, 0.027699245, 0.012426716, 0.007541404, -0.00090710283, -0.0007825985, 0.039082497, -0.021438457, 0.025043152, -0.03168338, 0.016790295, -0.03168338, -0.04439468, -0.026750641, -0.001565197, 0.0029643883, 0.04382552, 0.013659902, 0.09068657, 0.023809968, 0.022387061, 0.010576937, -0.013565041, -0.016790295, -0.0013221172, 0.0076836944, -0.002039499, -0.009391182, -0.020584712, -0.03984138, 0.03111422, 0.06867895, -0.01754918, -0.00056916254, 0.029217012, 0.010814088, 0.021723038, 0.02428427, -0.045153562, 0.06678174, 0.025232874, 0.021153875, -0.023145944, -0.038133893, -0.015082808, -0.025612315, 0.030165616, 0.06829951, 0.01754918, -0.036046963, 0.04648161, -0.009059171, 0.013090739, -0.021059014, 0.019636108, -0.009865484, 0.048189096, 0.0010731086, 0.0041975738, 0.027319804, -0.025612315, -0.0058576316, 0.034529194, 0.0071619623, 0.004434725, -0.0111461, -0.0031541092, -0.04022082, 0.02447399, 0.042876914, 0.00084188627, -0.015367389, -0.010576937, -0.021343596, -0.041548867, 0.015651971, 0.047619935, -0.0022766502, 0.03301143, 0.02656092, 0.049327422, 0.062607884, -0.018402923, 0.016505715, 0.012094704, -0.049327422, 0.0074939737, -0.07133504, 0.038513333, -0.027888965, -0.021817898, -0.029786173, 0.0041738586, -0.0032252546, -0.0066876602, -0.0094386125, -0.017074877, -0.06829951, 0.002656092, -0.010529507, 0.009533473, -0.010861519, -0.034718916, 0.02864785, -0.00022677571, 0.018023482, 0.00039129925, 0.011857553, -0.029975895, 0.026181478, 0.018402923, 0.03509836, -0.008015706, -0.013185599, 0.04894798, 0.006403079, -0.03756473, -0.010102635, 0.021817898, 0.013754762, 0.042687193, -0.0023952257, 0.007920845, -0.016790295, 0.04894798, -0.025422594, 0.0065453695, 0.041548867, 0.049327422, -0.030734777, 0.00441101, 0.025043152, 0.002893243, 0.050845187, 0.030355336, 0.031303942, 0.030165616, 0.025612315, -0.0222922, -0.007304253, -0.0066876602, -0.050845187, 0.015177668, -0.022861363, -0.013090739, 0.028268408, -0.032442264, 0.06829951, 0.04894798, -0.03984138, 0.060331233, -0.028078686, 0.0017549179, 0.042876914, 0.027319804, -0.023430526, 0.009296322, 0.049706865, 0.01764404, 0.010671798, 0.037375007, -0.077026665, 0.030165616, -0.011904984, -0.0074465433, 0.018402923, 0.011715263, 0.053501282, 0.03111422, 0.059192907, -0.0037469869, -0.053501282, -0.021628177, -0.034339476, 0.03111422, -0.058434024, -0.0028458128, -0.08878936, -0.0016007697, -0.008442578, -0.02656092, -0.034149755, 0.06488453, -0.023715107, 0.003130394, -0.029596454, 0.039082497, 0.010434647, -0.025232874, 0.00084781507, -0.003320115, 0.0038418472, 0.07512946, -0.022671642, -0.0061184973, -0.012995878, 0.030165616, -0.023715107, -0.005075033, 0.027319804, 0.0445844, -0.013754762, -0.0055967653, -0.011430682, 0.036616124, 0.036236685, -0.042687193, -0.030165616, -0.051604073, -0.06298732, -0.036805846, 0.059192907, 0.021153875, 0.01328046, 0.04894798, -0.0032489696, 0.012236995, -0.03775445, -0.042118028, -0.011620402, -0.061469555, -0.030355336, -0.010719229, -0.020584712, 0.028268408, 0.054260164, 0.03756473, -0.061090115, -0.019066947, -0.01555711, -0.05388072, -0.03528808, 0.004007853, -0.026181478, 0.030734777, 0.014513645, -0.00027420593, -0.016885156, -0.04041054, -0.04041054, -0.025232874, 0.025043152, 0.0025256588, -0.018782364, 0.038133893, -0.008110566, -0.07323225, -0.018592644, -0.0031778242, 0.006047352, -0.025422594, 0.0049801725, -0.010861519, 0.061469555, -0.012995878, 0.012995878, -0.03528808, 0.030545058, -0.024853433, 0.0021699322, 0.011715263, -0.008584869, -0.0019802114, 0.00069070247, -0.007351683, 0.011193531, -0.060331233, 0.016600575, -0.038133893, -0.0020039266, 0.008252857, -0.011715263, 0.0045533003, 0.021817898, -0.012142135, -0.06526397, 0.030355336, -0.026750641, -0.014608506, -0.012142135, 0.061090115, 0.0002312223, 0.017359458, 0.0036521265, 0.052742396, 0.012236995, 0.020774433, -0.009059171, 0.0123318555, 0.011904984, -0.007920845, 0.0009841769, 0.023240805, 0.00045651582, 0.00673509, 0.06867895, -0.012995878, 0.010624368, -0.036236685, -0.0032015394, -0.04230775, -0.060710672, 0.02191276, 0.022861363, -0.011193531, -0.028837569, 0.023809968, -0.055019047, 0.002039499, -0.06336676, -0.018118342, -0.027888965, -0.0030592487, -0.0069248113, 0.0036284113, 0.013849623, 0.009154031, 0.0070196716, -0.022387061, 0.0133753205, 0.028837569, 0.052362956, 0.022387061, 0.023240805, 0.015177668, 0.02656092, -0.0048141666, 0.03149366, 0.03965166, 0.045722727, -0.047240492, -0.027888965, 0.0144187845, 0.025612315, 0.017264597, -0.010671798, -0.006023637, -0.03528808, 0.047809657, -0.040789984, -0.00664023, 0.010339786, 0.045343284, -0.02428427, 0.015651971, -0.006023637, 0.000358691, -0.023620246, 0.03528808, -0.045722727, -0.003130394, 0.009912915, 0.027319804, -0.012711297, -0.005169893, 0.047430214, -0.03984138, 0.027130082, -0.0045533003, 0.023904828, 0.006047352, 0.0056679104, -0.034529194, -0.0036758415, -0.011051239, 0.04591245, -0.014893087, 0.046861053, 0.09068657, 0.042687193, -0.06450509, 0.021343596, 0.016600575, -0.043635797, 0.004909027, -0.08233885, 0.0041975738, -0.0055967653, -0.030355336, -0.050845187, -0.038513333, 0.020300131, -0.0037232717, -0.046861053, -0.011620402, -0.036046963, 0.0058576316, -0.024094548, 0.012995878, 1.130173e-05, 0.012806158, -0.0041264286, -0.0026323767, 0.059192907, -0.05388072, -0.04173859, -0.06867895, 0.014229064, -0.05995179, 0.0083951475, -0.022102479, 0.029596454, 0.039082497, -0.00062252156, -0.018782364, 0.02864785, -0.023335665, 0.019256666, 0.0066876602, 0.0036995567, 0.052362956, 0.015082808, -0.03377031, 0.041359145, 0.019730968, -0.0011264676, -0.02864785, -0.0076836944, -0.011193531, 0.015272529, 0.0041975738, -0.06678174, 0.017359458, 0.08082108, 0.026181478, 0.015367389, -0.017169738, 0.034718916, -0.021628177, -0.05122463, 0.029217012, -0.023715107, -0.010624368, 0.019066947, -0.009912915, 0.014039343, -0.04894798, 0.026750641, 0.03832361, 0.009628333, 0.030545058, -0.02428427, 0.038133893, 0.028458128, 0.009106601, -0.02864785, -0.014608506, -0.016790295, 0.04041054, -0.0030829639, -0.027319804, 0.014134204, 0.03301143, -0.0019446388, 0.0052884687, 0.010529507, -0.03377031, -0.011715263, 0.003912993, 0.0111461, 0.06526397, -0.0009841769, -0.038513333, 0.010861519, -0.045343284, 0.022576781, -0.017169738, -0.016695434, 0.000610664, -0.036805846, 0.08309773, -0.06905839, -0.04022082, 0.027888965, -0.0177389, 0.02902729, 0.028078686, 0.0054544746, 0.038703054, -0.012521576, -0.03984138, 0.01992069, 0.011620402, 0.032062825, -0.027699245, -0.00877459, -0.0061184973, 0.05577793, -0.0052173235, -0.006829951, -0.012142135, -0.036046963, -0.06412565, 0.004434725, 0.02428427, 0.023430526, 0.02656092, -0.015651971, 0.015367389, -0.019636108, -0.038703054, 0.06298732, 0.04173859, 0.034339476, 0.009533473, 0.00054248306, -0.05388072, -0.025612315, -0.052362956, -0.02902729, 0.023620246, -0.012047274, -0.04667133, 0.07019672, 0.0017074877, 0.0022766502, -0.015177668, 0.02219734, 0.015367389, 0.072852805, -0.05388072, -0.03320115, 0.022956224, 0.01584169, -0.01754918, -0.058813464, -0.02447399, -0.048378818, -0.02447399, 0.04249747, 0.045343284, 0.056916256, -0.092583776, 0.014513645, 0.08233885, 0.03528808, 0.055019047, 0.04173859, 0.03509836, -0.0049564573, -0.018118342, 0.034529194, -0.006450509, -0.00891688, -0.00069959566, 0.024094548, -0.0011976128, -7.7074095e-05, -0.0010434646, -0.01982583, 0.027319804, 0.036426403, 0.014798227, 0.039461937, -0.036616124, 0.012236995, 0.0007055244, -0.04022082, 0.026181478, -0.08233885, 0.03756473, -0.0026442343, 0.02428427, -0.042876914, -0.02656092, -0.045343284, -0.03566752, 0.07019672, 0.010814088, -0.006450509, 0.017264597, 0.025232874, -0.03339087, -0.00021491815, 0.018402923, 0.01555711, 0.013849623, 0.002656092, -0.0061659277, -0.050086305, -0.029217012, 0.026181478, -0.01109867, -0.061090115, -0.028078686, -0.015651971, 0.023240805, 0.054260164, -0.025991756, 0.0015177669, 0.005739056, 0.025043152, 0.0028458128, -0.05312184, -0.053501282, -0.0094386125, -0.0010553222, -0.008063137, 0.01754918, 0.01982583, 0.0047193062, -0.0354778, 0.02191276, 0.032252546, -0.046291888, 0.045533005, -0.04591245, 0.023809968, -0.03528808, 0.012521576, 0.0040315683, -0.008110566, 0.05312184, -0.026181478, -0.02447399, -0.009154031, 0.022102479, 0.017169738, 0.0094386125, 0.018592644, -0.013849623, 0.02656092, -0.027130082, -0.03320115, -0.0006017708, -0.010671798, -0.0045295856, -0.0123318555, 0.0061659277, -0.007873415, -0.02020527, 0.027699245, 0.021059014, -0.010197496, -0.034339476, 0.018592644, 0.043256354, -0.015651971, -0.04667133, 0.02864785, -0.020679573, 0.016600575, -0.022102479, -0.042118028, 0.014608506, -0.0035809812, 0.003794417, 0.028458128, -0.0035098358, -0.011051239, -0.00018601537, 0.03965166, 0.030545058, 0.028078686, -0.020300131, -0.0017667755, 0.0023003654, 0.0017549179, 0.010339786, -0.039272215, -0.027509524, -0.030545058, 0.014608506, -0.06829951, 0.0040315683, -0.013754762, 0.025043152, -0.008584869, -0.021153875, -0.003320115, 0.0056916256, -0.07892387, -0.036616124, -0.014134204, 0.022387061, -0.021343596, -0.019161806, 0.07133504, 0.014513645, 0.000557305, -0.009391182, 0.021248735, -0.008015706, -0.030545058, 0.053501282, 0.0015296243, -0.039082497, -0.05122463, -0.018118342, -0.012901018, 0.030734777, 0.025991756, -0.0133753205, 0.03718529, 0.0055256197, -0.017359458, -0.036236685, -0.036616124, -0.00024011545, 0.019636108, -0.011904984, 0.0034861206, 0.008347717, 0.008347717, -0.004055283, 0.06488453, -0.02902729}}
+var VectorDocument12 = VectorDocument{ID: "1a.34.2a", Text: Chunk12.Body, Vector: []float64{-0.009865484, 0.016885156, -0.011478112, -0.061090115, 0.0034149755, 0.056157373, 0.010150066, -0.004932742, 0.0056679104, 0.028268408, -0.014608506, 0.04591245, 0.014608506, 0.0012391143, -0.02694036, 0.0040315683, -0.02864785, 0.022576781, 0.0055256197, 0.018118342, -0.024663711, 0.022956224, 0.015082808, -0.010624368, 0.018213201, -0.0005543406, 0.0042924345, -0.04041054, 0.042687193, -0.021153875, 0.034529194, 0.018877225, -0.024853433, -0.05122463, 0.060710672, 0.014987947, 0.014703366, 0.01574683, 0.0063556484, -0.03756473, 0.047430214, 0.00053951866, -0.001274687, 0.015367389, -0.022671642, 0.036236685, -0.06526397, 0.011857553, 0.05122463, 0.050845187, 0.022956224, 0.07209393, -0.027888965, 0.05312184, 0.056916256, 0.027888965, -0.008063137, 0.030545058, 0.025043152, 0.024094548, -0.010908949, 0.009818054, 0.043256354, 0.06526397, -0.011810123, -0.05122463, -0.030355336, -0.0028458128, -0.02902729, 0.016885156, -0.020679573, -0.02902729, 0.04230775, -0.026181478, -0.02011041, -0.015082808, 0.101690374, -0.00547819, -0.025422594, 0.04439468, 0.02011041, -0.0045058704, -0.030165616, -0.018782364, 0.015651971, -0.037375007, 0.022956224, 0.028078686, 0.039461937, -0.0036758415, -0.009011741, -0.02020527, 0.042687193, -0.03775445, 0.021628177, -0.030734777, -0.008584869, -0.056157373, 0.021723038, 0.050086305, -0.014987947, 0.010197496, -0.02864785, -0.01574683, 0.050465748, -0.0023003654, -0.025802037, 0.0067825206, -0.0021106445, 0.0050276024, -0.017359458, 0.012426716, -0.036236685, -0.037375007, 0.045533005, -0.030734777, 0.019351527, -0.010292356, 0.036616124, -0.017264597, -0.0177389, -0.027130082, -0.018402923, -0.028458128, 0.012711297, -0.025802037, 0.050465748, 0.021059014, -0.03358059, 0.019636108, -0.038513333, -0.010861519, 0.029596454, 0.0043161493, 0.0045770155, -0.014039343, -0.009675764, -0.041169424, -0.025043152, -0.061090115, 0.041359145, 0.019256666, 0.020300131, 0.048189096, -0.043256354, 0.0023833683, -0.021153875, -0.027509524, -0.011572972, 0.032442264, 0.014134204, -0.0028458128, 0.08575383, 0.038513333, 0.025422594, -0.0028458128, 0.0021462173, -0.003320115, 0.034718916, -0.04173859, -0.0027983827, 0.0017549179, 0.028268408, 0.01992069, 0.002869528, 0.02011041, -0.01109867, 0.0112883905, -0.011857553, 0.02219734, -0.02020527, -0.034339476, -0.023145944, 0.08082108, -0.02447399, 0.06829951, 0.03775445, 0.0031541092, -0.034718916, -0.038513333, 0.0065453695, 0.026750641, -0.012094704, 0.005786486, 0.024189409, -0.009343752, -0.017359458, 0.013090739, 0.018782364, 0.016315993, 0.04591245, 0.050086305, 0.002976246, 0.0445844, 0.039082497, 0.08727159, -0.00047430213, -0.010434647, -0.0067825206, 0.047619935, -0.004648161, -0.02694036, 0.0072093923, 0.019636108, 0.025422594, -0.02694036, 0.00664023, -0.019636108, -0.004909027, -0.06526397, -0.0040315683, -0.0009604618, 0.018877225, -0.050465748, 0.011620402, -0.009391182, 0.07247337, -0.010624368, -0.02902729, 0.05539849, -0.04894798, 0.002869528, -0.013849623, 0.038133893, 0.00441101, -0.012995878, 0.0041264286, 0.009154031, -0.0063556484, -0.016126273, 0.023620246, 0.06222844, 0.0027983827, 0.018592644, 0.01982583, 0.038513333, -0.018213201, -0.0073991134, -0.004932742, 0.0024900862, 0.036616124, -0.06222844, -0.0039367075, -0.0047904514, 0.019730968, 0.029975895, 0.010529507, -0.004434725, 0.030355336, -0.07854443, -0.030165616, -0.014134204, -0.05388072, -0.04022082, -0.0007084888, 0.0015296243, -0.02428427, 0.012995878, 0.02656092, 0.011193531, 0.0013576898, -0.015082808, 0.025043152, -0.03301143, 0.017264597, -0.02902729, 0.0066876602, -0.047619935, 0.0018497783, 0.0018972085, 0.020679573, -0.024663711, 0.04648161, -0.039082497, 0.05577793, -0.008300288, 0.045343284, 0.011383251, -0.005146178, 0.011620402, 0.046861053, -0.038133893, -0.019161806, -0.020584712, 0.03718529, 0.02694036, -0.0030592487, -0.00111461, -0.025612315, -0.02219734, 0.012142135, -0.047430214, -0.01574683, 0.007351683, 0.024663711, -0.00664023, -0.042687193, 0.004434725, -0.05577793, 0.011383251, 0.01982583, -0.041548867, -0.044204958, -0.029596454, -0.002739095, 0.011667833, -0.02447399, -0.054639608, -0.023240805, 0.023620246, 0.009154031, 0.020774433, 0.027509524, 0.058434024, 0.07209393, -0.023904828, 0.07133504, 0.009011741, 0.027699245, 0.012426716, 0.007541404, -0.00090710283, -0.0007825985, 0.039082497, -0.021438457, 0.025043152, -0.03168338, 0.016790295, -0.03168338, -0.04439468, -0.026750641, -0.001565197, 0.0029643883, 0.04382552, 0.013659902, 0.09068657, 0.023809968, 0.022387061, 0.010576937, -0.013565041, -0.016790295, -0.0013221172, 0.0076836944, -0.002039499, -0.009391182, -0.020584712, -0.03984138, 0.03111422, 0.06867895, -0.01754918, -0.00056916254, 0.029217012, 0.010814088, 0.021723038, 0.02428427, -0.045153562, 0.06678174, 0.025232874, 0.021153875, -0.023145944, -0.038133893, -0.015082808, -0.025612315, 0.030165616, 0.06829951, 0.01754918, -0.036046963, 0.04648161, -0.009059171, 0.013090739, -0.021059014, 0.019636108, -0.009865484, 0.048189096, 0.0010731086, 0.0041975738, 0.027319804, -0.025612315, -0.0058576316, 0.034529194, 0.0071619623, 0.004434725, -0.0111461, -0.0031541092, -0.04022082, 0.02447399, 0.042876914, 0.00084188627, -0.015367389, -0.010576937, -0.021343596, -0.041548867, 0.015651971, 0.047619935, -0.0022766502, 0.03301143, 0.02656092, 0.049327422, 0.062607884, -0.018402923, 0.016505715, 0.012094704, -0.049327422, 0.0074939737, -0.07133504, 0.038513333, -0.027888965, -0.021817898, -0.029786173, 0.0041738586, -0.0032252546, -0.0066876602, -0.0094386125, -0.017074877, -0.06829951, 0.002656092, -0.010529507, 0.009533473, -0.010861519, -0.034718916, 0.02864785, -0.00022677571, 0.018023482, 0.00039129925, 0.011857553, -0.029975895, 0.026181478, 0.018402923, 0.03509836, -0.008015706, -0.013185599, 0.04894798, 0.006403079, -0.03756473, -0.010102635, 0.021817898, 0.013754762, 0.042687193, -0.0023952257, 0.007920845, -0.016790295, 0.04894798, -0.025422594, 0.0065453695, 0.041548867, 0.049327422, -0.030734777, 0.00441101, 0.025043152, 0.002893243, 0.050845187, 0.030355336, 0.031303942, 0.030165616, 0.025612315, -0.0222922, -0.007304253, -0.0066876602, -0.050845187, 0.015177668, -0.022861363, -0.013090739, 0.028268408, -0.032442264, 0.06829951, 0.04894798, -0.03984138, 0.060331233, -0.028078686, 0.0017549179, 0.042876914, 0.027319804, -0.023430526, 0.009296322, 0.049706865, 0.01764404, 0.010671798, 0.037375007, -0.077026665, 0.030165616, -0.011904984, -0.0074465433, 0.018402923, 0.011715263, 0.053501282, 0.03111422, 0.059192907, -0.0037469869, -0.053501282, -0.021628177, -0.034339476, 0.03111422, -0.058434024, -0.0028458128, -0.08878936, -0.0016007697, -0.008442578, -0.02656092, -0.034149755, 0.06488453, -0.023715107, 0.003130394, -0.029596454, 0.039082497, 0.010434647, -0.025232874, 0.00084781507, -0.003320115, 0.0038418472, 0.07512946, -0.022671642, -0.0061184973, -0.012995878, 0.030165616, -0.023715107, -0.005075033, 0.027319804, 0.0445844, -0.013754762, -0.0055967653, -0.011430682, 0.036616124, 0.036236685, -0.042687193, -0.030165616, -0.051604073, -0.06298732, -0.036805846, 0.059192907, 0.021153875, 0.01328046, 0.04894798, -0.0032489696, 0.012236995, -0.03775445, -0.042118028, -0.011620402, -0.061469555, -0.030355336, -0.010719229, -0.020584712, 0.028268408, 0.054260164, 0.03756473, -0.061090115, -0.019066947, -0.01555711, -0.05388072, -0.03528808, 0.004007853, -0.026181478, 0.030734777, 0.014513645, -0.00027420593, -0.016885156, -0.04041054, -0.04041054, -0.025232874, 0.025043152, 0.0025256588, -0.018782364, 0.038133893, -0.008110566, -0.07323225, -0.018592644, -0.0031778242, 0.006047352, -0.025422594, 0.0049801725, -0.010861519, 0.061469555, -0.012995878, 0.012995878, -0.03528808, 0.030545058, -0.024853433, 0.0021699322, 0.011715263, -0.008584869, -0.0019802114, 0.00069070247, -0.007351683, 0.011193531, -0.060331233, 0.016600575, -0.038133893, -0.0020039266, 0.008252857, -0.011715263, 0.0045533003, 0.021817898, -0.012142135, -0.06526397, 0.030355336, -0.026750641, -0.014608506, -0.012142135, 0.061090115, 0.0002312223, 0.017359458, 0.0036521265, 0.052742396, 0.012236995, 0.020774433, -0.009059171, 0.0123318555, 0.011904984, -0.007920845, 0.0009841769, 0.023240805, 0.00045651582, 0.00673509, 0.06867895, -0.012995878, 0.010624368, -0.036236685, -0.0032015394, -0.04230775, -0.060710672, 0.02191276, 0.022861363, -0.011193531, -0.028837569, 0.023809968, -0.055019047, 0.002039499, -0.06336676, -0.018118342, -0.027888965, -0.0030592487, -0.0069248113, 0.0036284113, 0.013849623, 0.009154031, 0.0070196716, -0.022387061, 0.0133753205, 0.028837569, 0.052362956, 0.022387061, 0.023240805, 0.015177668, 0.02656092, -0.0048141666, 0.03149366, 0.03965166, 0.045722727, -0.047240492, -0.027888965, 0.0144187845, 0.025612315, 0.017264597, -0.010671798, -0.006023637, -0.03528808, 0.047809657, -0.040789984, -0.00664023, 0.010339786, 0.045343284, -0.02428427, 0.015651971, -0.006023637, 0.000358691, -0.023620246, 0.03528808, -0.045722727, -0.003130394, 0.009912915, 0.027319804, -0.012711297, -0.005169893, 0.047430214, -0.03984138, 0.027130082, -0.0045533003, 0.023904828, 0.006047352, 0.0056679104, -0.034529194, -0.0036758415, -0.011051239, 0.04591245, -0.014893087, 0.046861053, 0.09068657, 0.042687193, -0.06450509, 0.021343596, 0.016600575, -0.043635797, 0.004909027, -0.08233885, 0.0041975738, -0.0055967653, -0.030355336, -0.050845187, -0.038513333, 0.020300131, -0.0037232717, -0.046861053, -0.011620402, -0.036046963, 0.0058576316, -0.024094548, 0.012995878, 1.130173e-05, 0.012806158, -0.0041264286, -0.0026323767, 0.059192907, -0.05388072, -0.04173859, -0.06867895, 0.014229064, -0.05995179, 0.0083951475, -0.022102479, 0.029596454, 0.039082497, -0.00062252156, -0.018782364, 0.02864785, -0.023335665, 0.019256666, 0.0066876602, 0.0036995567, 0.052362956, 0.015082808, -0.03377031, 0.041359145, 0.019730968, -0.0011264676, -0.02864785, -0.0076836944, -0.011193531, 0.015272529, 0.0041975738, -0.06678174, 0.017359458, 0.08082108, 0.026181478, 0.015367389, -0.017169738, 0.034718916, -0.021628177, -0.05122463, 0.029217012, -0.023715107, -0.010624368, 0.019066947, -0.009912915, 0.014039343, -0.04894798, 0.026750641, 0.03832361, 0.009628333, 0.030545058, -0.02428427, 0.038133893, 0.028458128, 0.009106601, -0.02864785, -0.014608506, -0.016790295, 0.04041054, -0.0030829639, -0.027319804, 0.014134204, 0.03301143, -0.0019446388, 0.0052884687, 0.010529507, -0.03377031, -0.011715263, 0.003912993, 0.0111461, 0.06526397, -0.0009841769, -0.038513333, 0.010861519, -0.045343284, 0.022576781, -0.017169738, -0.016695434, 0.000610664, -0.036805846, 0.08309773, -0.06905839, -0.04022082, 0.027888965, -0.0177389, 0.02902729, 0.028078686, 0.0054544746, 0.038703054, -0.012521576, -0.03984138, 0.01992069, 0.011620402, 0.032062825, -0.027699245, -0.00877459, -0.0061184973, 0.05577793, -0.0052173235, -0.006829951, -0.012142135, -0.036046963, -0.06412565, 0.004434725, 0.02428427, 0.023430526, 0.02656092, -0.015651971, 0.015367389, -0.019636108, -0.038703054, 0.06298732, 0.04173859, 0.034339476, 0.009533473, 0.00054248306, -0.05388072, -0.025612315, -0.052362956, -0.02902729, 0.023620246, -0.012047274, -0.04667133, 0.07019672, 0.0017074877, 0.0022766502, -0.015177668, 0.02219734, 0.015367389, 0.072852805, -0.05388072, -0.03320115, 0.022956224, 0.01584169, -0.01754918, -0.058813464, -0.02447399, -0.048378818, -0.02447399, 0.04249747, 0.045343284, 0.056916256, -0.092583776, 0.014513645, 0.08233885, 0.03528808, 0.055019047, 0.04173859, 0.03509836, -0.0049564573, -0.018118342, 0.034529194, -0.006450509, -0.00891688, -0.00069959566, 0.024094548, -0.0011976128, -7.7074095e-05, -0.0010434646, -0.01982583, 0.027319804, 0.036426403, 0.014798227, 0.039461937, -0.036616124, 0.012236995, 0.0007055244, -0.04022082, 0.026181478, -0.08233885, 0.03756473, -0.0026442343, 0.02428427, -0.042876914, -0.02656092, -0.045343284, -0.03566752, 0.07019672, 0.010814088, -0.006450509, 0.017264597, 0.025232874, -0.03339087, -0.00021491815, 0.018402923, 0.01555711, 0.013849623, 0.002656092, -0.0061659277, -0.050086305, -0.029217012, 0.026181478, -0.01109867, -0.061090115, -0.028078686, -0.015651971, 0.023240805, 0.054260164, -0.025991756, 0.0015177669, 0.005739056, 0.025043152, 0.0028458128, -0.05312184, -0.053501282, -0.0094386125, -0.0010553222, -0.008063137, 0.01754918, 0.01982583, 0.0047193062, -0.0354778, 0.02191276, 0.032252546, -0.046291888, 0.045533005, -0.04591245, 0.023809968, -0.03528808, 0.012521576, 0.0040315683, -0.008110566, 0.05312184, -0.026181478, -0.02447399, -0.009154031, 0.022102479, 0.017169738, 0.0094386125, 0.018592644, -0.013849623, 0.02656092, -0.027130082, -0.03320115, -0.0006017708, -0.010671798, -0.0045295856, -0.0123318555, 0.0061659277, -0.007873415, -0.02020527, 0.027699245, 0.021059014, -0.010197496, -0.034339476, 0.018592644, 0.043256354, -0.015651971, -0.04667133, 0.02864785, -0.020679573, 0.016600575, -0.022102479, -0.042118028, 0.014608506, -0.0035809812, 0.003794417, 0.028458128, -0.0035098358, -0.011051239, -0.00018601537, 0.03965166, 0.030545058, 0.028078686, -0.020300131, -0.0017667755, 0.0023003654, 0.0017549179, 0.010339786, -0.039272215, -0.027509524, -0.030545058, 0.014608506, -0.06829951, 0.0040315683, -0.013754762, 0.025043152, -0.008584869, -0.021153875, -0.003320115, 0.0056916256, -0.07892387, -0.036616124, -0.014134204, 0.022387061, -0.021343596, -0.019161806, 0.07133504, 0.014513645, 0.000557305, -0.009391182, 0.021248735, -0.008015706, -0.030545058, 0.053501282, 0.0015296243, -0.039082497, -0.05122463, -0.018118342, -0.012901018, 0.030734777, 0.025991756, -0.0133753205, 0.03718529, 0.0055256197, -0.017359458, -0.036236685, -0.036616124, -0.00024011545, 0.019636108, -0.011904984, 0.0034861206, 0.008347717, 0.008347717, -0.004055283, 0.06488453, -0.02902729}}
 
 var Prompt = "this is a test prompt."
 var PromptVD = VectorDocument{
 	ID:     "",
+	Text:   Prompt,
 	Vector: []float64{-0.10177986, -0.020542726, 0.008053682, -0.0060110814, 0.009220882, -0.012080523, 0.062095057, 0.012022163, 0.070032015, -0.028246248, -0.013948044, -0.0072950018, 0.044587053, 0.0012109703, -0.062095057, 0.013714603, -0.039451372, 0.08590594, 0.027429206, 0.024044326, -0.010796603, -0.00014863566, 0.03408225, 0.048322093, -0.04108545, -0.028946567, -0.029880328, -0.020426005, 0.055792175, -0.04598769, 0.050656494, 0.011847083, 0.003735041, -0.06863138, 0.032214727, 0.051590253, 0.026378727, 0.026728887, 0.0062445216, -0.0018091605, 0.032214727, -0.022993846, -0.0016705554, -0.017391285, 0.029880328, 0.031747848, -0.03688353, 0.045053933, 0.04412017, -0.011788723, 0.018558485, 0.06302882, -0.0011672003, -0.0036475009, 0.0029325907, 0.039451372, -0.03991825, -0.04412017, -0.045053933, 0.0014662953, -0.078902744, 0.026962327, -0.023460725, 0.033381928, 0.0011817903, -0.043419853, -0.014473284, 0.019375525, -0.067697614, -0.03291505, -0.024394486, -0.03408225, 0.028012807, 0.004464541, -0.04692145, -0.057659697, 0.04131889, -0.0008352777, -0.0078786025, -0.03805073, 0.009337602, -0.0069448417, -0.00012675066, 0.033381928, 0.0015100654, -0.029180007, 0.04692145, 0.017157845, 0.03921793, -0.0010067102, 0.04271953, -0.0016267854, 0.022060085, 0.010796603, 0.004231101, -0.016224084, 0.08310466, -0.017041124, -0.0704989, 0.017858164, 0.009337602, 0.008754002, 0.024277767, -0.03898449, 0.0658301, 0.04178577, 0.03921793, 0.09057474, -0.016574245, 0.004260281, 0.027312487, 0.029763607, 0.050189614, -0.0704989, 0.06489634, -0.024861366, 0.021359766, -0.04131889, -0.014414924, 0.0065363217, -0.012489043, -0.023577446, -0.0012474454, 0.0751677, -0.062095057, 0.020309286, 0.03758385, -0.03711697, 0.064429455, 0.08964098, 0.023460725, -0.048555534, -0.030113768, 0.0049022413, -0.07096578, -0.030580647, -0.0032681609, -0.056492496, -0.059760656, -0.015056884, 0.03361537, 0.03758385, 0.03244817, -0.018908644, -0.03128097, -0.0060986215, -0.0056901015, -0.008345482, 0.011263483, 0.009512682, -0.0024657107, -0.022760406, 0.00094105524, -0.010854963, 0.029530168, -0.025911847, -0.024277767, 0.018908644, -0.013831324, 0.021359766, -0.011496923, 0.00097023527, 0.010913323, 0.021593206, 0.026728887, 0.0007842127, -0.03524945, 0.03805073, 0.015173604, 0.045520812, -0.063495696, 0.0078786025, 0.03805073, -0.032681607, -0.03758385, 0.011730363, -0.0052815815, -0.017391285, -0.014006403, -0.057426255, -0.004201921, 0.026145287, -0.027312487, -0.04482049, 0.024044326, 0.028946567, 0.027896088, -0.010446442, 0.020309286, -0.035016008, -0.004522901, 0.018441765, 0.018091604, 0.020192565, -0.056492496, 0.054391533, 0.050656494, 0.009804483, 0.04085201, 0.06396258, 0.017741445, 0.020192565, -0.00063101767, 0.027779367, -0.0057484615, -0.023694167, -0.025795126, -0.051123373, 0.020309286, -0.028246248, -0.050656494, -0.049956173, -0.024044326, 0.0017362104, 0.011030043, 0.030814089, 0.011321843, 0.03408225, -0.08824034, 0.016574245, -0.027429206, 0.019492244, 0.04108545, 0.03571633, 0.012080523, 0.008929082, 0.03571633, -0.0034286508, 0.0017289155, 0.013364444, -0.006273702, -0.03688353, 0.008228762, 0.029880328, -0.03571633, -0.013714603, 0.015290324, 0.019608965, -0.0014590004, -0.03735041, 0.028012807, 0.014648364, -0.010913323, -0.03991825, 0.019725686, 0.03571633, 0.011555283, 0.009629402, -0.021126326, -0.0658301, 0.053457774, -0.03408225, 0.004231101, 0.03851761, 0.030113768, 0.0029909508, -0.011788723, -0.020659445, 0.050189614, 0.008578923, -0.025911847, -0.0015757204, 0.052990895, 0.011321843, 0.025561687, 0.007411722, 0.032214727, 0.017974885, -0.04178577, 0.023694167, 0.009512682, 0.016574245, 0.049022414, 0.014823444, 0.059293777, 0.029180007, -0.010796603, -0.03524945, -0.0060402616, -0.024161046, 0.016457524, 0.0051940414, -0.0017289155, -0.021709926, 0.016807685, -0.03431569, -0.06489634, -0.03571633, 0.0013933454, -0.011847083, -0.04528737, -0.04435361, -0.029763607, -0.03594977, -0.003910121, 0.015407044, -0.06863138, -0.011321843, 0.0063904217, -0.03594977, 0.025094807, 0.017508004, 0.04528737, -0.04412017, 0.010796603, 0.031047529, -0.054624975, 0.018091604, 0.010388083, 0.03968481, -0.050656494, -0.0004559376, -0.012138884, 0.030347208, 0.07376706, 0.0027721007, 0.03478257, 0.051823694, -0.003282751, 0.03781729, 0.008112042, 0.0027866908, 0.048555534, 0.04038513, -0.014181484, 0.010913323, 0.0042894613, -0.022410246, 0.048788972, 0.016574245, 0.013539524, -0.004727161, 0.019258805, -0.022643685, -0.04528737, 0.070032015, -0.005369121, 0.0016559655, -0.0041435612, -0.009746122, 0.029880328, -0.019608965, 0.04598769, -0.03291505, -0.04692145, -0.07189954, 0.004231101, 0.03314849, 0.03898449, -3.3283446e-05, -0.003457831, -0.015523764, 0.014181484, 0.008462202, 0.024978086, 0.026145287, -0.011730363, -0.026028566, -0.03688353, -0.012138884, -0.08497218, 0.007761882, -0.012955924, -0.004085201, -0.011321843, 0.025094807, -0.042252652, 0.019375525, -0.020426005, -0.004756341, -0.04692145, 0.045520812, 0.009921202, 0.0064487816, 0.0024657107, 0.013247724, -0.028829848, -0.00011672003, -0.027779367, 0.023927607, 0.020309286, -0.021243045, 0.019842405, -0.006273702, -0.022060085, 0.029880328, -0.059060335, -0.020892885, -0.0006419602, -0.024394486, -0.06489634, -0.032214727, 0.03781729, -0.020776166, -0.03431569, 0.009454322, 0.00046140887, -0.06629698, 0.008112042, -0.03431569, 0.0051065013, -0.017624725, 0.026495447, -0.024861366, -0.018558485, -0.016690964, 0.0007295002, 0.057426255, 0.04925585, 0.013422804, 0.028012807, -0.04412017, 0.018791925, -0.03548289, -0.0007769177, 0.045053933, -0.022760406, 0.013072643, 0.010446442, 0.010679883, -0.026495447, 0.013889683, -0.014414924, -0.04692145, 0.021709926, 0.026145287, -0.010913323, 0.03431569, 0.014006403, -0.004464541, 0.0028888208, 0.014648364, 0.024277767, -0.060694415, 0.005223221, 0.014823444, -0.0007878602, 0.04528737, -0.021243045, 0.03524945, 0.007820242, -0.057426255, -0.026028566, 0.031047529, -0.010154643, 0.026145287, 0.04131889, -0.020426005, -0.03128097, 0.00055077265, 0.0028158708, 0.0658301, -0.010796603, 0.026728887, -0.020309286, -0.0028304607, -0.026145287, -0.029763607, -0.027079048, 0.03571633, 0.0001322219, 0.04645457, -0.003735041, -0.0021885005, 0.022293527, -0.009804483, -0.011555283, -0.026145287, 0.021476485, 0.056259055, -0.019492244, -0.066763856, -0.025328247, 0.03198129, -0.007324182, 0.04598769, 0.03875105, -0.019959126, -0.006974022, 0.040618572, -0.060694415, 0.054624975, 0.033848807, 0.022060085, 0.03198129, 0.030347208, -0.010096283, 0.059293777, -0.023694167, 0.018791925, -0.015757205, 0.025211526, -0.056025613, -0.012722483, -0.0021739106, -0.022643685, 0.015290324, 0.04295297, -0.024394486, -0.03431569, -0.014356564, 0.0009556452, -0.03851761, 0.004668801, 0.020776166, -4.377001e-05, 0.017741445, 0.020542726, -0.016457524, -0.04692145, -0.064429455, -0.03688353, 0.025444966, 0.03548289, -0.004347821, 0.0053399415, 0.030113768, 0.020192565, 0.0016413755, 0.0056609213, 0.03805073, -4.3314074e-05, -0.0051065013, -0.0051940414, -0.047154892, 0.03898449, 0.0002863288, -0.023460725, -0.0145900035, 0.009454322, -0.0010140053, 0.019375525, 0.004260281, -0.007995322, -0.022643685, 0.021826645, -0.030347208, 0.008812362, -0.007528442, 0.014881804, -0.004026841, 0.0938429, -0.015523764, 0.022060085, 0.010913323, -0.0030201308, -0.026845608, 0.052290574, -0.0029034107, 0.015056884, 0.0029909508, 0.022760406, -0.005223221, -0.0010431852, 0.015990645, 0.03431569, 0.026845608, 0.013247724, -0.007061562, -0.023110567, 0.03594977, 0.023694167, -0.020892885, 0.030113768, -0.011380203, -0.016457524, -0.0018967005, 0.0027575106, -0.021709926, -0.0015684254, -0.04808865, -0.022176806, 0.011672003, 0.004522901, 0.03991825, 0.016690964, -0.0060986215, -0.06396258, 0.017858164, 0.03851761, 0.023227286, -0.03314849, 0.0035745509, 0.013014283, 0.020659445, -0.030113768, 0.007645162, 0.03968481, -0.009804483, 0.007586802, -0.03781729, -0.0023781706, 0.0051356815, -0.049956173, -0.018675204, 0.021243045, -0.048788972, -0.007178282, -0.0058943615, -0.0008571627, 0.035016008, -0.013831324, 0.014064764, 0.031747848, -0.022410246, -0.026845608, 0.004172741, -0.014006403, 0.023577446, 0.046688013, 0.018325044, -0.003355701, -0.049956173, 0.019375525, -0.026962327, -0.059060335, -0.051123373, 0.028829848, -0.010271363, -0.0015903104, -0.0051356815, 0.035016008, 0.03711697, -0.04482049, -0.07189954, -0.018325044, -0.023344006, 0.018558485, -0.064429455, -0.06489634, 0.04248609, 0.017041124, 0.03524945, 0.024161046, 0.04412017, 0.003662091, 0.019725686, 0.008228762, 0.016457524, 0.019258805, 0.013831324, 0.0082871225, -0.03314849, -0.003793401, -0.047855213, -0.050656494, -0.011788723, 0.03875105, 0.06863138, 0.054624975, -0.054391533, -0.052757453, 0.06256194, -8.3436586e-05, 0.0057776417, -0.033848807, -0.06256194, -0.046221133, -0.04972273, 0.030347208, -0.04925585, -0.025795126, 0.081237145, 0.0013495754, 0.028246248, -0.023227286, 0.004668801, -0.04108545, 0.00085351523, 0.047855213, 0.03198129, 0.0060110814, 0.0015976054, -0.021359766, 0.025911847, 0.016107364, 0.029646888, 0.028012807, 0.003414061, 0.026378727, 0.018675204, -0.0065071415, 0.015056884, 0.008695642, -0.029880328, 0.010913323, 0.014531644, 0.021826645, -0.013597883, -0.011380203, -0.064429455, 0.0039393012, -0.023344006, -0.008929082, -0.028129527, 0.040618572, -0.048322093, 0.009804483, 0.027312487, -0.020426005, -0.019608965, 0.04248609, -0.018091604, -0.013247724, 0.03805073, -0.005369121, -0.03478257, 0.025328247, 0.023460725, 0.043419853, 0.04248609, -0.012664123, 0.011672003, -0.028596407, -0.0022468606, -0.010446442, 0.028946567, -0.0072950018, -0.019258805, -0.046688013, 0.021593206, -0.050189614, 0.0014371154, -0.03805073, 0.024161046, 0.021826645, -0.025328247, 0.021476485, 0.016457524, 0.033381928, 0.013481163, -0.019608965, 0.017391285, 0.050423052, 0.0004085201, -0.024861366, 0.018908644, -0.016457524, -0.0032243908, 0.045520812, -0.021243045, -0.048555534, 0.031047529, 0.012780843, -0.028012807, -0.011146763, 0.048555534, 0.057659697, -0.03594977, -0.004756341, -0.015523764, -0.013072643, 0.062095057, 0.013189363, -0.031047529, -0.051123373, -0.021476485, 0.026262008, -0.0056025614, 0.04925585, -0.048555534, -0.003662091, -0.040618572, -0.024511207, -0.047388334, -0.032214727, -0.006273702, -0.03851761, 0.013247724, 0.040618572, 0.03688353, -0.0046979813, 0.025444966, -0.03151441, 0.007032382, -0.024861366, 0.020426005, -0.013014283, 0.052524015, 0.03478257, -0.03314849, -0.012605763, 0.07236642, -0.019375525, 0.0070032016, 0.058126576, 0.011088403, 0.03431569, -0.047855213, -0.006623862, -0.03618321, 0.018908644, -0.0052815815, -0.03618321, 0.017741445, -0.0010431852, -0.03314849, 0.03151441, 0.047388334, 0.018791925, -0.010563163, -0.017741445, 0.007324182, 0.052524015, -0.014181484, -0.014531644, 0.030347208, 0.060227536, -0.014823444, -0.013014283, -0.020776166, -0.017157845, 0.04528737, -0.003632911, 0.015873924, -0.019492244, 0.048322093, 0.0026991507, -0.024511207, -0.010796603, 0.0012693304, -0.00012675066, 0.0014225254, -0.009337602, -0.011788723, -0.006769762, 0.056025613, 0.04271953, -0.016107364, -0.007645162, -0.04178577, -0.004260281, 0.04295297, 0.028246248, 0.0057776417, -0.030113768, 0.04271953, 0.03478257, -0.03128097, 0.013831324, -0.035016008, -0.00021611444, -0.021126326, 0.029180007, -0.03781729, 0.011205123, -0.03991825, -0.03291505, -0.06536321, 0.050423052, 0.019725686, 0.03524945, 0.004201921, 0.014006403, -0.036650088, 0.03618321, 0.0007915077, -0.03898449, 0.021359766, 0.020309286, 0.022410246, 0.0070032016, 0.003516191, 0.019492244, 0.013481163, -0.018675204, 0.054391533, -0.04808865, 0.03921793, 0.027312487, 0.027079048, 0.011088403, -0.00013586941, 0.025911847, -0.004260281, -0.045053933, -0.04645457, -0.03968481, 0.018791925, 0.06489634, -0.008754002, -0.010913323, -0.0019112905, 0.009862843, -0.024627926, -0.045520812, 0.003851761, -0.019492244, 0.018908644, 0.0775021, -0.0017653905, -0.014123124, -0.015757205, -0.026145287, 0.0014590004, 0.064429455, -0.014356564, -0.003764221, 0.018441765, -0.03991825, -0.062095057, -0.009862843, 0.03991825, -0.052290574, -0.010913323, -0.004347821, -0.062095057, 0.014298203, -0.017741445, 0.014181484, -0.0023635805, -0.014181484, -0.010679883, 0.0021447306, -0.0031660309, 0.0063320617, -0.014414924, 0.016224084, -0.010738242, -0.016574245, 0.04808865, 0.0029034107, -0.06302882, 0.028596407, -0.060694415, -0.007411722, -0.015056884, 0.03594977, 0.014298203, -0.004114381, 0.03151441, 0.045053933, -0.024861366, 0.012605763, -0.021593206, 0.016457524, -0.022526966, -0.012722483, 0.023694167, -0.030113768, 0.026145287, 0.019725686, -0.0049022413, -0.00046688013, 0.0055442015, -0.0053399415, -0.025795126, -0.00015045941, 0.025094807, -0.015990645, -0.00068937766, -0.024161046, -0.0013787553, 0.010388083, -0.057192814, -0.026028566, -0.023694167, -0.022410246, -0.04692145, -0.009337602, 0.008578923, 0.016807685, 0.047154892, 0.00042128636, 0.018558485, 0.008812362, 0.030347208, 0.007265822, -0.0016778505, 0.06396258, -0.019842405, -0.018558485, -0.003589141, 0.022526966, 0.0011599053, 0.08497218, -0.019725686, 0.017041124, 0.014006403, 0.016457524, -0.04108545, -0.013189363, 0.004464541, -0.03594977, 0.025911847, 0.006682222, 0.04972273, -0.010796603, -0.03758385, -0.021243045, -0.019959126, 0.0020863705, -0.015990645, -0.016107364, -0.022993846, 0.009279243, -0.04528737, 0.036650088, 0.013948044, 0.008228762, -0.029530168, -0.028012807, -0.019375525, 0.045520812, -0.03408225, 0.011963803, -0.023344006, -0.0054566613, 0.051823694, -0.032214727, 0.015757205, -0.0010577752, -0.015173604, -0.003632911, -0.044587053, 0.052524015, 0.020192565, 0.010213003, -0.04271953, -0.016807685, 0.04131889, 0.019725686, 0.010213003, -0.019025365},
 }
 
@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"code/core"
+	"regexp"
+	"strings"
+)
+
+// markdown the model may answer with
+var (
+	markdownBulletRegexp        = regexp.MustCompile(`(?m)^(\s*)[*+]\s+`)
+	markdownHeadingRegexp       = regexp.MustCompile(`(?m)^#{1,6}\s+(.+?)\s*#*\s*$`)
+	markdownLinkRegexp          = regexp.MustCompile(`\[([^\]\n]+)\]\(([^)\s]+)\)`)
+	markdownBoldRegexp          = regexp.MustCompile(`\*\*([^*\n]+)\*\*|__([^_\n]+)__`)
+	markdownItalicRegexp        = regexp.MustCompile(`\*([^*\n]+)\*`)
+	markdownStrikethroughRegexp = regexp.MustCompile(`~~([^~\n]+)~~`)
+	markdownCodeRegexp          = regexp.MustCompile("`([^`\n]+)`")
+)
+
+// marks bold text while italics are rewritten, so WhatsApp bold is not taken for markdown italics
+const boldPlaceholder = "\x00"
+
+// FormatForChannel rewrites markdown text for the channel. The web chat renders markdown, WhatsApp has its
+// own markup for bold, italics and strikethrough, and the other channels only show plain text.
+func FormatForChannel(text string, channel core.Channel) string {
+	switch channel {
+	case core.ChannelWeb:
+		return text
+	case core.ChannelWhatsApp:
+		return formatMarkdown(text, boldPlaceholder+"$1$2"+boldPlaceholder, "_${1}_", "~${1}~", boldPlaceholder+"${1}"+boldPlaceholder)
+	default:
+		return formatMarkdown(text, "$1$2", "$1", "$1", "$1")
+	}
+}
+
+// formatMarkdown replaces markdown with the given templates, links are written as "text (url)"
+func formatMarkdown(text, bold, italic, strikethrough, heading string) string {
+	text = markdownBulletRegexp.ReplaceAllString(text, "$1- ")
+	text = markdownHeadingRegexp.ReplaceAllString(text, heading)
+	text = markdownLinkRegexp.ReplaceAllStringFunc(text, func(link string) string {
+		match := markdownLinkRegexp.FindStringSubmatch(link)
+		if match[1] == match[2] {
+			return match[2]
+		}
+		return match[1] + " (" + match[2] + ")"
+	})
+	text = markdownBoldRegexp.ReplaceAllString(text, bold)
+	text = markdownItalicRegexp.ReplaceAllString(text, italic)
+	text = markdownStrikethroughRegexp.ReplaceAllString(text, strikethrough)
+	text = markdownCodeRegexp.ReplaceAllString(text, "$1")
+	return strings.ReplaceAll(text, boldPlaceholder, "*")
+}
@@ -0,0 +1,266 @@
+package helpers
+
+import (
+	"code/core"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const mnRevisorStatutesCiteURL = "https://www.revisor.mn.gov/statutes/cite/"
+
+var multipleSpacesRegexp = regexp.MustCompile(`[ \t]{2,}`)
+
+// clauses cited after a subdivision or subpart, e.g. ", clause (3)" or ", item A"
+const clausesPattern = `(?:\s*,?\s*(?:paragraph|clause|item|subitem|unit|subunit)\s*(?:\([0-9a-z]+\)|[a-z]\b))*`
+
+// statute number followed by an optional subdivision and clauses, e.g. "337.10, subd. 1", "86B.33",
+// "609.52 subdivision 2" or "609.02, subd. 2, clause (3)"
+const statuteNumberPattern = `(\d+[a-z]?)\.(\d+[a-z]?)\b(?:\s*,?\s*(?:subd\.?|subds\.|subdivisions?)\s*(\d+[a-z]?)\b` + clausesPattern + `)?`
+
+// rule part followed by an optional subpart and clauses, e.g. "7100.0100, subp. 3" or "7100.0100 subpart 3, item A"
+const rulePartPattern = `(\d{4})\.(\d{4})\b(?:\s*,?\s*(?:subps?\.?|subparts?)\s*(\d+[a-z]?)\b` + clausesPattern + `)?`
+
+// matches "§ 337.10, subd. 1", "§ 86B.33", "section 609.52, subdivision 2", "Minn. Stat. 1.142" and
+// "Minn. R. 7100.0100, subp. 3"
+var citationRegexp = regexp.MustCompile(`(?i)(?:(?:§+|\bsections?|\bminn\.\s*stat\.)\s*` + statuteNumberPattern +
+	`|\bminn\.\s*r\.\s*(?:part\s*)?` + rulePartPattern + `)`)
+
+// rules cited as users text them, e.g. "rule 7100.0100" or "part 7100.0100"
+const promptRulePattern = `(?:\bminn\.\s*r\.|\bminnesota\s+rules,?|\brules?|\bparts?)\s*(?:part\s*)?` + rulePartPattern
+
+// also matches bare statute numbers followed by a subdivision, as users text them, e.g. "86B.33 subd 1", but not
+// bare numbers on their own, e.g. "0.08 BAC" or "2.5 drinks"
+var promptCitationRegexp = regexp.MustCompile(`(?i)(?:(?:§+|\bsections?|\bminn\.\s*stat\.)\s*` + statuteNumberPattern +
+	`|` + promptRulePattern +
+	`|\b(\d+[a-z]?)\.(\d+[a-z]?)\s*,?\s*(?:subd\.?|subds\.|subdivisions?)\s*(\d+[a-z]?)\b` + clausesPattern + `)`)
+
+// also matches bare statute numbers, the arguments of commands, e.g. "SOURCE 609.52", are always citations
+var commandCitationRegexp = regexp.MustCompile(`(?i)(?:(?:(?:§+|\bsections?|\bminn\.\s*stat\.)\s*|\b)` + statuteNumberPattern +
+	`|` + promptRulePattern + `)`)
+
+var statuteNumberRegexp = regexp.MustCompile(`^(\d+[A-Za-z]?)\.(\d+[A-Za-z]?)$`)
+
+// matches subdivisions or subparts cited on their own, e.g. "subdivision 2", "subd. 2a" or "subpart 3"
+var subdivisionRegexp = regexp.MustCompile(`(?i)\b(?:subd\.?|subds\.|subdivisions?|subparts?|subps?\.?)\s*(\d+[a-z]?)\b`)
+
+// ParseCitations returns the statute citations found in text, in order of first appearance.
+func ParseCitations(text string) []core.Citation {
+	return parseCitations(text, citationRegexp)
+}
+
+// ParsePromptCitations is like ParseCitations but also accepts statute numbers followed by a subdivision without
+// a "§" or "section" in front.
+func ParsePromptCitations(prompt string) []core.Citation {
+	return parseCitations(prompt, promptCitationRegexp)
+}
+
+// ParseCommandCitations is like ParsePromptCitations but also accepts bare statute numbers.
+func ParseCommandCitations(arguments string) []core.Citation {
+	return parseCitations(arguments, commandCitationRegexp)
+}
+
+// ParseSubdivisionReferences returns the subdivision or subpart numbers mentioned in text, in order of first
+// appearance.
+func ParseSubdivisionReferences(text string) []string {
+	subdivisions := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, match := range subdivisionRegexp.FindAllStringSubmatch(text, -1) {
+		if seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		subdivisions = append(subdivisions, match[1])
+	}
+	return subdivisions
+}
+
+func parseCitations(text string, re *regexp.Regexp) []core.Citation {
+	citations := make([]core.Citation, 0)
+	seen := make(map[string]bool)
+	for _, match := range re.FindAllStringSubmatchIndex(text, -1) {
+		chunkID := citationChunkID(text, match)
+		if seen[chunkID] {
+			continue
+		}
+		seen[chunkID] = true
+		citations = append(citations, core.Citation{ChunkID: chunkID, URL: ChunkIDToURL(chunkID)})
+	}
+	return citations
+}
+
+// StripCitations removes the citations to the given chunk IDs from text.
+func StripCitations(text string, chunkIDs []string) string {
+	toStrip := make(map[string]bool)
+	for _, chunkID := range chunkIDs {
+		toStrip[chunkID] = true
+	}
+	var builder strings.Builder
+	lastEnd := 0
+	for _, match := range citationRegexp.FindAllStringSubmatchIndex(text, -1) {
+		if !toStrip[citationChunkID(text, match)] {
+			continue
+		}
+		builder.WriteString(text[lastEnd:match[0]])
+		lastEnd = match[1]
+	}
+	builder.WriteString(text[lastEnd:])
+	return multipleSpacesRegexp.ReplaceAllString(builder.String(), " ")
+}
+
+// citationChunkID returns the chunk ID of a citation matched by one of the citation regexps, whose groups are
+// the chapter, section and subdivision of a statute, then the chapter, part and subpart of a rule, then those
+// of a bare statute number if any.
+func citationChunkID(text string, match []int) string {
+	groups := match[2:8]
+	prefix := ""
+	for alternative := 1; groups[0] < 0 && len(match) >= 8+6*alternative; alternative++ {
+		groups = match[2+6*alternative : 8+6*alternative]
+		if alternative == 1 {
+			prefix = RuleChunkIDPrefix
+		} else {
+			prefix = ""
+		}
+	}
+	chunkID := prefix + text[groups[0]:groups[1]] + "." + text[groups[2]:groups[3]]
+	if groups[4] >= 0 {
+		chunkID = chunkID + "." + text[groups[4]:groups[5]]
+	}
+	return chunkID
+}
+
+// ParseStatuteNumber splits a statute number, e.g. "86B.33", into its chapter and section, ok is false if
+// it is not a statute number.
+func ParseStatuteNumber(statute string) (string, string, bool) {
+	match := statuteNumberRegexp.FindStringSubmatch(statute)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// SplitChunkID splits a chunk ID into its chapter, section and subdivision, the subdivision is empty for
+// statutes without subdivisions.
+func SplitChunkID(chunkID string) (string, string, string) {
+	parts := strings.SplitN(chunkID, ".", 3)
+	for len(parts) < 3 {
+		parts = append(parts, "")
+	}
+	return parts[0], parts[1], parts[2]
+}
+
+// SortChunkIDs sorts chunk IDs in statute order, e.g. "609.52" < "609.52.2" < "609.52.2a" < "609.52.10".
+func SortChunkIDs(chunkIDs []string) {
+	sort.Slice(chunkIDs, func(i, j int) bool {
+		partsI := strings.SplitN(chunkIDs[i], ".", 3)
+		partsJ := strings.SplitN(chunkIDs[j], ".", 3)
+		for k := 0; k < len(partsI) && k < len(partsJ); k++ {
+			numberI, suffixI := splitNumber(partsI[k])
+			numberJ, suffixJ := splitNumber(partsJ[k])
+			if numberI != numberJ {
+				return numberI < numberJ
+			}
+			if suffixI != suffixJ {
+				return suffixI < suffixJ
+			}
+		}
+		return len(partsI) < len(partsJ)
+	})
+}
+
+// splitNumber splits "2a" into 2 and "a"
+func splitNumber(part string) (int, string) {
+	end := 0
+	for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+		end++
+	}
+	number, _ := strconv.Atoi(part[:end])
+	return number, strings.ToLower(part[end:])
+}
+
+// ChunkIDToURL returns the revisor.mn.gov URL of the statute or rule, anchored at the subdivision or subpart if any.
+func ChunkIDToURL(chunkID string) string {
+	chapter, section, subdivision := SplitChunkID(chunkID)
+	if IsRuleChunkID(chunkID) {
+		part := strings.TrimPrefix(chapter, RuleChunkIDPrefix) + "." + section
+		url := mnRevisorRulesURL + part + "/"
+		if len(subdivision) > 0 {
+			url = url + "#rule." + part + "." + subdivision
+		}
+		return url
+	}
+	if len(section) == 0 {
+		return mnRevisorStatutesCiteURL + chunkID
+	}
+	url := mnRevisorStatutesCiteURL + chapter + "." + section
+	if len(subdivision) > 0 {
+		url = url + "#stat." + chunkID
+	}
+	return url
+}
+
+// ChunkIDToCitation formats a chunk ID the way Statute2SubdivisionChunks and Rule2SubpartChunks label their
+// chunks, e.g. "1a.34.2a" becomes "§ 1a.34, subd. 2a" and "R7100.0100.3" becomes "Minn. R. 7100.0100, subp. 3".
+func ChunkIDToCitation(chunkID string) string {
+	chapter, section, subdivision := SplitChunkID(chunkID)
+	if IsRuleChunkID(chunkID) {
+		citation := "Minn. R. " + strings.TrimPrefix(chapter, RuleChunkIDPrefix) + "." + section
+		if len(subdivision) > 0 {
+			citation = citation + ", subp. " + subdivision
+		}
+		return citation
+	}
+	if len(subdivision) == 0 {
+		return "§ " + chunkID
+	}
+	return "§ " + chapter + "." + section + ", subd. " + subdivision
+}
+
+// ValidateCitations flags every citation of the answer that does not correspond to any of the
+// chunks, either exactly or as the section containing a chunk's subdivision.
+func ValidateCitations(answer core.Answer, chunks []core.Chunk) core.Answer {
+	chunkIDs := make(map[string]bool)
+	for _, chunk := range chunks {
+		chunkIDs[chunk.ID] = true
+		chapter, section, _ := SplitChunkID(chunk.ID)
+		chunkIDs[chapter+"."+section] = true
+	}
+	citations := make([]core.Citation, 0, len(answer.Citations))
+	for _, citation := range answer.Citations {
+		citation.IsVerified = chunkIDs[citation.ChunkID]
+		citations = append(citations, citation)
+	}
+	answer.Citations = citations
+	return answer
+}
+
+func UnverifiedCitations(answer core.Answer) []core.Citation {
+	unverified := make([]core.Citation, 0)
+	for _, citation := range answer.Citations {
+		if !citation.IsVerified {
+			unverified = append(unverified, citation)
+		}
+	}
+	return unverified
+}
+
+// FormatAnswer returns the answer text followed by its sources, flagging unverified citations.
+func FormatAnswer(answer core.Answer) string {
+	var builder strings.Builder
+	builder.WriteString(strings.TrimSpace(answer.Text))
+	if len(answer.Citations) == 0 {
+		return builder.String()
+	}
+	builder.WriteString("\n\nSources:")
+	for _, citation := range answer.Citations {
+		builder.WriteString("\n")
+		builder.WriteString(ChunkIDToCitation(citation.ChunkID))
+		builder.WriteString(" ")
+		builder.WriteString(citation.URL)
+		if !citation.IsVerified {
+			builder.WriteString(" (unverified)")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,154 @@
+package helpers
+
+import (
+	"code/core"
+	"regexp"
+	"strings"
+)
+
+var romanNumeralRegexp = regexp.MustCompile(`^x{0,3}(?:ix|iv|v?i{0,3})$`)
+
+// clauseScheme tells the kind of each label and how deep it nests.
+type clauseScheme struct {
+	labelRegexp *regexp.Regexp
+	levels      map[core.ClauseKind]int
+	upperKind   core.ClauseKind
+	numberKind  core.ClauseKind
+	letterKind  core.ClauseKind
+	romanKind   core.ClauseKind
+}
+
+// statutes label paragraphs "(a)", clauses "(3)", items "(iv)" and subitems "(B)"
+var statuteClauseScheme = clauseScheme{
+	labelRegexp: regexp.MustCompile(`^\((\d+[a-z]?|[a-z]{1,4}|[A-Z]{1,2})\)\s*`),
+	levels: map[core.ClauseKind]int{
+		core.ClauseKindParagraph: 1,
+		core.ClauseKindClause:    2,
+		core.ClauseKindItem:      3,
+		core.ClauseKindSubitem:   4,
+	},
+	upperKind:  core.ClauseKindSubitem,
+	numberKind: core.ClauseKindClause,
+	letterKind: core.ClauseKindParagraph,
+	romanKind:  core.ClauseKindItem,
+}
+
+// rules label items "A.", subitems "(1)", units "(a)" and subunits "(i)"
+var ruleClauseScheme = clauseScheme{
+	labelRegexp: regexp.MustCompile(`^(?:([A-Z]{1,2})\.|\((\d+[a-z]?|[a-z]{1,4})\))\s+`),
+	levels: map[core.ClauseKind]int{
+		core.ClauseKindItem:    1,
+		core.ClauseKindSubitem: 2,
+		core.ClauseKindUnit:    3,
+		core.ClauseKindSubunit: 4,
+	},
+	upperKind:  core.ClauseKindItem,
+	numberKind: core.ClauseKindSubitem,
+	letterKind: core.ClauseKindUnit,
+	romanKind:  core.ClauseKindSubunit,
+}
+
+type leveledClause struct {
+	level  int
+	clause core.Clause
+}
+
+// ParseClauses nests the paragraphs of a subdivision body by their labels: paragraphs (a) hold clauses (1),
+// which hold items (i), which hold subitems (A). It returns nil when none of the paragraphs is labeled.
+func ParseClauses(paragraphs []string) []core.Clause {
+	return parseClauses(paragraphs, statuteClauseScheme)
+}
+
+// ParseRuleClauses is like ParseClauses for the subparts of rules: items A. hold subitems (1), which hold
+// units (a), which hold subunits (i).
+func ParseRuleClauses(paragraphs []string) []core.Clause {
+	return parseClauses(paragraphs, ruleClauseScheme)
+}
+
+func parseClauses(paragraphs []string, scheme clauseScheme) []core.Clause {
+	var leveledClauses []leveledClause
+	var lastLetterLabel string
+	lastLevel := 0
+	labeled := false
+	for _, paragraph := range paragraphs {
+		paragraph = strings.TrimSpace(paragraph)
+		if len(paragraph) == 0 {
+			continue
+		}
+		match := scheme.labelRegexp.FindStringSubmatch(paragraph)
+		if match == nil {
+			leveledClauses = append(leveledClauses, leveledClause{clause: core.Clause{Content: paragraph}})
+			continue
+		}
+		labeled = true
+		label := strings.Join(match[1:], "")
+		kind := scheme.clauseKind(label, lastLetterLabel, lastLevel)
+		if kind == scheme.letterKind {
+			lastLetterLabel = label
+		}
+		lastLevel = scheme.levels[kind]
+		clause := core.Clause{Kind: kind, Label: label, Content: paragraph[len(match[0]):]}
+		leveledClauses = append(leveledClauses, leveledClause{level: lastLevel, clause: clause})
+	}
+	if !labeled {
+		return nil
+	}
+	return nestClauses(leveledClauses)
+}
+
+// clauseKind tells the kind of a label from its characters. Lowercase roman numerals nest under the letters,
+// or the numbers, above them, unless they follow the previous letter, e.g. "(i)" after "(h)".
+func (scheme clauseScheme) clauseKind(label string, lastLetterLabel string, lastLevel int) core.ClauseKind {
+	switch {
+	case label[0] >= '0' && label[0] <= '9':
+		return scheme.numberKind
+	case label[0] >= 'A' && label[0] <= 'Z':
+		return scheme.upperKind
+	case romanNumeralRegexp.MatchString(label) && len(label) > 1:
+		return scheme.romanKind
+	case romanNumeralRegexp.MatchString(label) && lastLevel >= scheme.levels[scheme.romanKind]-1 &&
+		!(len(lastLetterLabel) == 1 && lastLetterLabel[0]+1 == label[0]):
+		return scheme.romanKind
+	default:
+		return scheme.letterKind
+	}
+}
+
+// nestClauses puts each labeled clause over the deeper ones following it, unlabeled paragraphs hold none.
+func nestClauses(leveledClauses []leveledClause) []core.Clause {
+	clauses := make([]core.Clause, 0)
+	for i := 0; i < len(leveledClauses); {
+		j := i + 1
+		if len(leveledClauses[i].clause.Label) > 0 {
+			for j < len(leveledClauses) && leveledClauses[j].level > leveledClauses[i].level {
+				j++
+			}
+		}
+		clause := leveledClauses[i].clause
+		if j > i+1 {
+			clause.Clauses = nestClauses(leveledClauses[i+1 : j])
+		}
+		clauses = append(clauses, clause)
+		i = j
+	}
+	return clauses
+}
+
+// FormatClauses writes the clauses one per line with their labels, indented two spaces per level of nesting.
+func FormatClauses(clauses []core.Clause) string {
+	var builder strings.Builder
+	formatClauses(&builder, clauses, 0)
+	return builder.String()
+}
+
+func formatClauses(builder *strings.Builder, clauses []core.Clause, depth int) {
+	for _, clause := range clauses {
+		builder.WriteString(strings.Repeat("  ", depth))
+		if len(clause.Label) > 0 {
+			builder.WriteString(clause.FormattedLabel() + " ")
+		}
+		builder.WriteString(strings.TrimRight(clause.Content, "\n"))
+		builder.WriteString("\n")
+		formatClauses(builder, clause.Clauses, depth+1)
+	}
+}
@@ -0,0 +1,133 @@
+package helpers
+
+import (
+	"code/core"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// matches the quoted terms a definition starts with, e.g. `"Motor vehicle" or "vehicle" means`
+var definitionRegexp = regexp.MustCompile(`((?:["“][^"”]+["”](?:\s*,\s*|\s+or\s+|\s+and\s+)?)+)\s*(?:means|includes|has the meaning|shall mean)\b`)
+
+var quotedTermRegexp = regexp.MustCompile(`["“]([^"”]+)["”]`)
+
+// matches definitions that apply to the whole chapter although they are not in its definitions section
+var chapterScopeRegexp = regexp.MustCompile(`(?i)\bthis chapter\b`)
+
+// IsDefinitionsHeading tells whether a statute title or subdivision heading is the one of definitions,
+// e.g. "DEFINITIONS." or "Definitions; applicability."
+func IsDefinitionsHeading(heading string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(heading)), "definition")
+}
+
+// ParseDefinedTerms returns the terms defined in the content, lower case and in order of first appearance.
+func ParseDefinedTerms(content string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, match := range definitionRegexp.FindAllStringSubmatch(content, -1) {
+		for _, quotedTerm := range quotedTermRegexp.FindAllStringSubmatch(match[1], -1) {
+			term := strings.ToLower(strings.TrimSpace(strings.TrimRight(quotedTerm[1], ",.")))
+			if len(term) == 0 || seen[term] {
+				continue
+			}
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// Statute2Definitions returns the definitions of the terms of the statute. The definitions of a definitions
+// section, e.g. 609.02, or of a subdivision saying they are for "this chapter" apply to the whole chapter, the
+// other ones only to the statute, e.g. 609.531, subd. 1.
+func Statute2Definitions(statute core.Statute) []core.Definition {
+	return subdivisionDefinitions(statute.Chapter+"."+statute.Section, statute.Title, statute.Subdivisions)
+}
+
+func subdivisionDefinitions(id string, title string, subdivisions []core.Subdivision) []core.Definition {
+	definitions := make([]core.Definition, 0)
+	isDefinitionsSection := IsDefinitionsHeading(title)
+	for _, subdivision := range subdivisions {
+		idSubdiv := id
+		if len(subdivision.Number) > 0 {
+			idSubdiv = idSubdiv + "." + subdivision.Number
+		}
+		isSectionScoped := !isDefinitionsSection && !chapterScopeRegexp.MatchString(subdivision.Content)
+		for _, term := range subdivision.DefinedTerms {
+			definitions = append(definitions, core.Definition{Term: term, ChunkID: idSubdiv, IsSectionScoped: isSectionScoped})
+		}
+	}
+	return definitions
+}
+
+// SectionDefinitions returns the chunk ID defining each term for the chunks of a section of the chapter of the
+// definitions. The section's own definitions win over the chapter-wide ones, and a term defined for the whole
+// chapter more than once is defined by the first subdivision in statute order.
+func SectionDefinitions(definitions []core.Definition, section string) map[string]string {
+	chunkDefinitions := make(map[string][]core.Definition)
+	chunkIDs := make([]string, 0)
+	for _, definition := range definitions {
+		if _, ok := chunkDefinitions[definition.ChunkID]; !ok {
+			chunkIDs = append(chunkIDs, definition.ChunkID)
+		}
+		chunkDefinitions[definition.ChunkID] = append(chunkDefinitions[definition.ChunkID], definition)
+	}
+	SortChunkIDs(chunkIDs)
+
+	definitionChunkIDs := make(map[string]string)
+	for _, isSectionScoped := range []bool{true, false} {
+		for _, chunkID := range chunkIDs {
+			_, definitionSection, _ := SplitChunkID(chunkID)
+			for _, definition := range chunkDefinitions[chunkID] {
+				if definition.IsSectionScoped != isSectionScoped || (isSectionScoped && definitionSection != section) {
+					continue
+				}
+				if _, ok := definitionChunkIDs[definition.Term]; !ok {
+					definitionChunkIDs[definition.Term] = chunkID
+				}
+			}
+		}
+	}
+	return definitionChunkIDs
+}
+
+// FindDefinedTerms returns the terms used in the text, ignoring case and allowing plurals, in alphabetical order.
+func FindDefinedTerms(text string, terms []string) []string {
+	text = strings.ToLower(text)
+	foundTerms := make([]string, 0)
+	for _, term := range terms {
+		if containsWord(text, term) {
+			foundTerms = append(foundTerms, term)
+		}
+	}
+	sort.Strings(foundTerms)
+	return foundTerms
+}
+
+// containsWord tells whether the term is in the text as whole words, or followed by "s" or "es". It is called
+// for every term of a chapter on every retrieved chunk, so it scans the text rather than compiling a regexp.
+func containsWord(text, term string) bool {
+	for start := 0; start < len(text); {
+		index := strings.Index(text[start:], term)
+		if index < 0 {
+			return false
+		}
+		index += start
+		end := index + len(term)
+		if index == 0 || !isWordByte(text[index-1]) {
+			for _, suffix := range []string{"", "s", "es"} {
+				if strings.HasPrefix(text[end:], suffix) && (end+len(suffix) == len(text) || !isWordByte(text[end+len(suffix)])) {
+					return true
+				}
+			}
+		}
+		start = index + 1
+	}
+	return false
+}
+
+// isWordByte tells whether the byte is a word character, like \w in a regexp.
+func isWordByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"math"
+	"strings"
+)
+
+// MatchExpectedChunkIDs tells for every found chunk ID which expected chunk ID it is the first match of, -1
+// if it matches none or only ones matched before. A chunk matches an expected chunk ID equal to it or to
+// its section, so an expected section is matched by any of its subdivisions, once.
+func MatchExpectedChunkIDs(foundChunkIDs, expectedChunkIDs []string) []int {
+	matches := make([]int, len(foundChunkIDs))
+	isMatched := make([]bool, len(expectedChunkIDs))
+	for i, foundChunkID := range foundChunkIDs {
+		matches[i] = -1
+		for j, expectedChunkID := range expectedChunkIDs {
+			if isMatched[j] {
+				continue
+			}
+			if foundChunkID == expectedChunkID || strings.HasPrefix(foundChunkID, expectedChunkID+".") {
+				matches[i] = j
+				isMatched[j] = true
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// RecallAtK is the fraction of the expected chunk IDs matched within the first k found.
+func RecallAtK(matches []int, expectedCount, k int) float64 {
+	if expectedCount == 0 {
+		return 0
+	}
+	matchedCount := 0
+	for i := 0; i < len(matches) && i < k; i++ {
+		if matches[i] >= 0 {
+			matchedCount++
+		}
+	}
+	return float64(matchedCount) / float64(expectedCount)
+}
+
+// FirstMatchRank is the position of the first match starting at 1, 0 if there is none.
+func FirstMatchRank(matches []int) int {
+	for i, match := range matches {
+		if match >= 0 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ReciprocalRank is 1/rank of the first match, 0 if there is none.
+func ReciprocalRank(matches []int) float64 {
+	rank := FirstMatchRank(matches)
+	if rank == 0 {
+		return 0
+	}
+	return 1 / float64(rank)
+}
+
+// NDCGAtK is the discounted cumulative gain of the matches within the first k found with binary
+// relevance, normalized by the gain of finding every expected chunk ID first.
+func NDCGAtK(matches []int, expectedCount, k int) float64 {
+	var dcg, idcg float64
+	for i := 0; i < len(matches) && i < k; i++ {
+		if matches[i] >= 0 {
+			dcg += 1 / math.Log2(float64(i+2))
+		}
+	}
+	for i := 0; i < expectedCount && i < k; i++ {
+		idcg += 1 / math.Log2(float64(i+2))
+	}
+	if idcg == 0 {
+		return 0
+	}
+	return dcg / idcg
+}
@@ -3,8 +3,11 @@ package helpers
 import (
 	"code/core"
 	"encoding/base64"
+	"fmt"
 	"net"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -35,27 +38,40 @@ func Base64Encode(content string) string {
 }
 
 func Statute2SubdivisionChunks(statute core.Statute) []core.Chunk {
-	var chunks []core.Chunk = make([]core.Chunk, 0)
 	id := statute.Chapter + "." + statute.Section
-	for _, subdivision := range statute.Subdivisions {
+	return subdivisionChunks(id, "§ "+id, "subd.", statute.Title, statute.Subdivisions, FormatHistory(statute.History))
+}
+
+// subdivisionChunks returns a chunk per subdivision, labeled with the citation of the section and the title,
+// e.g. "§ 1.142, subd. 1: STATE FLOWER. -- Lady slipper.", and ending with the history line if any.
+func subdivisionChunks(id string, citation string, subdivisionAbbreviation string, title string, subdivisions []core.Subdivision, history string) []core.Chunk {
+	var chunks []core.Chunk = make([]core.Chunk, 0)
+	for _, subdivision := range subdivisions {
 		var builder strings.Builder
 		var idSubdiv string = id
-		builder.WriteString("§ ")
-		builder.WriteString(id)
+		builder.WriteString(citation)
 		if len(subdivision.Number) > 0 {
 			idSubdiv = idSubdiv + "." + subdivision.Number
-			builder.WriteString(", subd. ")
+			builder.WriteString(", " + subdivisionAbbreviation + " ")
 			builder.WriteString(subdivision.Number)
 		}
 		builder.WriteString(": ")
-		builder.WriteString(statute.Title)
+		builder.WriteString(title)
 		if len(subdivision.Heading) > 0 {
 			builder.WriteString(" -- ")
 			builder.WriteString(subdivision.Heading)
 		}
 		builder.WriteString("\n")
-		builder.WriteString(subdivision.Content)
-		if !strings.HasSuffix(subdivision.Content, "\n") {
+		content := subdivision.Content
+		if subdivision.Clauses != nil {
+			content = FormatClauses(subdivision.Clauses)
+		}
+		builder.WriteString(content)
+		if !strings.HasSuffix(content, "\n") {
+			builder.WriteString("\n")
+		}
+		if len(history) > 0 {
+			builder.WriteString(history)
 			builder.WriteString("\n")
 		}
 
@@ -66,6 +82,53 @@ func Statute2SubdivisionChunks(statute core.Statute) []core.Chunk {
 
 }
 
+// Statute2References returns the chunk IDs referenced by each subdivision chunk of the statute, keyed by the
+// chunk ID of the subdivision. Every chunk has an entry, so references removed from a statute are cleared, and
+// references of a chunk to itself are left out.
+func Statute2References(statute core.Statute) map[string][]string {
+	return subdivisionReferences(statute.Chapter+"."+statute.Section, statute.Subdivisions)
+}
+
+func subdivisionReferences(id string, subdivisions []core.Subdivision) map[string][]string {
+	references := make(map[string][]string)
+	for _, subdivision := range subdivisions {
+		idSubdiv := id
+		if len(subdivision.Number) > 0 {
+			idSubdiv = idSubdiv + "." + subdivision.Number
+		}
+		referencedChunkIDs := make([]string, 0, len(subdivision.References))
+		for _, reference := range subdivision.References {
+			if referencedChunkID := reference.ChunkID(); referencedChunkID != idSubdiv {
+				referencedChunkIDs = append(referencedChunkIDs, referencedChunkID)
+			}
+		}
+		references[idSubdiv] = referencedChunkIDs
+	}
+	return references
+}
+
+// FormatHistory summarizes the session laws of a statute by year, e.g. "History: enacted 1967; amended 1969, 1984.",
+// so the model can tell when the statute was last amended.
+func FormatHistory(history []core.SessionLaw) string {
+	if len(history) == 0 {
+		return ""
+	}
+	enacted := history[0].Year
+	amended := make([]string, 0)
+	seen := map[int]bool{enacted: true}
+	for _, sessionLaw := range history[1:] {
+		if seen[sessionLaw.Year] {
+			continue
+		}
+		seen[sessionLaw.Year] = true
+		amended = append(amended, strconv.Itoa(sessionLaw.Year))
+	}
+	if len(amended) == 0 {
+		return fmt.Sprintf("History: enacted %d.", enacted)
+	}
+	return fmt.Sprintf("History: enacted %d; amended %s.", enacted, strings.Join(amended, ", "))
+}
+
 func ChunkObjectKeyToID(chunkObjectKey string) string {
 	chunkFileNameParts := strings.Split(chunkObjectKey, "/")
 	chunkFileName := chunkFileNameParts[len(chunkFileNameParts)-1]
@@ -73,3 +136,35 @@ func ChunkObjectKeyToID(chunkObjectKey string) string {
 	chunkID := strings.Join(chunkIDParts[:len(chunkIDParts)-1], ".")
 	return chunkID
 }
+
+// ReciprocalRankFusion merges rankings of ids, an id scores weight/(k+rank) for every ranking it appears in.
+// Ids with equal scores are ordered by id.
+func ReciprocalRankFusion(rankings [][]string, weights []float64, k int) []string {
+	return RankByScore(ReciprocalRankFusionScores(rankings, weights, k))
+}
+
+// ReciprocalRankFusionScores returns the fused score of every id in the rankings.
+func ReciprocalRankFusionScores(rankings [][]string, weights []float64, k int) map[string]float64 {
+	scores := make(map[string]float64)
+	for i, ranking := range rankings {
+		for rank, id := range ranking {
+			scores[id] += weights[i] / float64(k+rank+1)
+		}
+	}
+	return scores
+}
+
+// RankByScore orders the ids by descending score, ids with equal scores are ordered by id.
+func RankByScore(scores map[string]float64) []string {
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
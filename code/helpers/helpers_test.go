@@ -2,7 +2,11 @@ package helpers
 
 import (
 	"code/core"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -51,6 +55,18 @@ var base64EncodeTestCases = []struct {
 	{content: "Lorem ipsum dolor sit amet", expected: "TG9yZW0gaXBzdW0gZG9sb3Igc2l0IGFtZXQ="},
 }
 
+var parseCitationsTestCases = []struct {
+	text     string
+	chunkIDs []string
+}{
+	{text: "See § 337.10, subd. 1 and § 86B.33.", chunkIDs: []string{"337.10.1", "86B.33"}},
+	{text: "Under section 609.52, subdivision 2a, theft is...", chunkIDs: []string{"609.52.2a"}},
+	{text: "Minn. Stat. 1.142 applies; § 1.142 again.", chunkIDs: []string{"1.142"}},
+	{text: "As § 609.02, subd. 2, clause (3), item (ii) says.", chunkIDs: []string{"609.02.2"}},
+	{text: "See Minn. R. 7100.0100, subp. 3, item A and § 86B.33.", chunkIDs: []string{"R7100.0100.3", "86B.33"}},
+	{text: "No statutes here, only 3.5 percent.", chunkIDs: []string{}},
+}
+
 func TestHelpers(t *testing.T) {
 	t.Run("statutes 2 subdivision chunks", func(t *testing.T) {
 		for _, test := range tests {
@@ -59,6 +75,136 @@ func TestHelpers(t *testing.T) {
 		}
 	})
 
+	t.Run("statutes with history end chunks with the years amended", func(t *testing.T) {
+		statute := core.Statute{
+			Chapter:      "1",
+			Section:      "142",
+			Title:        "STATE FLOWER.",
+			Subdivisions: []core.Subdivision{{Number: "1", Heading: "Lady slipper.", Content: "The lady slipper is the official flower."}},
+			History: []core.SessionLaw{
+				{Year: 1967, Chapter: "291", Section: "1"},
+				{Year: 1969, Chapter: "1129", Article: "3", Section: "1"},
+				{Year: 1969, Chapter: "1129", Article: "3", Section: "2"},
+				{Year: 2023, SpecialSession: 1, Chapter: "4", Section: "7"},
+			},
+		}
+		chunks := Statute2SubdivisionChunks(statute)
+		expected := []core.Chunk{{ID: "1.142.1", Body: "§ 1.142, subd. 1: STATE FLOWER. -- Lady slipper.\nThe lady slipper is the official flower.\nHistory: enacted 1967; amended 1969, 2023.\n"}}
+		assert.Equal(t, expected, chunks, "chunks are not the same")
+		assert.Equal(t, "History: enacted 1985.", FormatHistory([]core.SessionLaw{{Year: 1985, Chapter: "13"}}), "history is not formatted")
+		assert.Equal(t, "", FormatHistory(nil), "empty history is formatted")
+	})
+
+	t.Run("statutes 2 references", func(t *testing.T) {
+		statute := core.Statute{
+			Chapter: "609",
+			Section: "5311",
+			Subdivisions: []core.Subdivision{
+				{Number: "1", References: []core.Reference{{Chapter: "609", Section: "5311", Subdivision: "3"}, {Chapter: "609", Section: "5316"}}},
+				{Number: "3", References: []core.Reference{{Chapter: "609", Section: "5311", Subdivision: "3"}}},
+				{Number: "4"},
+			},
+		}
+		expected := map[string][]string{
+			"609.5311.1": {"609.5311.3", "609.5316"},
+			"609.5311.3": {},
+			"609.5311.4": {},
+		}
+		assert.Equal(t, expected, Statute2References(statute), "references are not the same")
+	})
+
+	t.Run("definitions", func(t *testing.T) {
+		assert.True(t, IsDefinitionsHeading("DEFINITIONS."))
+		assert.True(t, IsDefinitionsHeading("Definitions; applicability."))
+		assert.False(t, IsDefinitionsHeading("Felony."))
+
+		assert.Equal(t, []string{"felony"}, ParseDefinedTerms(`"Felony" means a crime for which a sentence of imprisonment for more than one year may be imposed.`))
+		content := `For the purpose of sections 609.531 to 609.5318, the following terms have the meanings given them. (a) "Conveyance device" means a device used for transportation. (b) "Weapon used" means a dangerous weapon. (c) "Motor vehicle" or "vehicle" includes a snowmobile. (d) "Prosecuting authority" has the meaning given in section 609.531.`
+		assert.Equal(t, []string{"conveyance device", "weapon used", "motor vehicle", "vehicle", "prosecuting authority"}, ParseDefinedTerms(content))
+		assert.Empty(t, ParseDefinedTerms("A crime is punished as provided by law."))
+
+		statute := core.Statute{
+			Chapter: "609",
+			Section: "02",
+			Title:   "DEFINITIONS.",
+			Subdivisions: []core.Subdivision{
+				{Number: "1", DefinedTerms: []string{"crime"}},
+				{Number: "2", DefinedTerms: []string{"felony"}},
+				{Number: "3"},
+			},
+		}
+		chapterDefinitions := Statute2Definitions(statute)
+		assert.Equal(t, []core.Definition{{Term: "crime", ChunkID: "609.02.1"}, {Term: "felony", ChunkID: "609.02.2"}}, chapterDefinitions)
+		statute = core.Statute{
+			Chapter: "609",
+			Section: "531",
+			Title:   "FORFEITURES.",
+			Subdivisions: []core.Subdivision{
+				{Number: "1", Heading: "Definitions.", Content: `For the purpose of this section, "crime" means a designated offense.`, DefinedTerms: []string{"crime"}},
+				{Number: "1a", Heading: "Definitions.", Content: `For the purpose of this chapter, "forfeiture" means a taking.`, DefinedTerms: []string{"forfeiture"}},
+			},
+		}
+		sectionDefinitions := Statute2Definitions(statute)
+		assert.Equal(t, []core.Definition{{Term: "crime", ChunkID: "609.531.1", IsSectionScoped: true}, {Term: "forfeiture", ChunkID: "609.531.1a"}}, sectionDefinitions)
+		definitions := append(sectionDefinitions, chapterDefinitions...)
+		assert.Equal(t, map[string]string{"crime": "609.531.1", "felony": "609.02.2", "forfeiture": "609.531.1a"}, SectionDefinitions(definitions, "531"), "the section's own definitions should win")
+		assert.Equal(t, map[string]string{"crime": "609.02.1", "felony": "609.02.2", "forfeiture": "609.531.1a"}, SectionDefinitions(definitions, "52"), "other sections should only get the chapter-wide definitions")
+		definitions = append(definitions, core.Definition{Term: "felony", ChunkID: "609.1095.1"})
+		assert.Equal(t, "609.02.2", SectionDefinitions(definitions, "52")["felony"], "the first chapter-wide definition should win")
+
+		terms := []string{"crime", "felony", "vehicle", "motor vehicle", "weapon"}
+		assert.Equal(t, []string{"felony", "motor vehicle", "vehicle"}, FindDefinedTerms("Using Motor Vehicles in a felony", terms))
+		assert.Empty(t, FindDefinedTerms("Weaponry and crimeless acts", terms), "terms should match whole words")
+		assert.Equal(t, []string{"crime", "witness"}, FindDefinedTerms("Crimeless crimes seen by witnesses.", []string{"crime", "witness"}), "terms should match after partial matches and in plurals")
+	})
+
+	t.Run("clauses", func(t *testing.T) {
+		paragraphs := []string{
+			"(a) A person commits theft if:",
+			"(1) the person takes property; or",
+			"(2) the person obtains property by:",
+			"(i) deceit; or",
+			"(ii) coercion.",
+			"(h) Fines apply.",
+			"(i) Intent is required.",
+			"The penalties are cumulative.",
+		}
+		expected := []core.Clause{
+			{Kind: core.ClauseKindParagraph, Label: "a", Content: "A person commits theft if:", Clauses: []core.Clause{
+				{Kind: core.ClauseKindClause, Label: "1", Content: "the person takes property; or"},
+				{Kind: core.ClauseKindClause, Label: "2", Content: "the person obtains property by:", Clauses: []core.Clause{
+					{Kind: core.ClauseKindItem, Label: "i", Content: "deceit; or"},
+					{Kind: core.ClauseKindItem, Label: "ii", Content: "coercion."},
+				}},
+			}},
+			{Kind: core.ClauseKindParagraph, Label: "h", Content: "Fines apply."},
+			{Kind: core.ClauseKindParagraph, Label: "i", Content: "Intent is required."},
+			{Content: "The penalties are cumulative."},
+		}
+		clauses := ParseClauses(paragraphs)
+		assert.Equal(t, expected, clauses, "clauses are not nested by label")
+		assert.Nil(t, ParseClauses([]string{"A single paragraph."}), "unlabeled bodies have no clauses")
+
+		formatted := "(a) A person commits theft if:\n  (1) the person takes property; or\n  (2) the person obtains property by:\n" +
+			"    (i) deceit; or\n    (ii) coercion.\n(h) Fines apply.\n(i) Intent is required.\nThe penalties are cumulative.\n"
+		assert.Equal(t, formatted, FormatClauses(clauses), "clauses are not formatted")
+
+		ruleClauses := ParseRuleClauses([]string{"The board may:", "A. issue a permit if:", "(1) the fee is paid; and", "(a) the form is signed;", "(i) by the applicant;", "B. deny a permit."})
+		expectedRuleClauses := []core.Clause{
+			{Content: "The board may:"},
+			{Kind: core.ClauseKindItem, Label: "A", Content: "issue a permit if:", Clauses: []core.Clause{
+				{Kind: core.ClauseKindSubitem, Label: "1", Content: "the fee is paid; and", Clauses: []core.Clause{
+					{Kind: core.ClauseKindUnit, Label: "a", Content: "the form is signed;", Clauses: []core.Clause{
+						{Kind: core.ClauseKindSubunit, Label: "i", Content: "by the applicant;"},
+					}},
+				}},
+			}},
+			{Kind: core.ClauseKindItem, Label: "B", Content: "deny a permit."},
+		}
+		assert.Equal(t, expectedRuleClauses, ruleClauses, "rule clauses are not nested by label")
+		assert.Equal(t, "The board may:\nA. issue a permit if:\n  (1) the fee is paid; and\n    (a) the form is signed;\n      (i) by the applicant;\nB. deny a permit.\n", FormatClauses(ruleClauses))
+	})
+
 	t.Run("ChunkObjectKeyToChunkID extracts chunk id successfully", func(t *testing.T) {
 		for _, test := range chunkTests {
 			chunkID := ChunkObjectKeyToID(test.objectKey)
@@ -80,4 +226,218 @@ func TestHelpers(t *testing.T) {
 			assert.Equal(t, tc.expected, result, "unexpected result for content: "+tc.content)
 		}
 	})
+
+	t.Run("ParseCitations", func(t *testing.T) {
+		for _, tc := range parseCitationsTestCases {
+			chunkIDs := make([]string, 0)
+			for _, citation := range ParseCitations(tc.text) {
+				chunkIDs = append(chunkIDs, citation.ChunkID)
+			}
+			assert.Equal(t, tc.chunkIDs, chunkIDs, "unexpected citations for text: "+tc.text)
+		}
+	})
+
+	t.Run("ChunkIDToURL and ChunkIDToCitation", func(t *testing.T) {
+		assert.Equal(t, "https://www.revisor.mn.gov/statutes/cite/609.52#stat.609.52.2a", ChunkIDToURL("609.52.2a"))
+		assert.Equal(t, "https://www.revisor.mn.gov/statutes/cite/86B.33", ChunkIDToURL("86B.33"))
+		assert.Equal(t, "§ 609.52, subd. 2a", ChunkIDToCitation("609.52.2a"))
+		assert.Equal(t, "§ 86B.33", ChunkIDToCitation("86B.33"))
+		assert.Equal(t, "https://www.revisor.mn.gov/rules/7100.0100/#rule.7100.0100.3", ChunkIDToURL("R7100.0100.3"))
+		assert.Equal(t, "https://www.revisor.mn.gov/rules/7100.0100/", ChunkIDToURL("R7100.0100"))
+		assert.Equal(t, "Minn. R. 7100.0100, subp. 3", ChunkIDToCitation("R7100.0100.3"))
+		assert.Equal(t, "Minn. R. 7100.0100", ChunkIDToCitation("R7100.0100"))
+	})
+
+	t.Run("rules 2 subpart chunks", func(t *testing.T) {
+		rule := core.Rule{
+			Chapter: "7100",
+			Part:    "0100",
+			Title:   "DEFINITIONS.",
+			Subparts: []core.Subdivision{
+				{Number: "1", Heading: "Scope.", Content: "The terms used in this chapter have the meanings given them."},
+				{Number: "2", Heading: "Board.", Content: "\"Board\" means the Board of Accountancy.", DefinedTerms: []string{"board"},
+					References: []core.Reference{{Chapter: "326A", Section: "02"}, {Chapter: "R7100", Section: "0100", Subdivision: "2"}}},
+			},
+		}
+		expected := []core.Chunk{
+			{ID: "R7100.0100.1", Body: "Minn. R. 7100.0100, subp. 1: DEFINITIONS. -- Scope.\nThe terms used in this chapter have the meanings given them.\n"},
+			{ID: "R7100.0100.2", Body: "Minn. R. 7100.0100, subp. 2: DEFINITIONS. -- Board.\n\"Board\" means the Board of Accountancy.\n"},
+		}
+		assert.Equal(t, expected, Rule2SubpartChunks(rule), "chunks are not the same")
+		assert.Equal(t, map[string][]string{"R7100.0100.1": {}, "R7100.0100.2": {"326A.02"}}, Rule2References(rule), "references are not the same")
+		assert.Equal(t, []core.Definition{{Term: "board", ChunkID: "R7100.0100.2"}}, Rule2Definitions(rule), "definitions are not the same")
+		assert.True(t, IsRuleChunkID("R7100.0100.2"))
+		assert.False(t, IsRuleChunkID("609.02"))
+	})
+
+	t.Run("ValidateCitations flags citations without a matching chunk", func(t *testing.T) {
+		answer := core.Answer{Text: "text", Citations: ParseCitations("§ 1a.34, subd. 1; § 1a.34; § 1a.34, subd. 9; § 2.1")}
+		answer = ValidateCitations(answer, []core.Chunk{core.Chunk11, core.Chunk12})
+		isVerified := make(map[string]bool)
+		for _, citation := range answer.Citations {
+			isVerified[citation.ChunkID] = citation.IsVerified
+		}
+		assert.Equal(t, map[string]bool{"1a.34.1": true, "1a.34": true, "1a.34.9": false, "2.1": false}, isVerified)
+		unverified := UnverifiedCitations(answer)
+		assert.Len(t, unverified, 2, "two citations should be unverified")
+		message := FormatAnswer(answer)
+		assert.Contains(t, message, "§ 1a.34, subd. 9 https://www.revisor.mn.gov/statutes/cite/1a.34#stat.1a.34.9 (unverified)")
+		assert.Contains(t, message, "§ 1a.34, subd. 1 https://www.revisor.mn.gov/statutes/cite/1a.34#stat.1a.34.1\n")
+	})
+
+	t.Run("StripCitations", func(t *testing.T) {
+		text := "See § 1a.34, subd. 1 and § 1a.34, subd. 9 for details."
+		assert.Equal(t, "See § 1a.34, subd. 1 and for details.", StripCitations(text, []string{"1a.34.9"}))
+		assert.Equal(t, text, StripCitations(text, []string{}))
+		assert.Equal(t, "See for details.", StripCitations("See Minn. R. 7100.0100, subp. 3 for details.", []string{"R7100.0100.3"}))
+	})
+
+	t.Run("ReciprocalRankFusion", func(t *testing.T) {
+		vectorRanking := []string{"a", "b", "c"}
+		lexicalRanking := []string{"c", "d"}
+		assert.Equal(t, []string{"c", "a", "b", "d"}, ReciprocalRankFusion([][]string{vectorRanking, lexicalRanking}, []float64{1, 1}, 60))
+		assert.Equal(t, []string{"c", "d", "a", "b"}, ReciprocalRankFusion([][]string{vectorRanking, lexicalRanking}, []float64{0.1, 1}, 60))
+		assert.Empty(t, ReciprocalRankFusion(nil, nil, 60))
+		scores := ReciprocalRankFusionScores([][]string{vectorRanking, lexicalRanking}, []float64{1, 1}, 60)
+		assert.InDelta(t, 1.0/63+1.0/61, scores["c"], 1e-9)
+		assert.Equal(t, ReciprocalRankFusion([][]string{vectorRanking, lexicalRanking}, []float64{1, 1}, 60), RankByScore(scores))
+	})
+
+	t.Run("SplitChunkID", func(t *testing.T) {
+		chapter, section, subdivision := SplitChunkID("609.52.2a")
+		assert.Equal(t, []string{"609", "52", "2a"}, []string{chapter, section, subdivision})
+		chapter, section, subdivision = SplitChunkID("86B.33")
+		assert.Equal(t, []string{"86B", "33", ""}, []string{chapter, section, subdivision})
+	})
+
+	t.Run("ParseStatuteNumber", func(t *testing.T) {
+		chapter, section, ok := ParseStatuteNumber("86B.33")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"86B", "33"}, []string{chapter, section})
+		for _, statute := range []string{"86B", "86B.33.1", "../86B.33", "86B.33 ", ""} {
+			_, _, ok := ParseStatuteNumber(statute)
+			assert.False(t, ok, "statute=%q is not a statute number", statute)
+		}
+	})
+
+	t.Run("ParsePromptCitations", func(t *testing.T) {
+		chunkIDs := make([]string, 0)
+		for _, citation := range ParsePromptCitations("what does 86B.33 subd 1 and § 609.52 say?") {
+			chunkIDs = append(chunkIDs, citation.ChunkID)
+		}
+		assert.Equal(t, []string{"86B.33.1", "609.52"}, chunkIDs)
+		chunkIDs = make([]string, 0)
+		for _, citation := range ParsePromptCitations("is part 7100.0100 subp 2 like rule 7100.0200?") {
+			chunkIDs = append(chunkIDs, citation.ChunkID)
+		}
+		assert.Equal(t, []string{"R7100.0100.2", "R7100.0200"}, chunkIDs)
+		assert.Empty(t, ParsePromptCitations("is 0.08 BAC after 2.5 drinks over the limit?"), "bare decimals are not citations")
+		assert.Empty(t, ParseCitations("what does 86B.33 subd 1 say?"), "answers require a § in front of the statute")
+	})
+
+	t.Run("ParseCommandCitations", func(t *testing.T) {
+		chunkIDs := make([]string, 0)
+		for _, citation := range ParseCommandCitations("609.52, 86B.33 subd 1 and part 7100.0100") {
+			chunkIDs = append(chunkIDs, citation.ChunkID)
+		}
+		assert.Equal(t, []string{"609.52", "86B.33.1", "R7100.0100"}, chunkIDs)
+	})
+
+	t.Run("SortChunkIDs", func(t *testing.T) {
+		chunkIDs := []string{"609.52.10", "609.52.2a", "609.52", "609.52.2", "609.6", "609.52.1"}
+		SortChunkIDs(chunkIDs)
+		assert.Equal(t, []string{"609.6", "609.52", "609.52.1", "609.52.2", "609.52.2a", "609.52.10"}, chunkIDs)
+	})
+
+	t.Run("ParseSubdivisionReferences", func(t *testing.T) {
+		assert.Equal(t, []string{"2", "3a"}, ParseSubdivisionReferences("what about subdivision 2, subd. 3a and subd 2?"))
+		assert.Empty(t, ParseSubdivisionReferences("what about the next section?"))
+		assert.Equal(t, []string{"3", "1"}, ParseSubdivisionReferences("what about subpart 3 and subp. 1?"))
+	})
+
+	t.Run("IsGSM7, SMSLength and NormalizeSMS", func(t *testing.T) {
+		assert.True(t, IsGSM7("See § 609.52, subd. 2."))
+		assert.Equal(t, 6, SMSLength("a{b}"), "extension characters take two septets")
+		assert.False(t, IsGSM7("it’s"))
+		assert.Equal(t, 3, SMSLength("a😀"), "characters outside the basic plane take two code units")
+		assert.Equal(t, "it's - \"so\"...", NormalizeSMS("it’s — “so”…"))
+	})
+
+	t.Run("SplitSMS keeps short messages whole", func(t *testing.T) {
+		assert.Equal(t, []string{"Yes."}, SplitSMS(" Yes. ", 0))
+	})
+
+	t.Run("SplitSMS splits at sentence boundaries and keeps citations whole", func(t *testing.T) {
+		sentence := "Register a boat under § 86B.401, subd. 1 before use. "
+		parts := SplitSMS(strings.Repeat(sentence, 6), 0)
+		assert.Len(t, parts, 3)
+		for i, part := range parts {
+			assert.True(t, strings.HasPrefix(part, fmt.Sprintf("(%d/3) Register", i+1)), "part=%s should be numbered", part)
+			assert.LessOrEqual(t, SMSLength(part), 160, "part=%s should fit in one SMS", part)
+			assert.Equal(t, 2, strings.Count(part, "§ 86B.401, subd. 1 before"), "citations should not be split")
+		}
+	})
+
+	t.Run("SplitSMS uses the UCS-2 limit and leaves room for the reserve", func(t *testing.T) {
+		parts := SplitSMS(strings.Repeat("Ω is ok, ü too — and ž is not. ", 10), 20)
+		assert.Greater(t, len(parts), 5)
+		for _, part := range parts {
+			assert.LessOrEqual(t, SMSLength(part), 50, "part=%s should leave room for the reserve", part)
+		}
+	})
+
+	t.Run("SplitSMS splits long words", func(t *testing.T) {
+		parts := SplitSMS(strings.Repeat("x", 400), 0)
+		assert.Len(t, parts, 3)
+		assert.Equal(t, strings.Repeat("x", 400), strings.TrimPrefix(parts[0], "(1/3) ")+strings.TrimPrefix(parts[1], "(2/3) ")+strings.TrimPrefix(parts[2], "(3/3) "))
+	})
+
+	t.Run("SMSSummary", func(t *testing.T) {
+		assert.Equal(t, "Yes, see § 86B.33.", SMSSummary("Yes, see § 86B.33. It applies to every boat.", 0))
+		summary := SMSSummary(strings.Repeat("word ", 50), 20)
+		assert.True(t, strings.HasSuffix(summary, "..."))
+		assert.LessOrEqual(t, SMSLength(summary), 140)
+	})
+
+	t.Run("TakeToken", func(t *testing.T) {
+		rateLimits := core.RateLimits{Burst: 2, PerHour: 4}
+		updatedAt := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+		tokens, isTaken := TakeToken(0.5, updatedAt, updatedAt.Add(15*time.Minute), rateLimits)
+		assert.True(t, isTaken, "a token should be refilled after 15 minutes")
+		assert.InDelta(t, 0.5, tokens, 1e-9)
+		tokens, isTaken = TakeToken(0.5, updatedAt, updatedAt.Add(time.Minute), rateLimits)
+		assert.False(t, isTaken, "no token should be refilled after a minute")
+		assert.InDelta(t, 0.5+1.0/15, tokens, 1e-9)
+		tokens, _ = TakeToken(0, updatedAt, updatedAt.Add(24*time.Hour), rateLimits)
+		assert.Equal(t, 1.0, tokens, "buckets should not refill beyond the burst")
+		assert.Equal(t, 30*time.Minute, GetRefillDuration(rateLimits))
+		assert.Equal(t, "2024-06-11", GetBudgetDay(time.Date(2024, 6, 10, 22, 0, 0, 0, time.FixedZone("CDT", -5*3600))))
+	})
+
+	t.Run("FormatForChannel", func(t *testing.T) {
+		markdown := "## Boats\n**Yes**, you *must* register it, see [§ 86B.33](https://www.revisor.mn.gov/statutes/cite/86B.33).\n* `canoes` over ~~9~~ 10 feet"
+		assert.Equal(t, markdown, FormatForChannel(markdown, core.ChannelWeb), "web chat should keep markdown")
+		assert.Equal(t, "Boats\nYes, you must register it, see § 86B.33 (https://www.revisor.mn.gov/statutes/cite/86B.33).\n- canoes over 9 10 feet", FormatForChannel(markdown, core.ChannelSMS))
+		assert.Equal(t, "*Boats*\n*Yes*, you _must_ register it, see § 86B.33 (https://www.revisor.mn.gov/statutes/cite/86B.33).\n- canoes over ~9~ 10 feet", FormatForChannel(markdown, core.ChannelWhatsApp))
+		assert.Equal(t, "Sources:\n§ 86B.33 https://www.revisor.mn.gov/statutes/cite/86B.33", FormatForChannel("Sources:\n§ 86B.33 https://www.revisor.mn.gov/statutes/cite/86B.33", core.ChannelRCS), "plain text should be kept")
+	})
+
+	t.Run("retrieval metrics", func(t *testing.T) {
+		found := []string{"609.52.1", "1.142.2", "609.52.2", "86B.33.1"}
+		matches := MatchExpectedChunkIDs(found, []string{"1.142.2", "609.52", "115B.49"})
+		assert.Equal(t, []int{1, 0, -1, -1}, matches, "a section should be matched by its first subdivision only")
+		assert.InDelta(t, 1.0/3, RecallAtK(matches, 3, 1), 1e-9)
+		assert.InDelta(t, 2.0/3, RecallAtK(matches, 3, 10), 1e-9)
+		assert.Equal(t, 1, FirstMatchRank(matches))
+		assert.Equal(t, 1.0, ReciprocalRank(matches))
+		assert.InDelta(t, (1+1/math.Log2(3))/(1+1/math.Log2(3)+0.5), NDCGAtK(matches, 3, 10), 1e-9)
+		assert.Equal(t, 1.0, NDCGAtK(matches, 3, 1), "a match first should be ideal at k=1")
+
+		matches = MatchExpectedChunkIDs(found, []string{"86B.33"})
+		assert.Equal(t, 4, FirstMatchRank(matches))
+		assert.Equal(t, 0.25, ReciprocalRank(matches))
+		assert.Equal(t, 0.0, RecallAtK(matches, 1, 3))
+		assert.Equal(t, 0.0, ReciprocalRank(MatchExpectedChunkIDs(nil, []string{"86B.33"})))
+		assert.Equal(t, 0.0, NDCGAtK(nil, 0, 10), "no expected chunk ids should not divide by zero")
+	})
 }
@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"code/core"
+	"time"
+)
+
+// TakeToken refills the bucket of a phone number that held tokens at updatedAt, and takes a token from it
+// if there is one. It returns the tokens left and whether a token was taken.
+func TakeToken(tokens float64, updatedAt, now time.Time, rateLimits core.RateLimits) (float64, bool) {
+	if elapsed := now.Sub(updatedAt); elapsed > 0 {
+		tokens += elapsed.Hours() * rateLimits.PerHour
+	}
+	if burst := float64(rateLimits.Burst); tokens > burst {
+		tokens = burst
+	}
+	if tokens < 1 {
+		return tokens, false
+	}
+	return tokens - 1, true
+}
+
+// GetRefillDuration returns how long an empty bucket takes to fill up, after which a bucket that has not
+// been touched can be forgotten.
+func GetRefillDuration(rateLimits core.RateLimits) time.Duration {
+	return time.Duration(float64(rateLimits.Burst) / rateLimits.PerHour * float64(time.Hour))
+}
+
+// GetBudgetDay returns the UTC day the daily budget of now is counted against, e.g. "2024-06-10".
+func GetBudgetDay(now time.Time) string {
+	return now.UTC().Format(time.DateOnly)
+}
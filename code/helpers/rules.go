@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"code/core"
+	"strings"
+)
+
+// RuleChunkIDPrefix starts the chunk IDs of the Minnesota Rules, e.g. "R7100.0100.3" is Minn. R. 7100.0100,
+// subp. 3, so they never collide with the chunk IDs of the statutes.
+const RuleChunkIDPrefix = "R"
+
+const mnRevisorRulesURL = "https://www.revisor.mn.gov/rules/"
+
+// IsRuleChunkID tells whether the chunk ID is the one of a part or subpart of the Minnesota Rules.
+func IsRuleChunkID(chunkID string) bool {
+	return strings.HasPrefix(chunkID, RuleChunkIDPrefix)
+}
+
+// Rule2SubpartChunks returns a chunk per subpart of the rule, labeled e.g. "Minn. R. 7100.0100, subp. 3".
+func Rule2SubpartChunks(rule core.Rule) []core.Chunk {
+	return subdivisionChunks(ruleChunkID(rule), "Minn. R. "+rule.Chapter+"."+rule.Part, "subp.", rule.Title, rule.Subparts, "")
+}
+
+// Rule2References is like Statute2References for the subparts of a rule.
+func Rule2References(rule core.Rule) map[string][]string {
+	return subdivisionReferences(ruleChunkID(rule), rule.Subparts)
+}
+
+// Rule2Definitions is like Statute2Definitions for the subparts of a rule, the definitions are those of
+// the chapter RuleChunkIDPrefix + rule.Chapter.
+func Rule2Definitions(rule core.Rule) []core.Definition {
+	return subdivisionDefinitions(ruleChunkID(rule), rule.Title, rule.Subparts)
+}
+
+func ruleChunkID(rule core.Rule) string {
+	return RuleChunkIDPrefix + rule.Chapter + "." + rule.Part
+}
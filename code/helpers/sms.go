@@ -0,0 +1,216 @@
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maximum length of a single SMS, in septets for GSM-7 and in UTF-16 code units for UCS-2
+const (
+	gsm7SMSLength = 160
+	ucs2SMSLength = 70
+)
+
+const smsEllipsis = "..."
+
+// characters of the GSM 03.38 default alphabet, and of its extension table which take two septets
+const (
+	gsm7Basic     = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7Extension = "\f^{}\\[~]|€"
+)
+
+// smsReplacer swaps typographic punctuation models like to use for its GSM-7 equivalent, a single
+// curly quote would otherwise turn the whole message into UCS-2 and more than double its parts
+var smsReplacer = strings.NewReplacer(
+	"‘", "'", "’", "'", "“", "\"", "”", "\"",
+	"–", "-", "—", "-", "…", smsEllipsis, "\u00a0", " ",
+)
+
+// a sentence ends at a ".", "!" or "?" followed by whitespace, a line always ends a piece
+var smsBoundaryRegexp = regexp.MustCompile(`[.!?]+["')\]]*[ \t]+|\n+`)
+
+var smsWordRegexp = regexp.MustCompile(`\s*\S+\s*`)
+
+// words whose trailing "." does not end a sentence, e.g. "§ 609.52, subd. 2" or "Minn. Stat. 1.142"
+var smsAbbreviations = map[string]bool{"subd": true, "subds": true, "minn": true, "stat": true, "e.g": true, "i.e": true, "ch": true, "art": true}
+
+var gsm7Septets = func() map[rune]int {
+	septets := make(map[rune]int)
+	for _, r := range gsm7Basic {
+		septets[r] = 1
+	}
+	for _, r := range gsm7Extension {
+		septets[r] = 2
+	}
+	return septets
+}()
+
+// NormalizeSMS replaces typographic punctuation with its GSM-7 equivalent.
+func NormalizeSMS(text string) string {
+	return smsReplacer.Replace(text)
+}
+
+// IsGSM7 reports whether text can be sent with the GSM-7 alphabet, otherwise it is sent as UCS-2.
+func IsGSM7(text string) bool {
+	for _, r := range text {
+		if gsm7Septets[r] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SMSLength returns the length of text as counted against the SMS limit of its encoding.
+func SMSLength(text string) int {
+	return smsLength(text, IsGSM7(text))
+}
+
+func smsLength(text string, isGSM7 bool) int {
+	length := 0
+	for _, r := range text {
+		switch {
+		case isGSM7:
+			length += gsm7Septets[r]
+		case r > 0xFFFF: // outside the basic multilingual plane, takes a surrogate pair
+			length += 2
+		default:
+			length += 1
+		}
+	}
+	return length
+}
+
+func smsLimit(isGSM7 bool) int {
+	if isGSM7 {
+		return gsm7SMSLength
+	}
+	return ucs2SMSLength
+}
+
+// SplitSMS splits text into parts that are each sent as a single SMS, breaking at sentence and line
+// boundaries so citations stay whole. Parts are numbered, e.g. "(1/3) ", unless the text fits in one
+// SMS. reserve is the length left free in every part for text appended later.
+func SplitSMS(text string, reserve int) []string {
+	text = strings.TrimSpace(text)
+	isGSM7 := IsGSM7(text)
+	limit := smsLimit(isGSM7)
+	if smsLength(text, isGSM7)+reserve <= limit {
+		return []string{text}
+	}
+	pieces := splitSMSPieces(text)
+	// the numbering takes more room once there are 10 or more parts, so pack again until it fits
+	count := 2
+	for {
+		prefixLength := len(getSMSPrefix(count, count))
+		parts := packSMSPieces(pieces, limit-prefixLength-reserve, isGSM7)
+		if len(strconv.Itoa(len(parts))) <= len(strconv.Itoa(count)) {
+			for i := range parts {
+				parts[i] = getSMSPrefix(i+1, len(parts)) + parts[i]
+			}
+			return parts
+		}
+		count = len(parts)
+	}
+}
+
+// SMSSummary returns the first sentence of text, shortened to fit in a single SMS with reserve left free.
+func SMSSummary(text string, reserve int) string {
+	pieces := splitSMSPieces(strings.TrimSpace(text))
+	if len(pieces) == 0 {
+		return ""
+	}
+	summary := strings.TrimSpace(pieces[0])
+	isGSM7 := IsGSM7(summary)
+	limit := smsLimit(isGSM7) - reserve
+	if smsLength(summary, isGSM7) <= limit {
+		return summary
+	}
+	words := packSMSPieces(splitSMSWords(summary), limit-len(smsEllipsis), isGSM7)
+	return words[0] + smsEllipsis
+}
+
+func getSMSPrefix(part, count int) string {
+	return fmt.Sprintf("(%d/%d) ", part, count)
+}
+
+// splitSMSPieces splits text into sentences and lines, each keeping its trailing whitespace.
+func splitSMSPieces(text string) []string {
+	pieces := make([]string, 0)
+	start := 0
+	for _, match := range smsBoundaryRegexp.FindAllStringIndex(text, -1) {
+		if text[match[0]] != '\n' && isSMSAbbreviation(text[start:match[0]]) {
+			continue
+		}
+		pieces = append(pieces, text[start:match[1]])
+		start = match[1]
+	}
+	if start < len(text) {
+		pieces = append(pieces, text[start:])
+	}
+	return pieces
+}
+
+func isSMSAbbreviation(sentence string) bool {
+	fields := strings.Fields(sentence)
+	if len(fields) == 0 {
+		return false
+	}
+	lastWord := strings.ToLower(strings.TrimLeft(fields[len(fields)-1], "(\"'"))
+	return smsAbbreviations[lastWord]
+}
+
+// splitSMSWords splits text into words, each keeping its trailing whitespace.
+func splitSMSWords(text string) []string {
+	return smsWordRegexp.FindAllString(text, -1)
+}
+
+// packSMSPieces greedily joins pieces into parts no longer than budget, pieces that do not fit in a part
+// of their own are split into words and words into characters.
+func packSMSPieces(pieces []string, budget int, isGSM7 bool) []string {
+	if budget < 1 {
+		budget = 1
+	}
+	parts := make([]string, 0)
+	current := ""
+	for _, piece := range pieces {
+		if smsLength(strings.TrimSpace(current+piece), isGSM7) <= budget {
+			current += piece
+			continue
+		}
+		if len(strings.TrimSpace(current)) > 0 {
+			parts = append(parts, strings.TrimSpace(current))
+		}
+		current = ""
+		if smsLength(strings.TrimSpace(piece), isGSM7) <= budget {
+			current = piece
+			continue
+		}
+		var subPieces []string
+		if words := splitSMSWords(piece); len(words) > 1 {
+			subPieces = packSMSPieces(words, budget, isGSM7)
+		} else {
+			subPieces = splitSMSRunes(strings.TrimSpace(piece), budget, isGSM7)
+		}
+		parts = append(parts, subPieces[:len(subPieces)-1]...)
+		current = subPieces[len(subPieces)-1] + " "
+	}
+	if len(strings.TrimSpace(current)) > 0 || len(parts) == 0 {
+		parts = append(parts, strings.TrimSpace(current))
+	}
+	return parts
+}
+
+func splitSMSRunes(word string, budget int, isGSM7 bool) []string {
+	parts := make([]string, 0)
+	current := ""
+	for _, r := range word {
+		if len(current) > 0 && smsLength(current+string(r), isGSM7) > budget {
+			parts = append(parts, current)
+			current = ""
+		}
+		current += string(r)
+	}
+	return append(parts, current)
+}
@@ -0,0 +1,47 @@
+package clients
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const savedPageContents = "<html>1.142</html>"
+
+func TestFileSystemClient(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "1.142.html"), []byte(savedPageContents), 0o644)
+	assert.NoError(t, err, "error on writing saved page: %v", err)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a page"), 0o644)
+
+	fsClientHelper, err := InitializeFileSystemClientHelper(dir)
+	assert.NoError(t, err, "error on InitializeFileSystemClientHelper: %v", err)
+
+	t.Run("test URLs lists saved html pages", func(t *testing.T) {
+		urls, err := fsClientHelper.URLs()
+		assert.NoError(t, err, "error on URLs: %v", err)
+		assert.Equal(t, []string{"file://" + filepath.ToSlash(filepath.Join(fsClientHelper.dir, "1.142.html"))}, urls)
+	})
+
+	t.Run("test GetHTML by file url and revisor url", func(t *testing.T) {
+		urls, _ := fsClientHelper.URLs()
+		for _, url := range []string{urls[0], "https://www.revisor.mn.gov/statutes/cite/1.142"} {
+			assert.True(t, fsClientHelper.CanGet(url), "should be able to get url=%s", url)
+			output, err := fsClientHelper.GetHTML(ctx, url)
+			assert.NoError(t, err, "error on GetHTML: %v", err)
+			assert.Equal(t, savedPageContents, string(output), "page contents are not equal")
+		}
+	})
+
+	t.Run("test missing and outside pages are rejected", func(t *testing.T) {
+		for _, url := range []string{"https://www.revisor.mn.gov/statutes/cite/1.143", "file:///etc/passwd"} {
+			assert.False(t, fsClientHelper.CanGet(url), "should not be able to get url=%s", url)
+			_, err := fsClientHelper.GetHTML(ctx, url)
+			assert.Error(t, err, "expected error on GetHTML for url=%s", url)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	fileScheme        = "file"
+	htmlFileExtension = ".html"
+)
+
+// FileSystemClientHelper serves saved revisor pages from a directory. Pages are addressed either by
+// their file:// URL or by their revisor URL, in which case the last path segment of the URL is used
+// as the file name (e.g. https://www.revisor.mn.gov/statutes/cite/1.142 is read from 1.142.html).
+type FileSystemClientHelper struct {
+	dir string
+}
+
+func InitializeFileSystemClientHelper(dir string) (*FileSystemClientHelper, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error on getting absolute path for dir='%s': %v", dir, err)
+	}
+	info, err := os.Stat(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("error on stat dir='%s': %v", absDir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory, dir='%s'", absDir)
+	}
+	return &FileSystemClientHelper{dir: absDir}, nil
+}
+
+func (fsClientHelper *FileSystemClientHelper) GetHTML(ctx context.Context, url string) ([]byte, error) {
+	filePath, err := fsClientHelper.getFilePath(url)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error on reading file for url='%s': %v", url, err)
+	}
+	return data, nil
+}
+
+// CanGet reports whether the page for url is saved in the directory.
+func (fsClientHelper *FileSystemClientHelper) CanGet(url string) bool {
+	filePath, err := fsClientHelper.getFilePath(url)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(filePath)
+	return err == nil && !info.IsDir()
+}
+
+// URLs returns the sorted file:// URLs of every saved html page in the directory.
+func (fsClientHelper *FileSystemClientHelper) URLs() ([]string, error) {
+	entries, err := os.ReadDir(fsClientHelper.dir)
+	if err != nil {
+		return nil, fmt.Errorf("error on reading dir='%s': %v", fsClientHelper.dir, err)
+	}
+	urls := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != htmlFileExtension {
+			continue
+		}
+		fileURL := url.URL{Scheme: fileScheme, Path: filepath.ToSlash(filepath.Join(fsClientHelper.dir, entry.Name()))}
+		urls = append(urls, fileURL.String())
+	}
+	sort.Strings(urls)
+	return urls, nil
+}
+
+func (fsClientHelper *FileSystemClientHelper) getFilePath(rawURL string) (string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("error on parsing url='%s': %v", rawURL, err)
+	}
+	if parsedURL.Scheme == fileScheme {
+		filePath := filepath.Clean(filepath.FromSlash(parsedURL.Path))
+		if !strings.HasPrefix(filePath, fsClientHelper.dir+string(filepath.Separator)) {
+			return "", fmt.Errorf("file url is outside of dir='%s', url='%s'", fsClientHelper.dir, rawURL)
+		}
+		return filePath, nil
+	}
+	fileName := path.Base(parsedURL.Path)
+	if fileName == "/" || fileName == "." {
+		return "", fmt.Errorf("could not determine file name for url='%s'", rawURL)
+	}
+	return filepath.Join(fsClientHelper.dir, fileName+htmlFileExtension), nil
+}
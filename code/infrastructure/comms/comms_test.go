@@ -1,10 +1,17 @@
 package comms
 
 import (
+	"code/core"
 	"code/infrastructure/settings"
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -14,18 +21,253 @@ const (
 	phoneNumberEnvName = "MY_PHONE_NUMBER"
 )
 
+// inbound_message.json is a synthetic payload shaped like a Sinch callback, signed with these made-up values
+const (
+	testWebhookSecret    = "test-webhook-secret"
+	testWebhookNonce     = "c1a6f0e2-4b7d-4a55-9d1e-2f3b8c9d0e11"
+	testWebhookTimestamp = "1718000000"
+	testWebhookSignature = "wHjB9IOKA1kCHIyUBG9gk7YKQCJaX7ziVcxScH7/H7Q="
+)
+
 func TestCommsTest(t *testing.T) {
 	ctx := context.Background()
 	assert := assert.New(t)
 
 	mySettings, err := settings.GetSettings()
 	assert.NoError(err, "error on get settings: %v", err)
-	commsHelper, err := InitializeSinchHelper(ctx, mySettings.SinchAPIToken, mySettings.SinchServiceID, mySettings.SinchVirtualPhoneNumber, mySettings.ContextTimeout)
+	commsHelper, err := InitializeSinchHelper(ctx, mySettings.SinchAPIURL, mySettings.SinchAPIToken, mySettings.SinchServiceID, mySettings.SinchVirtualPhoneNumber, mySettings.ContextTimeout)
 	assert.NoError(err, "error on initializing sinch helper: %v", err)
 
 	t.Run("test send sms", func(t *testing.T) {
 		phoneNumber := os.Getenv(phoneNumberEnvName)
-		err = commsHelper.SendMessage(ctx, phoneNumber, msg)
+		err = commsHelper.SendMessage(ctx, core.Recipient{Channel: core.ChannelSMS, Identity: phoneNumber}, msg)
+		assert.NoError(err, "error on send message: %v", err)
+	})
+}
+
+func TestSinchHelper(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	// fake sinch batches endpoint, recording every batch it receives
+	batches := make([]map[string]interface{}, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/project-1/batches" || r.Header.Get("Authorization") != "Bearer token-1" {
+			http.Error(w, `{"code":"unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		var batch map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&batch)
+		batches = append(batches, batch)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	_, err := InitializeSinchHelper(ctx, "", "token-1", "project-1", "+15555550199", time.Second)
+	assert.Error(err, "missing api url should fail")
+
+	sinchHelper, err := InitializeSinchHelper(ctx, server.URL+"/", "token-1", "project-1", "+15555550199", time.Second)
+	assert.NoError(err, "error on initializing sinch helper: %v", err)
+
+	t.Run("test SendMessage posts a batch", func(t *testing.T) {
+		err := sinchHelper.SendMessage(ctx, core.Recipient{Channel: core.ChannelSMS, Identity: "15555550100"}, "(1/2) first part")
+		assert.NoError(err, "error on send message: %v", err)
+		if assert.Len(batches, 1) {
+			assert.Equal("+15555550199", batches[0]["from"])
+			assert.Equal([]interface{}{"+15555550100"}, batches[0]["to"], "phone number should be prefixed with +")
+			assert.Equal("(1/2) first part", batches[0]["body"])
+		}
+	})
+
+	t.Run("test SendMessage fails on non-2xx", func(t *testing.T) {
+		otherHelper, _ := InitializeSinchHelper(ctx, server.URL, "token-2", "project-1", "+15555550199", time.Second)
+		err := otherHelper.SendMessage(ctx, core.Recipient{Channel: core.ChannelSMS, Identity: "+15555550100"}, msg)
+		assert.ErrorContains(err, "unauthorized")
+		err = sinchHelper.SendMessage(ctx, core.Recipient{Channel: core.ChannelWhatsApp, Identity: "15555550100"}, msg)
+		assert.Error(err, "other channels should not be texted")
+	})
+}
+
+func TestSinchConversationHelper(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	// fake sinch auth and conversation endpoints, recording every message sent
+	tokenRequests := 0
+	messages := make([]map[string]interface{}, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			keyID, keySecret, _ := r.BasicAuth()
+			if keyID != "key-1" || keySecret != "secret-1" || r.FormValue("grant_type") != "client_credentials" {
+				http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+				return
+			}
+			tokenRequests++
+			w.Write([]byte(`{"access_token":"token-1","expires_in":3599,"token_type":"bearer"}`))
+		case "/v1/projects/project-1/messages:send":
+			if r.Header.Get("Authorization") != "Bearer token-1" {
+				http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+				return
+			}
+			var message map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&message)
+			messages = append(messages, message)
+			w.Write([]byte(`{"message_id":"01HZZ"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	_, err := InitializeSinchConversationHelper(ctx, server.URL, server.URL+"/oauth2/token", "project-1", "", "key-1", "secret-1", time.Second)
+	assert.Error(err, "missing app id should fail")
+
+	helper, err := InitializeSinchConversationHelper(ctx, server.URL+"/", server.URL+"/oauth2/token", "project-1", "app-1", "key-1", "secret-1", time.Second)
+	assert.NoError(err, "error on initializing sinch conversation helper: %v", err)
+
+	t.Run("test SendMessage sends over the recipient's channel", func(t *testing.T) {
+		for _, channel := range []core.Channel{core.ChannelWhatsApp, core.ChannelMessenger} {
+			err := helper.SendMessage(ctx, core.Recipient{Channel: channel, Identity: "15555550100"}, msg)
+			assert.NoError(err, "error on send message: %v", err)
+		}
+		assert.Equal(1, tokenRequests, "access token should be reused")
+		if assert.Len(messages, 2) {
+			assert.Equal("app-1", messages[0]["app_id"])
+			assert.Equal([]interface{}{"WHATSAPP"}, messages[0]["channel_priority_order"])
+			assert.Equal(map[string]interface{}{"text": msg}, messages[0]["message"].(map[string]interface{})["text_message"])
+			identities := messages[1]["recipient"].(map[string]interface{})["identified_by"].(map[string]interface{})["channel_identities"]
+			assert.Equal([]interface{}{map[string]interface{}{"channel": "MESSENGER", "identity": "15555550100"}}, identities)
+		}
+	})
+
+	t.Run("test SendMessage fails on SMS and bad credentials", func(t *testing.T) {
+		err := helper.SendMessage(ctx, core.Recipient{Channel: core.ChannelSMS, Identity: "15555550100"}, msg)
+		assert.Error(err, "SMS should be texted with the sinch helper")
+		otherHelper, _ := InitializeSinchConversationHelper(ctx, server.URL, server.URL+"/oauth2/token", "project-1", "app-1", "key-1", "secret-2", time.Second)
+		err = otherHelper.SendMessage(ctx, core.Recipient{Channel: core.ChannelRCS, Identity: "15555550100"}, msg)
+		assert.ErrorContains(err, "invalid_client")
+	})
+}
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	_, err := InitializeRouter(nil)
+	assert.Error(err, "router without channels should fail")
+	outbox, _ := InitializeOutbox()
+	router, err := InitializeRouter(map[core.Channel]core.Comms{core.ChannelWeb: outbox})
+	assert.NoError(err, "error on initializing router: %v", err)
+
+	err = router.SendMessage(ctx, core.Recipient{Channel: core.ChannelWeb, Identity: "session-1"}, msg)
+	assert.NoError(err, "error on send message: %v", err)
+	assert.Equal([]string{msg}, outbox.Messages(), "web chat message should be kept in the outbox")
+	err = router.SendMessage(ctx, core.Recipient{Channel: core.ChannelSMS, Identity: "15555550100"}, msg)
+	assert.Error(err, "unconfigured channel should fail")
+	err = outbox.SendMessage(ctx, core.Recipient{Channel: core.ChannelSMS, Identity: "15555550100"}, msg)
+	assert.Error(err, "outbox should only keep web chat messages")
+}
+
+func TestConsole(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	_, err := InitializeConsole(nil)
+	assert.Error(err, "console without writer should fail")
+	var out strings.Builder
+	console, err := InitializeConsole(&out)
+	assert.NoError(err, "error on initializing console: %v", err)
+	for _, message := range []string{msg, "second"} {
+		err = console.SendMessage(ctx, core.Recipient{Channel: core.ChannelWeb, Identity: "chat"}, message)
 		assert.NoError(err, "error on send message: %v", err)
+	}
+	assert.Equal(msg+"\nsecond\n", out.String(), "every message should be printed on its own line")
+}
+
+func TestSinchWebhookVerifier(t *testing.T) {
+	assert := assert.New(t)
+	bodyBytes, err := os.ReadFile(filepath.Join("test_data", "inbound_message.json"))
+	assert.NoError(err, "error on reading test payload: %v", err)
+	body := string(bodyBytes)
+	getHeaders := func() map[string]string {
+		return map[string]string{
+			"X-Sinch-Webhook-Signature":           testWebhookSignature,
+			"X-Sinch-Webhook-Signature-Nonce":     testWebhookNonce,
+			"X-Sinch-Webhook-Signature-Timestamp": testWebhookTimestamp,
+			"X-Sinch-Webhook-Signature-Algorithm": "HmacSHA256",
+			"Content-Type":                        "application/json",
+		}
+	}
+
+	_, err = InitializeSinchWebhookVerifier("", time.Minute)
+	assert.Error(err, "missing secret should fail")
+	verifier, err := InitializeSinchWebhookVerifier(testWebhookSecret, 5*time.Minute)
+	assert.NoError(err, "error on initializing sinch webhook verifier: %v", err)
+	signedAt := time.Unix(1718000000, 0)
+	verifier.now = func() time.Time { return signedAt.Add(time.Minute) }
+
+	t.Run("test signed payload is verified", func(t *testing.T) {
+		assert.NoError(verifier.Verify(getHeaders(), body))
+		lowerHeaders := make(map[string]string)
+		for name, value := range getHeaders() {
+			lowerHeaders[strings.ToLower(name)] = value
+		}
+		assert.NoError(verifier.Verify(lowerHeaders, body), "header names should be case-insensitive")
+	})
+
+	t.Run("test tampered payloads are rejected", func(t *testing.T) {
+		tamperedBody := strings.Replace(body, "15555550100", "15555550199", 1)
+		assert.Error(verifier.Verify(getHeaders(), tamperedBody), "tampered body should fail")
+		headers := getHeaders()
+		headers["X-Sinch-Webhook-Signature-Nonce"] = "another-nonce"
+		assert.Error(verifier.Verify(headers, body), "tampered nonce should fail")
+		otherVerifier, _ := InitializeSinchWebhookVerifier("another-secret", 5*time.Minute)
+		otherVerifier.now = verifier.now
+		assert.Error(otherVerifier.Verify(getHeaders(), body), "wrong secret should fail")
+	})
+
+	t.Run("test missing and unsupported headers are rejected", func(t *testing.T) {
+		for _, name := range []string{"X-Sinch-Webhook-Signature", "X-Sinch-Webhook-Signature-Nonce", "X-Sinch-Webhook-Signature-Timestamp"} {
+			headers := getHeaders()
+			delete(headers, name)
+			assert.Error(verifier.Verify(headers, body), "missing header=%s should fail", name)
+		}
+		headers := getHeaders()
+		headers["X-Sinch-Webhook-Signature-Algorithm"] = "HmacSHA1"
+		assert.Error(verifier.Verify(headers, body), "unsupported algorithm should fail")
+	})
+
+	t.Run("test replays outside of the window are rejected", func(t *testing.T) {
+		defer func(now func() time.Time) { verifier.now = now }(verifier.now)
+		verifier.now = func() time.Time { return signedAt.Add(6 * time.Minute) }
+		assert.ErrorContains(verifier.Verify(getHeaders(), body), "replay window")
+		verifier.now = func() time.Time { return signedAt.Add(-6 * time.Minute) }
+		assert.ErrorContains(verifier.Verify(getHeaders(), body), "replay window")
+	})
+
+	t.Run("test ParseSinchInboundMessage", func(t *testing.T) {
+		inboundMessage, isText, err := ParseSinchInboundMessage(body)
+		assert.NoError(err, "error on parsing test payload: %v", err)
+		assert.True(isText, "test payload should be a text message")
+		assert.Equal("01HZZKQ7C3V9Y2J4W6N8P0R5T1", inboundMessage.ID)
+		assert.Equal(core.Recipient{Channel: core.ChannelSMS, Identity: "15555550100"}, inboundMessage.From)
+		assert.Equal("Do I need to register my canoe?", inboundMessage.Text)
+		assert.Equal(time.Date(2024, 6, 10, 6, 13, 19, 258000000, time.UTC), inboundMessage.ReceivedAt.UTC())
+
+		whatsAppBody := strings.Replace(body, `"channel":"SMS"`, `"channel":"WHATSAPP"`, 1)
+		inboundMessage, _, _ = ParseSinchInboundMessage(whatsAppBody)
+		assert.Equal(core.ChannelWhatsApp, inboundMessage.From.Channel)
+		_, isText, _ = ParseSinchInboundMessage(strings.Replace(body, `"channel":"SMS"`, `"channel":"TELEGRAM"`, 1))
+		assert.False(isText, "unsupported channels should be ignored")
+		_, isText, err = ParseSinchInboundMessage(`{"message_delivery_report":{"status":"DELIVERED"}}`)
+		assert.NoError(err, "error on parsing delivery report: %v", err)
+		assert.False(isText, "delivery reports should be ignored")
+		_, _, err = ParseSinchInboundMessage("not json")
+		assert.Error(err, "invalid json should fail")
+	})
+
+	t.Run("test GetSinchSignature", func(t *testing.T) {
+		assert.Equal(testWebhookSignature, GetSinchSignature([]byte(testWebhookSecret), body, testWebhookNonce, testWebhookTimestamp))
 	})
 }
@@ -0,0 +1,31 @@
+package comms
+
+import (
+	"code/core"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Console prints every message to a writer instead of delivering it, for chatting in a terminal.
+type Console struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+func InitializeConsole(writer io.Writer) (*Console, error) {
+	if writer == nil {
+		return nil, fmt.Errorf("writer is not specified")
+	}
+	return &Console{writer: writer}, nil
+}
+
+func (console *Console) SendMessage(ctx context.Context, to core.Recipient, messageContent string) error {
+	console.mutex.Lock()
+	defer console.mutex.Unlock()
+	if _, err := fmt.Fprintln(console.writer, messageContent); err != nil {
+		return fmt.Errorf("error on writing message: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package comms
+
+import (
+	"code/core"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Router sends every message with the comms of the recipient's channel.
+type Router struct {
+	channels map[core.Channel]core.Comms
+}
+
+func InitializeRouter(channels map[core.Channel]core.Comms) (*Router, error) {
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("no channels specified")
+	}
+	return &Router{channels: channels}, nil
+}
+
+func (router *Router) SendMessage(ctx context.Context, to core.Recipient, messageContent string) error {
+	comms, ok := router.channels[to.Channel]
+	if !ok {
+		return fmt.Errorf("channel=%s is not configured", to.Channel)
+	}
+	return comms.SendMessage(ctx, to, messageContent)
+}
+
+// Outbox keeps the messages sent to a web chat session, so they are returned in the response to its
+// request rather than delivered.
+type Outbox struct {
+	messages []string
+	mutex    sync.Mutex
+}
+
+func InitializeOutbox() (*Outbox, error) {
+	return &Outbox{messages: make([]string, 0)}, nil
+}
+
+func (outbox *Outbox) SendMessage(ctx context.Context, to core.Recipient, messageContent string) error {
+	if to.Channel != core.ChannelWeb {
+		return fmt.Errorf("outbox only keeps web chat messages, got channel=%s", to.Channel)
+	}
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	outbox.messages = append(outbox.messages, messageContent)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (outbox *Outbox) Messages() []string {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	return append(make([]string, 0, len(outbox.messages)), outbox.messages...)
+}
@@ -2,6 +2,7 @@ package comms
 
 import (
 	"bytes"
+	"code/core"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,9 +11,11 @@ import (
 	"time"
 )
 
-const sinchAPIURL = "https://us.sms.api.sinch.com/xms/v1/%s/batches"
+const sinchBatchesURL = "%s/%s/batches"
 
+// SinchHelper texts SMS recipients with the Sinch SMS API.
 type SinchHelper struct {
+	apiURL                  string
 	apiToken                string
 	client                  *http.Client
 	projectID               string
@@ -20,11 +23,12 @@ type SinchHelper struct {
 	timeout                 time.Duration
 }
 
-func InitializeSinchHelper(ctx context.Context, apiToken, projectID, sinchVirtualPhoneNumber string, contextTimeout time.Duration) (*SinchHelper, error) {
-	if len(apiToken) == 0 || len(projectID) == 0 || len(sinchVirtualPhoneNumber) == 0 {
-		return nil, fmt.Errorf("apiToken, projectID, or sinchVirtualPhoneNumber is not specified")
+func InitializeSinchHelper(ctx context.Context, apiURL, apiToken, projectID, sinchVirtualPhoneNumber string, contextTimeout time.Duration) (*SinchHelper, error) {
+	if len(apiURL) == 0 || len(apiToken) == 0 || len(projectID) == 0 || len(sinchVirtualPhoneNumber) == 0 {
+		return nil, fmt.Errorf("apiURL, apiToken, projectID, or sinchVirtualPhoneNumber is not specified")
 	}
 	return &SinchHelper{
+		apiURL:                  strings.TrimSuffix(apiURL, "/"),
 		apiToken:                apiToken,
 		client:                  &http.Client{},
 		projectID:               projectID,
@@ -33,9 +37,13 @@ func InitializeSinchHelper(ctx context.Context, apiToken, projectID, sinchVirtua
 	}, nil
 }
 
-func (sh *SinchHelper) SendMessage(ctx context.Context, toPhoneNumber, messageContent string) error {
-	url := fmt.Sprintf(sinchAPIURL, sh.projectID)
+func (sh *SinchHelper) SendMessage(ctx context.Context, to core.Recipient, messageContent string) error {
+	if to.Channel != core.ChannelSMS {
+		return fmt.Errorf("sinch helper only sends SMS, got channel=%s", to.Channel)
+	}
+	url := fmt.Sprintf(sinchBatchesURL, sh.apiURL, sh.projectID)
 
+	toPhoneNumber := to.Identity
 	if !strings.HasPrefix(toPhoneNumber, "+") {
 		toPhoneNumber = "+" + toPhoneNumber
 	}
@@ -0,0 +1,159 @@
+package comms
+
+import (
+	"bytes"
+	"code/core"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const sinchMessagesSendURL = "%s/v1/projects/%s/messages:send"
+
+// access tokens are renewed this long before they expire
+const sinchAccessTokenMargin = time.Minute
+
+// channels the Sinch Conversation API sends messages over
+var sinchConversationChannels = map[core.Channel]bool{
+	core.ChannelWhatsApp:  true,
+	core.ChannelMessenger: true,
+	core.ChannelRCS:       true,
+}
+
+// SinchConversationHelper sends messages over WhatsApp, Messenger and RCS with the Sinch Conversation API.
+// It authenticates with an OAuth access token obtained with the access key, and reuses the token until
+// it is about to expire.
+type SinchConversationHelper struct {
+	apiURL          string
+	authURL         string
+	projectID       string
+	appID           string
+	accessKeyID     string
+	accessKeySecret string
+	client          *http.Client
+	timeout         time.Duration
+	accessToken     string
+	expiresAt       time.Time
+	mutex           sync.Mutex
+}
+
+type sinchAccessTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func InitializeSinchConversationHelper(ctx context.Context, apiURL, authURL, projectID, appID, accessKeyID, accessKeySecret string, contextTimeout time.Duration) (*SinchConversationHelper, error) {
+	if len(apiURL) == 0 || len(authURL) == 0 || len(projectID) == 0 || len(appID) == 0 || len(accessKeyID) == 0 || len(accessKeySecret) == 0 {
+		return nil, fmt.Errorf("apiURL, authURL, projectID, appID, accessKeyID, or accessKeySecret is not specified")
+	}
+	return &SinchConversationHelper{
+		apiURL:          strings.TrimSuffix(apiURL, "/"),
+		authURL:         authURL,
+		projectID:       projectID,
+		appID:           appID,
+		accessKeyID:     accessKeyID,
+		accessKeySecret: accessKeySecret,
+		client:          &http.Client{},
+		timeout:         contextTimeout,
+	}, nil
+}
+
+func (sch *SinchConversationHelper) SendMessage(ctx context.Context, to core.Recipient, messageContent string) error {
+	if !sinchConversationChannels[to.Channel] {
+		return fmt.Errorf("sinch conversation helper does not send over channel=%s", to.Channel)
+	}
+	accessToken, err := sch.getAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting access token: %v", err)
+	}
+
+	payload := map[string]interface{}{
+		"app_id": sch.appID,
+		"recipient": map[string]interface{}{
+			"identified_by": map[string]interface{}{
+				"channel_identities": []map[string]string{
+					{"channel": string(to.Channel), "identity": to.Identity},
+				},
+			},
+		},
+		"message": map[string]interface{}{
+			"text_message": map[string]string{"text": messageContent},
+		},
+		"channel_priority_order": []string{string(to.Channel)},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sch.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf(sinchMessagesSendURL, sch.apiURL, sch.projectID), bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+	return sch.do(req, nil)
+}
+
+// getAccessToken returns the cached access token, or gets a new one with the client credentials grant.
+func (sch *SinchConversationHelper) getAccessToken(ctx context.Context) (string, error) {
+	sch.mutex.Lock()
+	defer sch.mutex.Unlock()
+	if len(sch.accessToken) > 0 && time.Now().Add(sinchAccessTokenMargin).Before(sch.expiresAt) {
+		return sch.accessToken, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sch.timeout)
+	defer cancel()
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, "POST", sch.authURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(sch.accessKeyID, sch.accessKeySecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var response sinchAccessTokenResponse
+	if err := sch.do(req, &response); err != nil {
+		return "", err
+	}
+	if len(response.AccessToken) == 0 {
+		return "", fmt.Errorf("no access token in response")
+	}
+	sch.accessToken = response.AccessToken
+	sch.expiresAt = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+	return sch.accessToken, nil
+}
+
+// do sends the request and decodes the response into output unless it is nil
+func (sch *SinchConversationHelper) do(req *http.Request, output interface{}) error {
+	resp, err := sch.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var responseBody bytes.Buffer
+		_, err := responseBody.ReadFrom(resp.Body)
+		if err != nil {
+			return fmt.Errorf("received non-2xx response status: %s, and failed to read response body: %w", resp.Status, err)
+		}
+		return fmt.Errorf("received non-2xx response status: %s, response body: %s", resp.Status, responseBody.String())
+	}
+	if output == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(output); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package comms
+
+import (
+	"code/core"
+	"code/infrastructure/types"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// headers Sinch signs its Conversation API callbacks with
+const (
+	SinchSignatureHeader = "x-sinch-webhook-signature"
+	SinchNonceHeader     = "x-sinch-webhook-signature-nonce"
+	SinchTimestampHeader = "x-sinch-webhook-signature-timestamp"
+	SinchAlgorithmHeader = "x-sinch-webhook-signature-algorithm"
+)
+
+const sinchSignatureAlgorithm = "HmacSHA256"
+
+// SinchWebhookVerifier checks that callbacks were signed by Sinch with the webhook secret, and were
+// signed recently enough that they are not being replayed.
+type SinchWebhookVerifier struct {
+	secret       []byte
+	replayWindow time.Duration
+	now          func() time.Time
+}
+
+func InitializeSinchWebhookVerifier(secret string, replayWindow time.Duration) (*SinchWebhookVerifier, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("webhook secret is not specified")
+	}
+	if replayWindow <= 0 {
+		return nil, fmt.Errorf("replay window must be positive, got replayWindow=%v", replayWindow)
+	}
+	return &SinchWebhookVerifier{secret: []byte(secret), replayWindow: replayWindow, now: time.Now}, nil
+}
+
+// Verify returns an error unless the signature header is the base64 HMAC-SHA256 of "body.nonce.timestamp"
+// and the timestamp is within the replay window. Header names are matched case-insensitively.
+func (verifier *SinchWebhookVerifier) Verify(headers map[string]string, body string) error {
+	lowerHeaders := make(map[string]string, len(headers))
+	for name, value := range headers {
+		lowerHeaders[strings.ToLower(name)] = strings.TrimSpace(value)
+	}
+	signature := lowerHeaders[SinchSignatureHeader]
+	nonce := lowerHeaders[SinchNonceHeader]
+	timestamp := lowerHeaders[SinchTimestampHeader]
+	if len(signature) == 0 || len(nonce) == 0 || len(timestamp) == 0 {
+		return fmt.Errorf("missing signature, nonce or timestamp header")
+	}
+	if algorithm, ok := lowerHeaders[SinchAlgorithmHeader]; ok && !strings.EqualFold(algorithm, sinchSignatureAlgorithm) {
+		return fmt.Errorf("unsupported signature algorithm=%s", algorithm)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("error parsing timestamp=%s: %v", timestamp, err)
+	}
+	age := verifier.now().Sub(time.Unix(seconds, 0))
+	if age > verifier.replayWindow || age < -verifier.replayWindow {
+		return fmt.Errorf("timestamp=%s is outside of the replay window=%v", timestamp, verifier.replayWindow)
+	}
+
+	expected := GetSinchSignature(verifier.secret, body, nonce, timestamp)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+// GetSinchSignature signs a callback the way Sinch does.
+func GetSinchSignature(secret []byte, body, nonce, timestamp string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body + "." + nonce + "." + timestamp))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// channels Sinch delivers inbound messages from, by their name in callbacks
+var sinchInboundChannels = map[string]core.Channel{
+	"SMS":       core.ChannelSMS,
+	"WHATSAPP":  core.ChannelWhatsApp,
+	"MESSENGER": core.ChannelMessenger,
+	"RCS":       core.ChannelRCS,
+}
+
+// ParseSinchInboundMessage returns the text message of a Sinch Conversation API callback. It returns false
+// for callbacks without a text message from a supported channel, e.g. delivery reports or images.
+func ParseSinchInboundMessage(body string) (core.InboundMessage, bool, error) {
+	var whp types.SinchWebhookPayload
+	if err := json.Unmarshal([]byte(body), &whp); err != nil {
+		return core.InboundMessage{}, false, fmt.Errorf("error on unmarshalling json: %v", err)
+	}
+	channel, ok := sinchInboundChannels[strings.ToUpper(whp.Message.ChannelIdentity.Channel)]
+	inboundMessage := core.InboundMessage{
+		ID:         whp.Message.ID,
+		From:       core.Recipient{Channel: channel, Identity: whp.Message.ChannelIdentity.Identity},
+		Text:       whp.Message.ContactMessage.TextMessage.Text,
+		ReceivedAt: whp.Message.AcceptTime,
+	}
+	if !ok || len(inboundMessage.ID) == 0 || len(inboundMessage.From.Identity) == 0 || len(inboundMessage.Text) == 0 {
+		return inboundMessage, false, nil
+	}
+	return inboundMessage, true, nil
+}
@@ -0,0 +1,373 @@
+package factories
+
+import (
+	"code/core"
+	"code/infrastructure/comms"
+	"code/infrastructure/indexers"
+	"code/infrastructure/memory"
+	"code/infrastructure/queues"
+	"code/infrastructure/settings"
+	"code/infrastructure/stores"
+	"code/infrastructure/vectorizers"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// names of the queues, memory queues with the same name are shared within a process
+const (
+	urlQueueName       = "url"
+	rawEventsQueueName = "raw-events"
+	toIndexQueueName   = "to-index"
+	inboundQueueName   = "inbound"
+)
+
+// DataStore holds both raw pages and chunks.
+type DataStore interface {
+	core.RawDataStore
+	core.ChunksDataStore
+}
+
+// memory backends are created once per process, so every cmd wired in the same process shares them
+var (
+	memoryMutex             sync.Mutex
+	memoryQueues            = make(map[string]*memory.Queue)
+	memoryDataStore         *memory.DataStore
+	memorySeenURLStore      *memory.SeenURLStore
+	memorySearchIndex       *memory.SearchIndex
+	memoryConversationStore *memory.ConversationStore
+	memoryOptOutStore       *memory.OptOutStore
+	memoryRateLimiter       *memory.RateLimiter
+	memoryDedupStore        *memory.DedupStore
+	memoryReferenceGraph    *memory.ReferenceGraph
+	memoryDefinitionStore   *memory.DefinitionStore
+)
+
+func InitializeURLQueue(ctx context.Context, mySettings *settings.Settings) (core.URLQueue, error) {
+	return initializeQueue(ctx, mySettings, urlQueueName, mySettings.URLSQSARN)
+}
+
+func InitializeRawEventsQueue(ctx context.Context, mySettings *settings.Settings) (core.RawEventsQueue, error) {
+	return initializeQueue(ctx, mySettings, rawEventsQueueName, mySettings.RawEventsSQSARN)
+}
+
+func InitializeToIndexQueue(ctx context.Context, mySettings *settings.Settings) (core.Queue, error) {
+	return initializeQueue(ctx, mySettings, toIndexQueueName, mySettings.ToIndexSQSARN)
+}
+
+func InitializeInboundQueue(ctx context.Context, mySettings *settings.Settings) (core.Queue, error) {
+	return initializeQueue(ctx, mySettings, inboundQueueName, mySettings.InboundSQSARN)
+}
+
+func InitializeDataStore(ctx context.Context, mySettings *settings.Settings) (DataStore, error) {
+	switch mySettings.DataStoreBackend {
+	case settings.DataStoreBackendS3:
+		s3Helper, err := stores.InitializeS3Helper(ctx, mySettings.MainBucketName, mySettings.RawPathPrefix, mySettings.ChunkPathPrefix, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing s3 helper: %v", err)
+		}
+		return s3Helper, nil
+	case settings.DataStoreBackendFileSystem:
+		fsStore, err := stores.InitializeFileSystemStore(mySettings.DataStoreDir, mySettings.RawPathPrefix, mySettings.ChunkPathPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing filesystem store: %v", err)
+		}
+		return fsStore, nil
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memoryDataStore == nil {
+			dataStore, err := memory.InitializeDataStore(mySettings.RawPathPrefix, mySettings.ChunkPathPrefix)
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory data store: %v", err)
+			}
+			memoryDataStore = dataStore
+		}
+		return memoryDataStore, nil
+	default:
+		return nil, fmt.Errorf("unsupported data store backend=%s", mySettings.DataStoreBackend)
+	}
+}
+
+func InitializeSeenURLStore(ctx context.Context, mySettings *settings.Settings) (core.SeenURLStore, error) {
+	switch mySettings.SeenURLStoreBackend {
+	case settings.SeenURLStoreBackendDynamoDB:
+		return initializeTable1(ctx, mySettings)
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memorySeenURLStore == nil {
+			seenURLStore, err := memory.InitializeSeenURLStore()
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory seen url store: %v", err)
+			}
+			memorySeenURLStore = seenURLStore
+		}
+		return memorySeenURLStore, nil
+	default:
+		return nil, fmt.Errorf("unsupported seen url store backend=%s", mySettings.SeenURLStoreBackend)
+	}
+}
+
+func InitializeConversationStore(ctx context.Context, mySettings *settings.Settings) (core.ConversationStore, error) {
+	switch mySettings.ConversationStoreBackend {
+	case settings.ConversationStoreBackendDynamoDB:
+		return initializeTable1(ctx, mySettings)
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memoryConversationStore == nil {
+			conversationStore, err := memory.InitializeConversationStore(mySettings.ConversationTTL)
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory conversation store: %v", err)
+			}
+			memoryConversationStore = conversationStore
+		}
+		return memoryConversationStore, nil
+	default:
+		return nil, fmt.Errorf("unsupported conversation store backend=%s", mySettings.ConversationStoreBackend)
+	}
+}
+
+func InitializeOptOutStore(ctx context.Context, mySettings *settings.Settings) (core.OptOutStore, error) {
+	switch mySettings.OptOutStoreBackend {
+	case settings.OptOutStoreBackendDynamoDB:
+		return initializeTable1(ctx, mySettings)
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memoryOptOutStore == nil {
+			optOutStore, err := memory.InitializeOptOutStore()
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory opt-out store: %v", err)
+			}
+			memoryOptOutStore = optOutStore
+		}
+		return memoryOptOutStore, nil
+	default:
+		return nil, fmt.Errorf("unsupported opt-out store backend=%s", mySettings.OptOutStoreBackend)
+	}
+}
+
+func InitializeDedupStore(ctx context.Context, mySettings *settings.Settings) (core.DedupStore, error) {
+	switch mySettings.DedupStoreBackend {
+	case settings.DedupStoreBackendDynamoDB:
+		return initializeTable1(ctx, mySettings)
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memoryDedupStore == nil {
+			dedupStore, err := memory.InitializeDedupStore()
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory dedup store: %v", err)
+			}
+			memoryDedupStore = dedupStore
+		}
+		return memoryDedupStore, nil
+	default:
+		return nil, fmt.Errorf("unsupported dedup store backend=%s", mySettings.DedupStoreBackend)
+	}
+}
+
+func InitializeReferenceGraph(ctx context.Context, mySettings *settings.Settings) (core.ReferenceGraph, error) {
+	switch mySettings.ReferenceGraphBackend {
+	case settings.ReferenceGraphBackendDynamoDB:
+		return initializeTable1(ctx, mySettings)
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memoryReferenceGraph == nil {
+			referenceGraph, err := memory.InitializeReferenceGraph()
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory reference graph: %v", err)
+			}
+			memoryReferenceGraph = referenceGraph
+		}
+		return memoryReferenceGraph, nil
+	default:
+		return nil, fmt.Errorf("unsupported reference graph backend=%s", mySettings.ReferenceGraphBackend)
+	}
+}
+
+func InitializeDefinitionStore(ctx context.Context, mySettings *settings.Settings) (core.DefinitionStore, error) {
+	switch mySettings.DefinitionStoreBackend {
+	case settings.DefinitionStoreBackendDynamoDB:
+		return initializeTable1(ctx, mySettings)
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memoryDefinitionStore == nil {
+			definitionStore, err := memory.InitializeDefinitionStore()
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory definition store: %v", err)
+			}
+			memoryDefinitionStore = definitionStore
+		}
+		return memoryDefinitionStore, nil
+	default:
+		return nil, fmt.Errorf("unsupported definition store backend=%s", mySettings.DefinitionStoreBackend)
+	}
+}
+
+func InitializeRateLimiter(ctx context.Context, mySettings *settings.Settings) (core.RateLimiter, error) {
+	switch mySettings.RateLimiterBackend {
+	case settings.RateLimiterBackendDynamoDB:
+		table1, err := initializeTable1(ctx, mySettings)
+		if err != nil {
+			return nil, err
+		}
+		rateLimiter, err := stores.InitializeRateLimiter(table1, mySettings.GetRateLimits())
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing rate limiter: %v", err)
+		}
+		return rateLimiter, nil
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memoryRateLimiter == nil {
+			rateLimiter, err := memory.InitializeRateLimiter(mySettings.GetRateLimits())
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory rate limiter: %v", err)
+			}
+			memoryRateLimiter = rateLimiter
+		}
+		return memoryRateLimiter, nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limiter backend=%s", mySettings.RateLimiterBackend)
+	}
+}
+
+func InitializeSearchIndex(ctx context.Context, mySettings *settings.Settings, logger core.Logger) (core.SearchIndex, error) {
+	switch mySettings.IndexBackend {
+	case settings.IndexBackendOpenSearch:
+		osiHelper, err := indexers.InitializeOpenSearchIndexerHelper(ctx, mySettings.OpensearchUsername, mySettings.OpensearchPassword, mySettings.OpensearchDomain, mySettings.DoAllowOpensearchInsecure, mySettings.OpensearchIndexName, mySettings.OpensearchLexicalWeight, mySettings.OpensearchVectorWeight, mySettings.ContextTimeout, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing opensearch indexer helper: %v", err)
+		}
+		return osiHelper, nil
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if memorySearchIndex == nil {
+			searchIndex, err := memory.InitializeSearchIndex(0)
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing memory search index: %v", err)
+			}
+			memorySearchIndex = searchIndex
+		}
+		return memorySearchIndex, nil
+	default:
+		return nil, fmt.Errorf("unsupported index backend=%s", mySettings.IndexBackend)
+	}
+}
+
+func InitializeVectorizer(ctx context.Context, mySettings *settings.Settings) (core.Vectorizer, error) {
+	switch mySettings.ModelBackend {
+	case settings.ModelBackendBedrock:
+		bedrockHelper, err := initializeBedrockHelper(ctx, mySettings)
+		if err != nil {
+			return nil, err
+		}
+		return bedrockHelper, nil
+	case settings.BackendMemory:
+		vectorizer, err := memory.InitializeVectorizer(0)
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing memory vectorizer: %v", err)
+		}
+		return vectorizer, nil
+	default:
+		return nil, fmt.Errorf("unsupported model backend=%s", mySettings.ModelBackend)
+	}
+}
+
+func InitializeAgent(ctx context.Context, mySettings *settings.Settings) (core.Agent, error) {
+	switch mySettings.ModelBackend {
+	case settings.ModelBackendBedrock:
+		bedrockHelper, err := initializeBedrockHelper(ctx, mySettings)
+		if err != nil {
+			return nil, err
+		}
+		return bedrockHelper, nil
+	case settings.BackendMemory:
+		agent, err := memory.InitializeAgent()
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing memory agent: %v", err)
+		}
+		return agent, nil
+	default:
+		return nil, fmt.Errorf("unsupported model backend=%s", mySettings.ModelBackend)
+	}
+}
+
+func InitializeComms(ctx context.Context, mySettings *settings.Settings) (core.Comms, error) {
+	switch mySettings.CommsBackend {
+	case settings.CommsBackendSinch:
+		sinchHelper, err := comms.InitializeSinchHelper(ctx, mySettings.SinchAPIURL, mySettings.SinchAPIToken, mySettings.SinchServiceID, mySettings.SinchVirtualPhoneNumber, mySettings.ContextTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing sinch helper: %v", err)
+		}
+		channels := map[core.Channel]core.Comms{core.ChannelSMS: sinchHelper}
+		if len(mySettings.SinchAppID) > 0 {
+			sinchConversationHelper, err := comms.InitializeSinchConversationHelper(ctx, mySettings.SinchConversationAPIURL, mySettings.SinchAuthURL, mySettings.SinchProjectID, mySettings.SinchAppID, mySettings.SinchAccessKeyID, mySettings.SinchAccessKeySecret, mySettings.ContextTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing sinch conversation helper: %v", err)
+			}
+			channels[core.ChannelWhatsApp] = sinchConversationHelper
+			channels[core.ChannelMessenger] = sinchConversationHelper
+			channels[core.ChannelRCS] = sinchConversationHelper
+		}
+		router, err := comms.InitializeRouter(channels)
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing router: %v", err)
+		}
+		return router, nil
+	case settings.BackendMemory:
+		memoryComms, err := memory.InitializeComms()
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing memory comms: %v", err)
+		}
+		return memoryComms, nil
+	default:
+		return nil, fmt.Errorf("unsupported comms backend=%s", mySettings.CommsBackend)
+	}
+}
+
+func initializeQueue(ctx context.Context, mySettings *settings.Settings, name, queueARN string) (core.URLQueue, error) {
+	switch mySettings.QueueBackend {
+	case settings.QueueBackendSQS:
+		sqsHelper, err := queues.InitializeSQSHelper(ctx, queueARN, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("error on initializing %s sqs helper: %v", name, err)
+		}
+		return sqsHelper, nil
+	case settings.BackendMemory:
+		memoryMutex.Lock()
+		defer memoryMutex.Unlock()
+		if _, ok := memoryQueues[name]; !ok {
+			queue, err := memory.InitializeQueue(0)
+			if err != nil {
+				return nil, fmt.Errorf("error on initializing %s memory queue: %v", name, err)
+			}
+			memoryQueues[name] = queue
+		}
+		return memoryQueues[name], nil
+	default:
+		return nil, fmt.Errorf("unsupported queue backend=%s", mySettings.QueueBackend)
+	}
+}
+
+func initializeTable1(ctx context.Context, mySettings *settings.Settings) (*stores.Table1, error) {
+	table1, err := stores.InitializeTable1(ctx, mySettings.Table1ARN, mySettings.ConversationTTL, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error on initializing table1: %v", err)
+	}
+	return table1, nil
+}
+
+func initializeBedrockHelper(ctx context.Context, mySettings *settings.Settings) (*vectorizers.BedrockHelper, error) {
+	bedrockHelper, err := vectorizers.InitializeBedrockHelper(ctx, mySettings.EmbeddingModelID, mySettings.FoundationModelID, mySettings.ContextTimeout, mySettings.BedrockEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error on initializing bedrock helper: %v", err)
+	}
+	return bedrockHelper, nil
+}
@@ -0,0 +1,129 @@
+package factories
+
+import (
+	"code/core"
+	"code/infrastructure/loggers"
+	"code/infrastructure/memory"
+	"code/infrastructure/settings"
+	"code/infrastructure/stores"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMemorySettings() *settings.Settings {
+	return &settings.Settings{
+		RawPathPrefix:            "raw",
+		ChunkPathPrefix:          "chunk",
+		DataStoreBackend:         settings.BackendMemory,
+		QueueBackend:             settings.BackendMemory,
+		SeenURLStoreBackend:      settings.BackendMemory,
+		IndexBackend:             settings.BackendMemory,
+		ModelBackend:             settings.BackendMemory,
+		CommsBackend:             settings.BackendMemory,
+		ConversationStoreBackend: settings.BackendMemory,
+		OptOutStoreBackend:       settings.BackendMemory,
+		RateLimiterBackend:       settings.BackendMemory,
+		DedupStoreBackend:        settings.BackendMemory,
+		ReferenceGraphBackend:    settings.BackendMemory,
+		DefinitionStoreBackend:   settings.BackendMemory,
+		RateLimitBurst:           5,
+		RateLimitPerHour:         10,
+		ConversationTTL:          time.Minute,
+	}
+}
+
+func TestFactories(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+	logger, _ := loggers.InitializeMultiLogger(false)
+
+	t.Run("test memory backends are shared within the process", func(t *testing.T) {
+		mySettings := newMemorySettings()
+
+		urlQueue, err := InitializeURLQueue(ctx, mySettings)
+		assert.NoError(err, "error on initializing url queue: %v", err)
+		sameURLQueue, _ := InitializeURLQueue(ctx, mySettings)
+		rawEventsQueue, err := InitializeRawEventsQueue(ctx, mySettings)
+		assert.NoError(err, "error on initializing raw events queue: %v", err)
+		assert.Same(urlQueue, sameURLQueue, "url queues should be shared")
+		assert.NotSame(urlQueue, rawEventsQueue, "url and raw events queues should differ")
+
+		urlQueue.SendURL(ctx, "https://url1.com")
+		msg, _ := sameURLQueue.ReceiveMessage(ctx)
+		assert.Equal("https://url1.com", msg.Body, "message should be received from the shared queue")
+
+		dataStore, err := InitializeDataStore(ctx, mySettings)
+		assert.NoError(err, "error on initializing data store: %v", err)
+		sameDataStore, _ := InitializeDataStore(ctx, mySettings)
+		assert.Same(dataStore, sameDataStore, "data stores should be shared")
+
+		searchIndex, err := InitializeSearchIndex(ctx, mySettings, logger)
+		assert.NoError(err, "error on initializing search index: %v", err)
+		sameSearchIndex, _ := InitializeSearchIndex(ctx, mySettings, logger)
+		assert.Same(searchIndex, sameSearchIndex, "search indexes should be shared")
+
+		seenURLStore, err := InitializeSeenURLStore(ctx, mySettings)
+		assert.NoError(err, "error on initializing seen url store: %v", err)
+		assert.IsType(&memory.SeenURLStore{}, seenURLStore)
+
+		var vectorizer core.Vectorizer
+		vectorizer, err = InitializeVectorizer(ctx, mySettings)
+		assert.NoError(err, "error on initializing vectorizer: %v", err)
+		assert.IsType(&memory.Vectorizer{}, vectorizer)
+		agent, err := InitializeAgent(ctx, mySettings)
+		assert.NoError(err, "error on initializing agent: %v", err)
+		assert.IsType(&memory.Agent{}, agent)
+		comms, err := InitializeComms(ctx, mySettings)
+		assert.NoError(err, "error on initializing comms: %v", err)
+		assert.IsType(&memory.Comms{}, comms)
+		conversationStore, err := InitializeConversationStore(ctx, mySettings)
+		assert.NoError(err, "error on initializing conversation store: %v", err)
+		sameConversationStore, _ := InitializeConversationStore(ctx, mySettings)
+		assert.Same(conversationStore, sameConversationStore, "conversation stores should be shared")
+		optOutStore, err := InitializeOptOutStore(ctx, mySettings)
+		assert.NoError(err, "error on initializing opt-out store: %v", err)
+		sameOptOutStore, _ := InitializeOptOutStore(ctx, mySettings)
+		assert.Same(optOutStore, sameOptOutStore, "opt-out stores should be shared")
+		rateLimiter, err := InitializeRateLimiter(ctx, mySettings)
+		assert.NoError(err, "error on initializing rate limiter: %v", err)
+		sameRateLimiter, _ := InitializeRateLimiter(ctx, mySettings)
+		assert.Same(rateLimiter, sameRateLimiter, "rate limiters should be shared")
+		dedupStore, err := InitializeDedupStore(ctx, mySettings)
+		assert.NoError(err, "error on initializing dedup store: %v", err)
+		sameDedupStore, _ := InitializeDedupStore(ctx, mySettings)
+		assert.Same(dedupStore, sameDedupStore, "dedup stores should be shared")
+		referenceGraph, err := InitializeReferenceGraph(ctx, mySettings)
+		assert.NoError(err, "error on initializing reference graph: %v", err)
+		sameReferenceGraph, _ := InitializeReferenceGraph(ctx, mySettings)
+		assert.Same(referenceGraph, sameReferenceGraph, "reference graphs should be shared")
+		definitionStore, err := InitializeDefinitionStore(ctx, mySettings)
+		assert.NoError(err, "error on initializing definition store: %v", err)
+		sameDefinitionStore, _ := InitializeDefinitionStore(ctx, mySettings)
+		assert.Same(definitionStore, sameDefinitionStore, "definition stores should be shared")
+	})
+
+	t.Run("test filesystem data store", func(t *testing.T) {
+		mySettings := newMemorySettings()
+		mySettings.DataStoreBackend = settings.DataStoreBackendFileSystem
+		mySettings.DataStoreDir = t.TempDir()
+		dataStore, err := InitializeDataStore(ctx, mySettings)
+		assert.NoError(err, "error on initializing data store: %v", err)
+		assert.IsType(&stores.FileSystemStore{}, dataStore)
+	})
+
+	t.Run("test unsupported backends are rejected", func(t *testing.T) {
+		mySettings := newMemorySettings()
+		mySettings.QueueBackend = "sqlite"
+		mySettings.DataStoreBackend = "unknown"
+		mySettings.IndexBackend = "bleve"
+		_, err := InitializeURLQueue(ctx, mySettings)
+		assert.Error(err, "unsupported queue backend should fail")
+		_, err = InitializeDataStore(ctx, mySettings)
+		assert.Error(err, "unsupported data store backend should fail")
+		_, err = InitializeSearchIndex(ctx, mySettings, logger)
+		assert.Error(err, "unsupported index backend should fail")
+	})
+}
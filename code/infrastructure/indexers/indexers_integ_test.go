@@ -28,7 +28,7 @@ func TestIndexers(t *testing.T) {
 	assert.NoError(err, "error on get settings: %v", err)
 	logger, err := loggers.InitializeMultiLogger(true)
 	assert.NoError(err, "error on initializing multilogger: %v", err)
-	osiHelper, err := InitializeOpenSearchIndexerHelper(ctx, mySettings.OpensearchUsername, mySettings.OpensearchPassword, mySettings.OpensearchDomain, mySettings.DoAllowOpensearchInsecure, mySettings.OpensearchIndexName, mySettings.ContextTimeout, logger)
+	osiHelper, err := InitializeOpenSearchIndexerHelper(ctx, mySettings.OpensearchUsername, mySettings.OpensearchPassword, mySettings.OpensearchDomain, mySettings.DoAllowOpensearchInsecure, mySettings.OpensearchIndexName, mySettings.OpensearchLexicalWeight, mySettings.OpensearchVectorWeight, mySettings.ContextTimeout, logger)
 	assert.NoError(err, "error on creating opensearch indexer helper: %v", err)
 
 	t.Run("test can setup index", func(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 	"strings"
 	"time"
 
@@ -20,6 +21,42 @@ import (
 )
 
 const findMatchesK = 10
+
+// each search fetches more candidates than needed so that the fusion can promote chunks ranked lower by one search
+const findCandidatesK = 5 * findMatchesK
+
+// rankConstant dampens the reciprocal rank fusion scores of the top ranks, 60 is the usual choice
+const rankConstant = 60
+
+// indexMappings are the mappings of the fields of the index documents, they are put on indexes created before
+// some of the fields existed
+const indexMappings = `{
+	"properties": {
+		"id": {
+			"type": "keyword"
+		},
+		"vector": {
+			"type": "knn_vector",
+			"dimension": 1024
+		},
+		"text": {
+			"type": "text"
+		},
+		"chapter": {
+			"type": "keyword"
+		},
+		"section": {
+			"type": "keyword"
+		},
+		"statute": {
+			"type": "keyword"
+		},
+		"subdivision": {
+			"type": "keyword"
+		}
+	}
+}`
+
 const indexSettingsForKNNEmbeddings = `{
 	"settings": {
 		"index": {
@@ -29,21 +66,30 @@ const indexSettingsForKNNEmbeddings = `{
 			"knn.algo_param.m": 16
 		}
 	},
-	"mappings": {
-		"properties": {
-			"vector": {
-				"type": "knn_vector",
-				"dimension": 1024
-			}
-		}
-	}
+	"mappings": ` + indexMappings + `
 }`
 
+// statuteNumberRegexp matches statute numbers like "609.52" or "86B.33" in a query
+var statuteNumberRegexp = regexp.MustCompile(`\b\d+[A-Za-z]?\.\d+[A-Za-z]?\b`)
+
 type OpenSearchIndexerHelper struct {
-	client    *opensearch.Client
-	indexName string
-	timeout   time.Duration
-	logger    core.Logger
+	client        *opensearch.Client
+	indexName     string
+	lexicalWeight float64
+	vectorWeight  float64
+	timeout       time.Duration
+	logger        core.Logger
+}
+
+// indexDocument is a chunk as stored in the index, statute is "chapter.section"
+type indexDocument struct {
+	ID          string    `json:"id"`
+	Vector      []float64 `json:"vector"`
+	Text        string    `json:"text"`
+	Chapter     string    `json:"chapter"`
+	Section     string    `json:"section"`
+	Statute     string    `json:"statute"`
+	Subdivision string    `json:"subdivision"`
 }
 
 type SearchResponse struct {
@@ -62,7 +108,10 @@ type ErrorResponse struct {
 	} `json:"error"`
 }
 
-func InitializeOpenSearchIndexerHelper(ctx context.Context, username, password, domain string, doInsecureSkipVerify bool, indexName string, timeout time.Duration, logger core.Logger) (*OpenSearchIndexerHelper, error) {
+func InitializeOpenSearchIndexerHelper(ctx context.Context, username, password, domain string, doInsecureSkipVerify bool, indexName string, lexicalWeight, vectorWeight float64, timeout time.Duration, logger core.Logger) (*OpenSearchIndexerHelper, error) {
+	if lexicalWeight < 0 || vectorWeight < 0 || lexicalWeight+vectorWeight == 0 {
+		return nil, fmt.Errorf("invalid search weights lexical=%f vector=%f, expected non-negative weights that are not both zero", lexicalWeight, vectorWeight)
+	}
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	opensearchCfg := opensearch.Config{
@@ -93,7 +142,7 @@ func InitializeOpenSearchIndexerHelper(ctx context.Context, username, password,
 		return nil, fmt.Errorf("error on creating new opensearch client: %v", err)
 	}
 
-	osiHelper := &OpenSearchIndexerHelper{client: client, indexName: indexName, timeout: timeout, logger: logger}
+	osiHelper := &OpenSearchIndexerHelper{client: client, indexName: indexName, lexicalWeight: lexicalWeight, vectorWeight: vectorWeight, timeout: timeout, logger: logger}
 	return osiHelper, nil
 }
 
@@ -127,8 +176,8 @@ func (osiHelper *OpenSearchIndexerHelper) createIndex(ctx context.Context) error
 		if err = json.Unmarshal(contents, &values); err != nil {
 			return fmt.Errorf("failed to unmarshal string: %v", err)
 		}
-		if values.Error.RootCause[0].Type == "resource_already_exists_exception" {
-			return nil
+		if len(values.Error.RootCause) > 0 && values.Error.RootCause[0].Type == "resource_already_exists_exception" {
+			return osiHelper.putMappings(ctx)
 		}
 		return fmt.Errorf("failed to create index: response=%s", createResp.String())
 	}
@@ -136,6 +185,24 @@ func (osiHelper *OpenSearchIndexerHelper) createIndex(ctx context.Context) error
 	return nil
 }
 
+// putMappings adds the fields missing from an existing index. It fails when a field was already mapped with
+// another type, e.g. "statute" dynamically mapped as text before it was a keyword, the index must be deleted
+// and the chunks indexed again then.
+func (osiHelper *OpenSearchIndexerHelper) putMappings(ctx context.Context) error {
+	req := opensearchapi.IndicesPutMappingRequest{
+		Index: []string{osiHelper.indexName},
+		Body:  strings.NewReader(indexMappings),
+	}
+	putResp, err := req.Do(ctx, osiHelper.client)
+	if err != nil {
+		return fmt.Errorf("failed to put mappings of existing index: %v, response=%s", err, putResp.String())
+	}
+	if putResp.IsError() {
+		return fmt.Errorf("failed to put mappings of existing index %s, delete it and index the chunks again: response=%s", osiHelper.indexName, putResp.String())
+	}
+	return nil
+}
+
 func (osiHelper *OpenSearchIndexerHelper) AddVectorDocument(ctx context.Context, vectorDocument core.VectorDocument) error {
 	if err := osiHelper.addToIndex(ctx, vectorDocument); err != nil {
 		return fmt.Errorf("error on adding to index: %v", err)
@@ -143,22 +210,58 @@ func (osiHelper *OpenSearchIndexerHelper) AddVectorDocument(ctx context.Context,
 	return nil
 }
 
+// FindMatchingChunkIDs fuses the BM25 matches of the text and the kNN matches of the vector with reciprocal rank fusion.
 func (osiHelper *OpenSearchIndexerHelper) FindMatchingChunkIDs(ctx context.Context, vectorDocument core.VectorDocument) ([]string, error) {
-	results, err := osiHelper.search(ctx, vectorDocument.Vector, findMatchesK)
+	scoredChunkIDs, err := osiHelper.FindScoredChunkIDs(ctx, vectorDocument)
 	if err != nil {
-		return nil, fmt.Errorf("error on search: %v", err)
+		return nil, err
 	}
-	var chunkIDs []string = make([]string, 0, len(results))
-	for _, result := range results {
-		chunkIDs = append(chunkIDs, result.ID)
+	chunkIDs := make([]string, 0, len(scoredChunkIDs))
+	for _, scoredChunkID := range scoredChunkIDs {
+		chunkIDs = append(chunkIDs, scoredChunkID.ChunkID)
 	}
 	return chunkIDs, nil
 }
 
+// FindScoredChunkIDs returns the matches of FindMatchingChunkIDs with their reciprocal rank fusion scores.
+func (osiHelper *OpenSearchIndexerHelper) FindScoredChunkIDs(ctx context.Context, vectorDocument core.VectorDocument) ([]core.ScoredChunkID, error) {
+	rankings := make([][]string, 0, 2)
+	weights := make([]float64, 0, 2)
+
+	if osiHelper.vectorWeight > 0 {
+		vectorChunkIDs, err := osiHelper.search(ctx, getVectorQuery(vectorDocument.Vector), findCandidatesK)
+		if err != nil {
+			return nil, fmt.Errorf("error on vector search: %v", err)
+		}
+		rankings = append(rankings, vectorChunkIDs)
+		weights = append(weights, osiHelper.vectorWeight)
+	}
+
+	if osiHelper.lexicalWeight > 0 && len(strings.TrimSpace(vectorDocument.Text)) > 0 {
+		lexicalChunkIDs, err := osiHelper.search(ctx, getLexicalQuery(vectorDocument.Text), findCandidatesK)
+		if err != nil {
+			return nil, fmt.Errorf("error on lexical search: %v", err)
+		}
+		rankings = append(rankings, lexicalChunkIDs)
+		weights = append(weights, osiHelper.lexicalWeight)
+	}
+
+	scores := helpers.ReciprocalRankFusionScores(rankings, weights, rankConstant)
+	chunkIDs := helpers.RankByScore(scores)
+	if len(chunkIDs) > findMatchesK {
+		chunkIDs = chunkIDs[:findMatchesK]
+	}
+	scoredChunkIDs := make([]core.ScoredChunkID, 0, len(chunkIDs))
+	for _, chunkID := range chunkIDs {
+		scoredChunkIDs = append(scoredChunkIDs, core.ScoredChunkID{ChunkID: chunkID, Score: scores[chunkID]})
+	}
+	return scoredChunkIDs, nil
+}
+
 func (osiHelper *OpenSearchIndexerHelper) addToIndex(ctx context.Context, vectorDocument core.VectorDocument) error {
 	ctx, cancel := context.WithTimeout(ctx, osiHelper.timeout)
 	defer cancel()
-	docBytes, err := json.Marshal(vectorDocument)
+	docBytes, err := json.Marshal(newIndexDocument(vectorDocument))
 	if err != nil {
 		return fmt.Errorf("failed to marshal document: %v", err)
 	}
@@ -181,28 +284,70 @@ func (osiHelper *OpenSearchIndexerHelper) addToIndex(ctx context.Context, vector
 	return nil
 }
 
-func (osiHelper *OpenSearchIndexerHelper) search(ctx context.Context, embeddings []float64, k int) ([]core.VectorDocument, error) {
-	ctx, cancel := context.WithTimeout(ctx, osiHelper.timeout)
-	defer cancel()
-	body := map[string]interface{}{
-		"size": k,
-		"query": map[string]interface{}{
-			"script_score": map[string]interface{}{
-				"query": map[string]interface{}{
-					"match_all": map[string]interface{}{},
-				},
-				"script": map[string]interface{}{
-					"source": "knn_score",
-					"lang":   "knn",
-					"params": map[string]interface{}{
-						"field":       "vector",
-						"query_value": embeddings,
-						"space_type":  "cosinesimil",
-					},
+func newIndexDocument(vectorDocument core.VectorDocument) indexDocument {
+	chapter, section, subdivision := helpers.SplitChunkID(vectorDocument.ID)
+	return indexDocument{
+		ID:          vectorDocument.ID,
+		Vector:      vectorDocument.Vector,
+		Text:        vectorDocument.Text,
+		Chapter:     chapter,
+		Section:     section,
+		Statute:     chapter + "." + section,
+		Subdivision: subdivision,
+	}
+}
+
+func getVectorQuery(embeddings []float64) map[string]interface{} {
+	return map[string]interface{}{
+		"script_score": map[string]interface{}{
+			"query": map[string]interface{}{
+				"match_all": map[string]interface{}{},
+			},
+			"script": map[string]interface{}{
+				"source": "knn_score",
+				"lang":   "knn",
+				"params": map[string]interface{}{
+					"field":       "vector",
+					"query_value": embeddings,
+					"space_type":  "cosinesimil",
 				},
 			},
 		},
 	}
+}
+
+// getLexicalQuery matches the text with BM25, boosting the chunks of any statute number mentioned in it
+func getLexicalQuery(text string) map[string]interface{} {
+	should := []interface{}{
+		map[string]interface{}{
+			"match": map[string]interface{}{
+				"text": map[string]interface{}{"query": text},
+			},
+		},
+	}
+	for _, statute := range statuteNumberRegexp.FindAllString(text, -1) {
+		should = append(should, map[string]interface{}{
+			"term": map[string]interface{}{
+				"statute": map[string]interface{}{"value": statute, "boost": 2.0},
+			},
+		})
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+func (osiHelper *OpenSearchIndexerHelper) search(ctx context.Context, query map[string]interface{}, k int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, osiHelper.timeout)
+	defer cancel()
+	body := map[string]interface{}{
+		"size":    k,
+		"_source": []string{"id"},
+		"query":   query,
+	}
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(body); err != nil {
@@ -227,14 +372,14 @@ func (osiHelper *OpenSearchIndexerHelper) search(ctx context.Context, embeddings
 		return nil, fmt.Errorf("error on decoding search response: %v", err)
 	}
 
-	results := make([]core.VectorDocument, len(searchResponse.Hits.Hits))
+	chunkIDs := make([]string, len(searchResponse.Hits.Hits))
 	for i, hit := range searchResponse.Hits.Hits {
-		var source core.VectorDocument
+		var source indexDocument
 		if err := json.Unmarshal(hit.Source, &source); err != nil {
 			return nil, fmt.Errorf("error on unmarshalling hit source: %v", err)
 		}
-		results[i] = source
+		chunkIDs[i] = source.ID
 	}
 
-	return results, nil
+	return chunkIDs, nil
 }
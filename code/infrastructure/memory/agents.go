@@ -0,0 +1,33 @@
+package memory
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"strings"
+)
+
+const noChunksAnswer = "I could not find any statutes relevant to your question."
+
+// Agent answers without a foundation model by quoting the heading line of every chunk it is given,
+// which keeps answers deterministic for offline runs. The conversation history is ignored.
+type Agent struct{}
+
+func InitializeAgent() (*Agent, error) {
+	return &Agent{}, nil
+}
+
+func (agent *Agent) AskWithChunks(ctx context.Context, prompt string, history []core.ConversationTurn, chunks []core.Chunk) (core.Answer, error) {
+	if len(chunks) == 0 {
+		return core.Answer{Text: noChunksAnswer, Citations: make([]core.Citation, 0), StopReason: core.StopReasonEndTurn}, nil
+	}
+	var builder strings.Builder
+	builder.WriteString("Relevant statutes:\n")
+	for _, chunk := range chunks {
+		heading, _, _ := strings.Cut(chunk.Body, "\n")
+		builder.WriteString(heading)
+		builder.WriteString("\n")
+	}
+	text := builder.String()
+	return core.Answer{Text: text, Citations: helpers.ParseCitations(text), StopReason: core.StopReasonEndTurn}, nil
+}
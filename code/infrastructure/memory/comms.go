@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"code/core"
+	"context"
+	"sync"
+)
+
+type SentMessage struct {
+	To   core.Recipient
+	Body string
+}
+
+// Comms records every message instead of delivering it.
+type Comms struct {
+	messages []SentMessage
+	mutex    sync.Mutex
+}
+
+func InitializeComms() (*Comms, error) {
+	return &Comms{messages: make([]SentMessage, 0)}, nil
+}
+
+func (comms *Comms) SendMessage(ctx context.Context, to core.Recipient, body string) error {
+	comms.mutex.Lock()
+	defer comms.mutex.Unlock()
+	comms.messages = append(comms.messages, SentMessage{To: to, Body: body})
+	return nil
+}
+
+// Messages returns a copy of the messages sent so far, oldest first.
+func (comms *Comms) Messages() []SentMessage {
+	comms.mutex.Lock()
+	defer comms.mutex.Unlock()
+	messages := make([]SentMessage, len(comms.messages))
+	copy(messages, comms.messages)
+	return messages
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"code/core"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+const defaultFindMatchesK = 10
+
+type SearchIndex struct {
+	vectors map[string][]float64
+	k       int
+	mutex   sync.Mutex
+}
+
+func InitializeSearchIndex(k int) (*SearchIndex, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("k must not be negative, got k=%d", k)
+	}
+	if k == 0 {
+		k = defaultFindMatchesK
+	}
+	return &SearchIndex{vectors: make(map[string][]float64), k: k}, nil
+}
+
+func (searchIndex *SearchIndex) SetupIndexIfNecessary(ctx context.Context) error {
+	return nil
+}
+
+func (searchIndex *SearchIndex) AddVectorDocument(ctx context.Context, vectorDocument core.VectorDocument) error {
+	if len(vectorDocument.ID) == 0 {
+		return fmt.Errorf("vector document is missing an id")
+	}
+	vector := make([]float64, len(vectorDocument.Vector))
+	copy(vector, vectorDocument.Vector)
+	searchIndex.mutex.Lock()
+	defer searchIndex.mutex.Unlock()
+	searchIndex.vectors[vectorDocument.ID] = vector
+	return nil
+}
+
+// FindMatchingChunkIDs ranks every indexed document by cosine similarity to the vector document and
+// returns the ids of the top k, mirroring the cosinesimil knn_score query of the opensearch indexer.
+func (searchIndex *SearchIndex) FindMatchingChunkIDs(ctx context.Context, vectorDocument core.VectorDocument) ([]string, error) {
+	scored, err := searchIndex.FindScoredChunkIDs(ctx, vectorDocument)
+	if err != nil {
+		return nil, err
+	}
+	chunkIDs := make([]string, 0, len(scored))
+	for _, s := range scored {
+		chunkIDs = append(chunkIDs, s.ChunkID)
+	}
+	return chunkIDs, nil
+}
+
+// FindScoredChunkIDs returns the top k with their cosine similarity as score.
+func (searchIndex *SearchIndex) FindScoredChunkIDs(ctx context.Context, vectorDocument core.VectorDocument) ([]core.ScoredChunkID, error) {
+	searchIndex.mutex.Lock()
+	defer searchIndex.mutex.Unlock()
+	scored := make([]core.ScoredChunkID, 0, len(searchIndex.vectors))
+	for chunkID, vector := range searchIndex.vectors {
+		score, err := cosineSimilarity(vectorDocument.Vector, vector)
+		if err != nil {
+			return nil, fmt.Errorf("error on scoring chunkID=%s: %v", chunkID, err)
+		}
+		scored = append(scored, core.ScoredChunkID{ChunkID: chunkID, Score: score})
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score == scored[j].Score {
+			return scored[i].ChunkID < scored[j].ChunkID
+		}
+		return scored[i].Score > scored[j].Score
+	})
+	if len(scored) > searchIndex.k {
+		scored = scored[:searchIndex.k]
+	}
+	return scored, nil
+}
+
+func cosineSimilarity(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("vector dimensions differ, got %d and %d", len(a), len(b))
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0, nil
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB)), nil
+}
@@ -0,0 +1,261 @@
+package memory
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	msg1            = "msg-1"
+	msg2            = "msg-2"
+	msg3            = "msg-3"
+	url1            = "https://url1.com"
+	rawPathPrefix   = "raw"
+	chunkPathPrefix = "chunk"
+	phoneNumber     = "15555550100"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	t.Run("test Queue SendMessage, ReceiveMessage, DeleteMessage", func(t *testing.T) {
+		queue, err := InitializeQueue(time.Minute)
+		assert.NoError(err, "error on initialize queue: %v", err)
+		now := time.Now()
+		queue.now = func() time.Time { return now }
+
+		err = queue.SendMessage(ctx, core.QueueMessage{Body: msg1})
+		assert.NoError(err, "error on send message: %v", err)
+		err = queue.SendURL(ctx, msg2)
+		assert.NoError(err, "error on send url: %v", err)
+
+		msg, err := queue.ReceiveMessage(ctx)
+		assert.NoError(err, "error on receive message: %v", err)
+		assert.False(msg.IsEmpty, "message should not be empty")
+		assert.Equal(msg1, msg.Body, "messages should be equal")
+		assert.NotEmpty(msg.Handle, "message should have a receipt handle")
+
+		// first message is invisible, so the second one is received
+		msg2Received, err := queue.ReceiveMessage(ctx)
+		assert.NoError(err, "error on receive message: %v", err)
+		assert.Equal(msg2, msg2Received.Body, "messages should be equal")
+
+		empty, err := queue.ReceiveMessage(ctx)
+		assert.NoError(err, "error on receive message: %v", err)
+		assert.True(empty.IsEmpty, "message should be empty while others are invisible")
+
+		// after the visibility timeout the first message is redelivered with a new handle
+		now = now.Add(time.Minute)
+		redelivered, err := queue.ReceiveMessage(ctx)
+		assert.NoError(err, "error on receive message: %v", err)
+		assert.Equal(msg1, redelivered.Body, "messages should be equal")
+		assert.NotEqual(msg.Handle, redelivered.Handle, "redelivered message should have a new handle")
+
+		err = queue.DeleteMessage(ctx, msg)
+		assert.Error(err, "stale receipt handle should not delete the message")
+		err = queue.DeleteMessage(ctx, redelivered)
+		assert.NoError(err, "error on delete message: %v", err)
+		err = queue.DeleteMessageByHandle(ctx, msg2Received.Handle)
+		assert.NoError(err, "error on delete message by handle: %v", err)
+		assert.Equal(0, queue.Len(), "queue should be empty")
+	})
+
+	t.Run("test Queue Clear", func(t *testing.T) {
+		queue, _ := InitializeQueue(0)
+		queue.SendMessage(ctx, core.QueueMessage{Body: msg1})
+		queue.SendMessage(ctx, core.QueueMessage{Body: msg2})
+		err := queue.Clear(ctx)
+		assert.NoError(err, "error on clear: %v", err)
+		msg, _ := queue.ReceiveMessage(ctx)
+		assert.True(msg.IsEmpty, "message should be empty")
+
+		queue.SendMessage(ctx, core.QueueMessage{Body: msg1, DeduplicationID: "1"})
+		queue.Clear(ctx)
+		queue.SendMessage(ctx, core.QueueMessage{Body: msg1, DeduplicationID: "1"})
+		assert.Equal(1, queue.Len(), "clear should forget deduplication IDs")
+	})
+
+	t.Run("test Queue keeps the order of message groups", func(t *testing.T) {
+		queue, _ := InitializeQueue(time.Minute)
+		queue.SendMessage(ctx, core.QueueMessage{Body: msg1, GroupID: "a"})
+		queue.SendMessage(ctx, core.QueueMessage{Body: msg2, GroupID: "a"})
+		queue.SendMessage(ctx, core.QueueMessage{Body: msg3, GroupID: "b"})
+
+		first, _ := queue.ReceiveMessage(ctx)
+		assert.Equal(msg1, first.Body, "oldest message should be received first")
+		other, _ := queue.ReceiveMessage(ctx)
+		assert.Equal(msg3, other.Body, "group should wait for its message in flight, other groups should not")
+		empty, _ := queue.ReceiveMessage(ctx)
+		assert.True(empty.IsEmpty, "message should be empty while its group is in flight")
+
+		queue.DeleteMessage(ctx, first)
+		second, _ := queue.ReceiveMessage(ctx)
+		assert.Equal(msg2, second.Body, "group should be received once its message in flight is deleted")
+	})
+
+	t.Run("test SeenURLStore Put, Has, DeleteAll", func(t *testing.T) {
+		seenURLStore, _ := InitializeSeenURLStore()
+		hasURL, _ := seenURLStore.HasURL(ctx, url1)
+		assert.False(hasURL, "url=%s should not be seen", url1)
+		seenURLStore.PutURL(ctx, url1)
+		hasURL, _ = seenURLStore.HasURL(ctx, url1)
+		assert.True(hasURL, "url=%s should be seen", url1)
+		seenURLStore.DeleteAll(ctx)
+		hasURL, _ = seenURLStore.HasURL(ctx, url1)
+		assert.False(hasURL, "url=%s should not be seen after DeleteAll", url1)
+	})
+
+	t.Run("test DataStore text files and chunks", func(t *testing.T) {
+		dataStore, err := InitializeDataStore(rawPathPrefix, chunkPathPrefix)
+		assert.NoError(err, "error on initialize data store: %v", err)
+
+		fileName := "my-file.txt"
+		err = dataStore.PutTextFile(ctx, fileName, strings.NewReader("contents"))
+		assert.NoError(err, "error on put text file: %v", err)
+		key := dataStore.GetRawObjectKey(fileName)
+		contents, err := dataStore.GetTextFile(ctx, key)
+		assert.NoError(err, "error on get text file: %v", err)
+		assert.Equal("contents", contents, "contents should be equal")
+		_, err = dataStore.GetTextFile(ctx, fileName)
+		assert.Error(err, "get text file without the raw prefix should fail")
+		err = dataStore.DeleteTextFile(ctx, fileName)
+		assert.NoError(err, "error on delete text file: %v", err)
+		_, err = dataStore.GetTextFile(ctx, key)
+		assert.Error(err, "get text file should fail after delete")
+
+		for _, chunk := range helpers.Statute2SubdivisionChunks(core.TestStatute1) {
+			err := dataStore.PutChunk(ctx, chunk)
+			assert.NoError(err, "error on put chunk: %v", err)
+			foundChunk, err := dataStore.GetChunk(ctx, chunk.ID)
+			assert.NoError(err, "error on get chunk: %v", err)
+			assert.Equal(chunk, foundChunk, "chunks should be equal")
+		}
+		assert.Equal([]string{"chunk/1a.34.1.txt", "chunk/1a.34.2a.txt"}, dataStore.Keys(chunkPathPrefix+"/"), "chunk keys should use the s3 layout")
+	})
+
+	t.Run("test SearchIndex finds nearest chunks by cosine similarity", func(t *testing.T) {
+		searchIndex, _ := InitializeSearchIndex(2)
+		searchIndex.AddVectorDocument(ctx, core.VectorDocument{ID: "a", Vector: []float64{1, 0, 0}})
+		searchIndex.AddVectorDocument(ctx, core.VectorDocument{ID: "b", Vector: []float64{0.7, 0.7, 0}})
+		searchIndex.AddVectorDocument(ctx, core.VectorDocument{ID: "c", Vector: []float64{0, 0, 1}})
+		chunkIDs, err := searchIndex.FindMatchingChunkIDs(ctx, core.VectorDocument{Vector: []float64{2, 0.1, 0}})
+		assert.NoError(err, "error on find matching chunk ids: %v", err)
+		assert.Equal([]string{"a", "b"}, chunkIDs, "chunk ids should be ordered by similarity")
+		_, err = searchIndex.FindMatchingChunkIDs(ctx, core.VectorDocument{Vector: []float64{1}})
+		assert.Error(err, "mismatched dimensions should fail")
+		scoredChunkIDs, err := searchIndex.FindScoredChunkIDs(ctx, core.VectorDocument{Vector: []float64{1, 0, 0}})
+		assert.NoError(err, "error on find scored chunk ids: %v", err)
+		if assert.Len(scoredChunkIDs, 2) {
+			assert.Equal(core.ScoredChunkID{ChunkID: "a", Score: 1}, scoredChunkIDs[0], "score should be the cosine similarity")
+			assert.InDelta(math.Sqrt(0.5), scoredChunkIDs[1].Score, 1e-9, "score should be the cosine similarity")
+		}
+	})
+
+	t.Run("test Vectorizer is deterministic and normalized", func(t *testing.T) {
+		vectorizer, _ := InitializeVectorizer(0)
+		vd1, err := vectorizer.VectorizeChunk(ctx, core.Chunk11)
+		assert.NoError(err, "error on vectorize chunk: %v", err)
+		assert.Equal(core.Chunk11.ID, vd1.ID, "vector document id should be the chunk id")
+		assert.Len(vd1.Vector, defaultDimension, "vector should have the default dimension")
+		vd2, _ := vectorizer.VectorizeChunk(ctx, core.Chunk11)
+		assert.Equal(vd1, vd2, "vectorizing twice should give the same vector")
+		similarity, _ := cosineSimilarity(vd1.Vector, vd1.Vector)
+		assert.InDelta(1.0, similarity, 1e-9, "vector should be normalized")
+		assert.Equal([]string{"see", "609.52", "subd", "1"}, tokenize("See § 609.52, subd. 1."), "statute numbers should stay single terms")
+	})
+
+	t.Run("test DedupStore forgets message IDs after the ttl", func(t *testing.T) {
+		dedupStore, _ := InitializeDedupStore()
+		now := time.Now()
+		dedupStore.now = func() time.Time { return now }
+		isPut, _ := dedupStore.PutMessageID(ctx, "01HXY")
+		assert.True(isPut, "new message ID should be put")
+		isPut, _ = dedupStore.PutMessageID(ctx, "01HXY")
+		assert.False(isPut, "message ID should only be put once")
+		dedupStore.DeleteMessageID(ctx, "01HXY")
+		isPut, _ = dedupStore.PutMessageID(ctx, "01HXY")
+		assert.True(isPut, "deleted message ID should be put again")
+		now = now.Add(core.DedupTTL)
+		isPut, _ = dedupStore.PutMessageID(ctx, "01HXY")
+		assert.True(isPut, "expired message ID should be put again")
+	})
+
+	t.Run("test RateLimiter", func(t *testing.T) {
+		rateLimiter, err := InitializeRateLimiter(core.RateLimits{Burst: 2, PerHour: 1, DailyBudget: 3})
+		assert.NoError(err, "error on initialize rate limiter: %v", err)
+		now := time.Date(2024, 6, 10, 22, 0, 0, 0, time.UTC)
+		rateLimiter.now = func() time.Time { return now }
+		allow := func(phoneNumber string) core.RateLimitDecision {
+			decision, err := rateLimiter.Allow(ctx, phoneNumber)
+			assert.NoError(err, "error on allow: %v", err)
+			return decision
+		}
+
+		assert.True(allow(phoneNumber).IsAllowed, "burst should be allowed")
+		assert.True(allow(phoneNumber).IsAllowed, "burst should be allowed")
+		assert.Equal(core.RateLimitDecision{IsFirstRejection: true}, allow(phoneNumber), "empty bucket should be rejected")
+		assert.Equal(core.RateLimitDecision{}, allow(phoneNumber), "only the first rejection should be notified")
+
+		now = now.Add(time.Hour)
+		assert.True(allow(phoneNumber).IsAllowed, "bucket should refill")
+		assert.Equal(core.RateLimitDecision{IsFirstRejection: true, IsDailyBudget: true}, allow("15555550101"), "daily budget should be shared")
+		assert.Equal(core.RateLimitDecision{IsDailyBudget: true}, allow("15555550101"), "only the first rejection should be notified")
+
+		now = now.Add(time.Hour)
+		assert.True(allow("15555550101").IsAllowed, "daily budget should reset the next UTC day")
+		assert.True(allow("15555550101").IsAllowed, "daily budget rejections should not take tokens")
+
+		_, err = InitializeRateLimiter(core.RateLimits{Burst: 0, PerHour: 1})
+		assert.Error(err, "zero burst should fail")
+	})
+
+	t.Run("test ConversationStore keeps recent turns until they expire", func(t *testing.T) {
+		conversationStore, err := InitializeConversationStore(time.Minute)
+		assert.NoError(err, "error on initialize conversation store: %v", err)
+		now := time.Now()
+		conversationStore.now = func() time.Time { return now }
+
+		turns, err := conversationStore.GetConversation(ctx, phoneNumber)
+		assert.NoError(err, "error on get conversation: %v", err)
+		assert.Empty(turns, "new conversation should be empty")
+
+		for i := 0; i < core.ConversationMaxTurns+2; i++ {
+			err = conversationStore.AddConversationTurn(ctx, phoneNumber, core.ConversationTurn{Prompt: fmt.Sprintf("prompt-%d", i), ChunkIDs: []string{"1.142.1"}})
+			assert.NoError(err, "error on add conversation turn: %v", err)
+		}
+		turns, _ = conversationStore.GetConversation(ctx, phoneNumber)
+		if assert.Len(turns, core.ConversationMaxTurns, "only the most recent turns should be kept") {
+			assert.Equal("prompt-2", turns[0].Prompt, "oldest turns should be dropped first")
+		}
+		otherTurns, _ := conversationStore.GetConversation(ctx, "15555550101")
+		assert.Empty(otherTurns, "conversations should be kept per phone number")
+
+		now = now.Add(time.Minute)
+		turns, _ = conversationStore.GetConversation(ctx, phoneNumber)
+		assert.Empty(turns, "conversation should expire after the ttl")
+
+		err = conversationStore.SetPendingParts(ctx, phoneNumber, []string{"(2/2) rest"})
+		assert.NoError(err, "error on set pending parts: %v", err)
+		pendingParts, err := conversationStore.GetPendingParts(ctx, phoneNumber)
+		assert.NoError(err, "error on get pending parts: %v", err)
+		assert.Equal([]string{"(2/2) rest"}, pendingParts, "pending parts should be kept")
+		now = now.Add(time.Minute)
+		pendingParts, _ = conversationStore.GetPendingParts(ctx, phoneNumber)
+		assert.Empty(pendingParts, "pending parts should expire with the conversation")
+
+		err = conversationStore.DeleteConversation(ctx, phoneNumber)
+		assert.NoError(err, "error on delete conversation: %v", err)
+
+		_, err = InitializeConversationStore(0)
+		assert.Error(err, "zero ttl should fail")
+	})
+}
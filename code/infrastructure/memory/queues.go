@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"code/core"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultVisibilityTimeout = 30 * time.Second
+
+// deduplicationInterval is how long a deduplication ID is remembered, as in SQS FIFO queues
+const deduplicationInterval = 5 * time.Minute
+
+var emptyQMsg = core.QueueMessage{IsEmpty: true}
+
+type Queue struct {
+	messages          []*queueMessage
+	visibilityTimeout time.Duration
+	deduplicatedUntil map[string]time.Time
+	now               func() time.Time
+	mutex             sync.Mutex
+}
+
+type queueMessage struct {
+	body           string
+	groupID        string
+	handle         string
+	invisibleUntil time.Time
+}
+
+func InitializeQueue(visibilityTimeout time.Duration) (*Queue, error) {
+	if visibilityTimeout < 0 {
+		return nil, fmt.Errorf("visibility timeout must not be negative, got visibilityTimeout=%v", visibilityTimeout)
+	}
+	if visibilityTimeout == 0 {
+		visibilityTimeout = defaultVisibilityTimeout
+	}
+	return &Queue{messages: make([]*queueMessage, 0), visibilityTimeout: visibilityTimeout, deduplicatedUntil: make(map[string]time.Time), now: time.Now}, nil
+}
+
+func (queue *Queue) SendURL(ctx context.Context, url string) error {
+	return queue.SendMessage(ctx, core.QueueMessage{Body: url})
+}
+
+func (queue *Queue) DeleteMessageByHandle(ctx context.Context, handle string) error {
+	return queue.DeleteMessage(ctx, core.QueueMessage{Handle: handle})
+}
+
+func (queue *Queue) Clear(ctx context.Context) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.messages = make([]*queueMessage, 0)
+	queue.deduplicatedUntil = make(map[string]time.Time)
+	return nil
+}
+
+func (queue *Queue) SendMessage(ctx context.Context, queueMessage core.QueueMessage) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if len(queueMessage.DeduplicationID) > 0 {
+		now := queue.now()
+		if now.Before(queue.deduplicatedUntil[queueMessage.DeduplicationID]) {
+			return nil
+		}
+		queue.deduplicatedUntil[queueMessage.DeduplicationID] = now.Add(deduplicationInterval)
+	}
+	queue.messages = append(queue.messages, newQueueMessage(queueMessage.Body, queueMessage.GroupID))
+	return nil
+}
+
+// ReceiveMessage returns the oldest visible message and hides it for the visibility timeout. Every
+// receive issues a fresh receipt handle, so a handle from an earlier receive can no longer delete it.
+// As in SQS FIFO queues, a message is not received while an older message of its group is in flight.
+func (queue *Queue) ReceiveMessage(ctx context.Context) (core.QueueMessage, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	now := queue.now()
+	inFlightGroupIDs := make(map[string]bool)
+	for _, message := range queue.messages {
+		if now.Before(message.invisibleUntil) {
+			if len(message.groupID) > 0 {
+				inFlightGroupIDs[message.groupID] = true
+			}
+			continue
+		}
+		if inFlightGroupIDs[message.groupID] {
+			continue
+		}
+		handle, err := newReceiptHandle()
+		if err != nil {
+			return emptyQMsg, fmt.Errorf("error on creating receipt handle: %v", err)
+		}
+		message.handle = handle
+		message.invisibleUntil = now.Add(queue.visibilityTimeout)
+		return core.QueueMessage{Body: message.body, Handle: handle}, nil
+	}
+	return emptyQMsg, nil
+}
+
+func (queue *Queue) DeleteMessage(ctx context.Context, queueMessage core.QueueMessage) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	for i, message := range queue.messages {
+		if len(message.handle) > 0 && message.handle == queueMessage.Handle {
+			queue.messages = append(queue.messages[:i], queue.messages[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("receipt handle is invalid, handle=%s", queueMessage.Handle)
+}
+
+// Len returns the number of messages in the queue, including messages that are not visible.
+func (queue *Queue) Len() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return len(queue.messages)
+}
+
+func newQueueMessage(body, groupID string) *queueMessage {
+	return &queueMessage{body: body, groupID: groupID}
+}
+
+func newReceiptHandle() (string, error) {
+	handle := make([]byte, 16)
+	if _, err := rand.Read(handle); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(handle), nil
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter keeps the token buckets of every phone number and the answers taken from the daily budget.
+type RateLimiter struct {
+	rateLimits core.RateLimits
+	buckets    map[string]*bucket
+	dailyUsage map[string]int
+	now        func() time.Time
+	mutex      sync.Mutex
+}
+
+type bucket struct {
+	tokens     float64
+	updatedAt  time.Time
+	isNotified bool
+}
+
+func InitializeRateLimiter(rateLimits core.RateLimits) (*RateLimiter, error) {
+	if rateLimits.Burst < 1 || rateLimits.PerHour <= 0 || rateLimits.DailyBudget < 0 {
+		return nil, fmt.Errorf("invalid rate limits=%+v", rateLimits)
+	}
+	return &RateLimiter{rateLimits: rateLimits, buckets: make(map[string]*bucket), dailyUsage: make(map[string]int), now: time.Now}, nil
+}
+
+func (rateLimiter *RateLimiter) Allow(ctx context.Context, phoneNumber string) (core.RateLimitDecision, error) {
+	rateLimiter.mutex.Lock()
+	defer rateLimiter.mutex.Unlock()
+	now := rateLimiter.now()
+	b, ok := rateLimiter.buckets[phoneNumber]
+	if !ok {
+		b = &bucket{tokens: float64(rateLimiter.rateLimits.Burst), updatedAt: now}
+		rateLimiter.buckets[phoneNumber] = b
+	}
+	var decision core.RateLimitDecision
+	// the daily budget is checked first, so a rejection by it doesn't take the phone number's token
+	day := helpers.GetBudgetDay(now)
+	isBudgeted := rateLimiter.rateLimits.DailyBudget > 0
+	if isBudgeted && rateLimiter.dailyUsage[day] >= rateLimiter.rateLimits.DailyBudget {
+		decision.IsDailyBudget = true
+	} else {
+		b.tokens, decision.IsAllowed = helpers.TakeToken(b.tokens, b.updatedAt, now, rateLimiter.rateLimits)
+		b.updatedAt = now
+		if decision.IsAllowed && isBudgeted {
+			rateLimiter.dailyUsage[day]++
+		}
+	}
+
+	if decision.IsAllowed {
+		b.isNotified = false
+	} else {
+		decision.IsFirstRejection = !b.isNotified
+		b.isNotified = true
+	}
+	return decision, nil
+}
@@ -0,0 +1,335 @@
+package memory
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var emptyChunk = core.Chunk{}
+
+type SeenURLStore struct {
+	urls  map[string]bool
+	mutex sync.Mutex
+}
+
+func InitializeSeenURLStore() (*SeenURLStore, error) {
+	return &SeenURLStore{urls: make(map[string]bool)}, nil
+}
+
+func (seenURLStore *SeenURLStore) PutURL(ctx context.Context, url string) error {
+	seenURLStore.mutex.Lock()
+	defer seenURLStore.mutex.Unlock()
+	seenURLStore.urls[url] = true
+	return nil
+}
+
+func (seenURLStore *SeenURLStore) HasURL(ctx context.Context, url string) (bool, error) {
+	seenURLStore.mutex.Lock()
+	defer seenURLStore.mutex.Unlock()
+	return seenURLStore.urls[url], nil
+}
+
+func (seenURLStore *SeenURLStore) DeleteAll(ctx context.Context) error {
+	seenURLStore.mutex.Lock()
+	defer seenURLStore.mutex.Unlock()
+	seenURLStore.urls = make(map[string]bool)
+	return nil
+}
+
+type ConversationStore struct {
+	conversations map[string]*conversation
+	ttl           time.Duration
+	now           func() time.Time
+	mutex         sync.Mutex
+}
+
+type conversation struct {
+	turns        []core.ConversationTurn
+	pendingParts []string
+	expiresAt    time.Time
+}
+
+func InitializeConversationStore(ttl time.Duration) (*ConversationStore, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("conversation ttl must be positive, got ttl=%v", ttl)
+	}
+	return &ConversationStore{conversations: make(map[string]*conversation), ttl: ttl, now: time.Now}, nil
+}
+
+func (conversationStore *ConversationStore) GetConversation(ctx context.Context, phoneNumber string) ([]core.ConversationTurn, error) {
+	conversationStore.mutex.Lock()
+	defer conversationStore.mutex.Unlock()
+	conv, ok := conversationStore.conversations[phoneNumber]
+	if !ok || !conversationStore.now().Before(conv.expiresAt) {
+		return make([]core.ConversationTurn, 0), nil
+	}
+	return append(make([]core.ConversationTurn, 0, len(conv.turns)), conv.turns...), nil
+}
+
+func (conversationStore *ConversationStore) AddConversationTurn(ctx context.Context, phoneNumber string, turn core.ConversationTurn) error {
+	conversationStore.mutex.Lock()
+	defer conversationStore.mutex.Unlock()
+	now := conversationStore.now()
+	conv := conversationStore.getOrCreateConversation(phoneNumber, now)
+	conv.turns = append(conv.turns, turn)
+	if len(conv.turns) > core.ConversationMaxTurns {
+		conv.turns = conv.turns[len(conv.turns)-core.ConversationMaxTurns:]
+	}
+	conv.expiresAt = now.Add(conversationStore.ttl)
+	return nil
+}
+
+func (conversationStore *ConversationStore) GetPendingParts(ctx context.Context, phoneNumber string) ([]string, error) {
+	conversationStore.mutex.Lock()
+	defer conversationStore.mutex.Unlock()
+	conv, ok := conversationStore.conversations[phoneNumber]
+	if !ok || !conversationStore.now().Before(conv.expiresAt) {
+		return make([]string, 0), nil
+	}
+	return append(make([]string, 0, len(conv.pendingParts)), conv.pendingParts...), nil
+}
+
+func (conversationStore *ConversationStore) SetPendingParts(ctx context.Context, phoneNumber string, parts []string) error {
+	conversationStore.mutex.Lock()
+	defer conversationStore.mutex.Unlock()
+	now := conversationStore.now()
+	conv := conversationStore.getOrCreateConversation(phoneNumber, now)
+	conv.pendingParts = append(make([]string, 0, len(parts)), parts...)
+	conv.expiresAt = now.Add(conversationStore.ttl)
+	return nil
+}
+
+func (conversationStore *ConversationStore) DeleteConversation(ctx context.Context, phoneNumber string) error {
+	conversationStore.mutex.Lock()
+	defer conversationStore.mutex.Unlock()
+	delete(conversationStore.conversations, phoneNumber)
+	return nil
+}
+
+// getOrCreateConversation starts a new conversation when there is none or it has expired
+func (conversationStore *ConversationStore) getOrCreateConversation(phoneNumber string, now time.Time) *conversation {
+	conv, ok := conversationStore.conversations[phoneNumber]
+	if !ok || !now.Before(conv.expiresAt) {
+		conv = &conversation{turns: make([]core.ConversationTurn, 0, 1)}
+		conversationStore.conversations[phoneNumber] = conv
+	}
+	return conv
+}
+
+type OptOutStore struct {
+	phoneNumbers map[string]bool
+	mutex        sync.Mutex
+}
+
+func InitializeOptOutStore() (*OptOutStore, error) {
+	return &OptOutStore{phoneNumbers: make(map[string]bool)}, nil
+}
+
+func (optOutStore *OptOutStore) IsOptedOut(ctx context.Context, phoneNumber string) (bool, error) {
+	optOutStore.mutex.Lock()
+	defer optOutStore.mutex.Unlock()
+	return optOutStore.phoneNumbers[phoneNumber], nil
+}
+
+func (optOutStore *OptOutStore) SetOptedOut(ctx context.Context, phoneNumber string, isOptedOut bool) error {
+	optOutStore.mutex.Lock()
+	defer optOutStore.mutex.Unlock()
+	if isOptedOut {
+		optOutStore.phoneNumbers[phoneNumber] = true
+	} else {
+		delete(optOutStore.phoneNumbers, phoneNumber)
+	}
+	return nil
+}
+
+// DedupStore remembers message IDs until they are core.DedupTTL old.
+type DedupStore struct {
+	expiresAt map[string]time.Time
+	now       func() time.Time
+	mutex     sync.Mutex
+}
+
+func InitializeDedupStore() (*DedupStore, error) {
+	return &DedupStore{expiresAt: make(map[string]time.Time), now: time.Now}, nil
+}
+
+func (dedupStore *DedupStore) PutMessageID(ctx context.Context, messageID string) (bool, error) {
+	dedupStore.mutex.Lock()
+	defer dedupStore.mutex.Unlock()
+	now := dedupStore.now()
+	if now.Before(dedupStore.expiresAt[messageID]) {
+		return false, nil
+	}
+	dedupStore.expiresAt[messageID] = now.Add(core.DedupTTL)
+	return true, nil
+}
+
+func (dedupStore *DedupStore) DeleteMessageID(ctx context.Context, messageID string) error {
+	dedupStore.mutex.Lock()
+	defer dedupStore.mutex.Unlock()
+	delete(dedupStore.expiresAt, messageID)
+	return nil
+}
+
+type ReferenceGraph struct {
+	references map[string][]string
+	mutex      sync.Mutex
+}
+
+func InitializeReferenceGraph() (*ReferenceGraph, error) {
+	return &ReferenceGraph{references: make(map[string][]string)}, nil
+}
+
+func (referenceGraph *ReferenceGraph) PutReferences(ctx context.Context, chunkID string, referencedChunkIDs []string) error {
+	referenceGraph.mutex.Lock()
+	defer referenceGraph.mutex.Unlock()
+	referenceGraph.references[chunkID] = append(make([]string, 0, len(referencedChunkIDs)), referencedChunkIDs...)
+	return nil
+}
+
+func (referenceGraph *ReferenceGraph) GetReferences(ctx context.Context, chunkID string) ([]string, error) {
+	referenceGraph.mutex.Lock()
+	defer referenceGraph.mutex.Unlock()
+	return append(make([]string, 0, len(referenceGraph.references[chunkID])), referenceGraph.references[chunkID]...), nil
+}
+
+// DefinitionStore keeps the definitions of each chapter by section
+type DefinitionStore struct {
+	definitions map[string]map[string][]core.Definition
+	mutex       sync.Mutex
+}
+
+func InitializeDefinitionStore() (*DefinitionStore, error) {
+	return &DefinitionStore{definitions: make(map[string]map[string][]core.Definition)}, nil
+}
+
+func (definitionStore *DefinitionStore) PutDefinitions(ctx context.Context, chapter, section string, definitions []core.Definition) error {
+	definitionStore.mutex.Lock()
+	defer definitionStore.mutex.Unlock()
+	chapterDefinitions, ok := definitionStore.definitions[chapter]
+	if !ok {
+		chapterDefinitions = make(map[string][]core.Definition)
+		definitionStore.definitions[chapter] = chapterDefinitions
+	}
+	chapterDefinitions[section] = append(make([]core.Definition, 0, len(definitions)), definitions...)
+	return nil
+}
+
+func (definitionStore *DefinitionStore) GetDefinitions(ctx context.Context, chapter string) ([]core.Definition, error) {
+	definitionStore.mutex.Lock()
+	defer definitionStore.mutex.Unlock()
+	definitions := make([]core.Definition, 0)
+	for _, sectionDefinitions := range definitionStore.definitions[chapter] {
+		definitions = append(definitions, sectionDefinitions...)
+	}
+	return definitions, nil
+}
+
+// DataStore keeps raw files and chunks under the same key layout as stores.S3Helper, so object keys
+// handed to application.ScrapeRawPage look the same as the ones found in S3 events.
+type DataStore struct {
+	objects         map[string]string
+	rawPathPrefix   string
+	chunkPathPrefix string
+	mutex           sync.Mutex
+}
+
+func InitializeDataStore(rawPathPrefix, chunkPathPrefix string) (*DataStore, error) {
+	if len(rawPathPrefix) == 0 || len(chunkPathPrefix) == 0 {
+		return nil, fmt.Errorf("rawPathPrefix or chunkPathPrefix is not specified")
+	}
+	return &DataStore{objects: make(map[string]string), rawPathPrefix: rawPathPrefix, chunkPathPrefix: chunkPathPrefix}, nil
+}
+
+func (dataStore *DataStore) GetChunk(ctx context.Context, chunkID string) (core.Chunk, error) {
+	key := dataStore.GetChunkObjectKey(chunkID)
+	body, err := dataStore.getObject(key)
+	if err != nil {
+		return emptyChunk, err
+	}
+	return core.Chunk{ID: chunkID, Body: body}, nil
+}
+
+func (dataStore *DataStore) PutChunk(ctx context.Context, chunk core.Chunk) error {
+	key := dataStore.GetChunkObjectKey(chunk.ID)
+	return dataStore.putObject(key, strings.NewReader(chunk.Body))
+}
+
+func (dataStore *DataStore) ListChunkIDs(ctx context.Context, prefix string) ([]string, error) {
+	chunkIDs := make([]string, 0)
+	for _, key := range dataStore.Keys(dataStore.chunkPathPrefix + "/" + prefix) {
+		chunkIDs = append(chunkIDs, helpers.ChunkObjectKeyToID(key))
+	}
+	return chunkIDs, nil
+}
+
+func (dataStore *DataStore) PutTextFile(ctx context.Context, fileName string, body io.Reader) error {
+	key := dataStore.GetRawObjectKey(fileName)
+	return dataStore.putObject(key, body)
+}
+
+func (dataStore *DataStore) GetTextFile(ctx context.Context, key string) (string, error) {
+	prefix := dataStore.rawPathPrefix + "/"
+	if !strings.HasPrefix(key, prefix) {
+		return "", fmt.Errorf("key doesn't have correct prefix, prefix=%s, key=%s", prefix, key)
+	}
+	return dataStore.getObject(key)
+}
+
+func (dataStore *DataStore) DeleteTextFile(ctx context.Context, fileName string) error {
+	key := dataStore.GetRawObjectKey(fileName)
+	dataStore.mutex.Lock()
+	defer dataStore.mutex.Unlock()
+	delete(dataStore.objects, key) // like s3, deleting a missing object is not an error
+	return nil
+}
+
+// Keys returns the sorted keys of all stored objects with the given prefix.
+func (dataStore *DataStore) Keys(prefix string) []string {
+	dataStore.mutex.Lock()
+	defer dataStore.mutex.Unlock()
+	keys := make([]string, 0)
+	for key := range dataStore.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (dataStore *DataStore) GetRawObjectKey(fileName string) string {
+	return dataStore.rawPathPrefix + "/" + fileName
+}
+
+func (dataStore *DataStore) GetChunkObjectKey(chunkID string) string {
+	return dataStore.chunkPathPrefix + "/" + chunkID + ".txt"
+}
+
+func (dataStore *DataStore) putObject(key string, body io.Reader) error {
+	contents, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("error on reading body (key=%s): %v", key, err)
+	}
+	dataStore.mutex.Lock()
+	defer dataStore.mutex.Unlock()
+	dataStore.objects[key] = string(contents)
+	return nil
+}
+
+func (dataStore *DataStore) getObject(key string) (string, error) {
+	dataStore.mutex.Lock()
+	defer dataStore.mutex.Unlock()
+	contents, ok := dataStore.objects[key]
+	if !ok {
+		return "", fmt.Errorf("error on getting object, key=%s: %w", key, core.ErrNotFound)
+	}
+	return contents, nil
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"code/core"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const defaultDimension = 1024
+
+var emptyVD = core.VectorDocument{}
+
+// Vectorizer embeds text by hashing its lowercased terms into a fixed number of buckets and
+// normalizing the result. It is deterministic and needs no model, so texts sharing terms are close
+// under cosine similarity.
+type Vectorizer struct {
+	dimension int
+}
+
+func InitializeVectorizer(dimension int) (*Vectorizer, error) {
+	if dimension < 0 {
+		return nil, fmt.Errorf("dimension must not be negative, got dimension=%d", dimension)
+	}
+	if dimension == 0 {
+		dimension = defaultDimension
+	}
+	return &Vectorizer{dimension: dimension}, nil
+}
+
+func (vectorizer *Vectorizer) VectorizeChunk(ctx context.Context, chunk core.Chunk) (core.VectorDocument, error) {
+	if len(strings.TrimSpace(chunk.Body)) == 0 {
+		return emptyVD, fmt.Errorf("chunk body is empty, chunkID=%s", chunk.ID)
+	}
+	return core.VectorDocument{ID: chunk.ID, Text: chunk.Body, Vector: vectorizer.embed(chunk.Body)}, nil
+}
+
+func (vectorizer *Vectorizer) Vectorize(ctx context.Context, content string) (core.VectorDocument, error) {
+	if len(strings.TrimSpace(content)) == 0 {
+		return emptyVD, fmt.Errorf("content is empty")
+	}
+	return core.VectorDocument{ID: "", Text: content, Vector: vectorizer.embed(content)}, nil
+}
+
+func (vectorizer *Vectorizer) embed(content string) []float64 {
+	vector := make([]float64, vectorizer.dimension)
+	for _, term := range tokenize(content) {
+		hash := fnv.New32a()
+		hash.Write([]byte(term))
+		vector[hash.Sum32()%uint32(vectorizer.dimension)] += 1
+	}
+	var norm float64
+	for _, value := range vector {
+		norm += value * value
+	}
+	if norm == 0 {
+		return vector
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
+
+// tokenize splits content into lowercased terms, keeping dots that sit between digits or letters so
+// statute numbers such as "609.52" stay a single term.
+func tokenize(content string) []string {
+	isTermRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.'
+	}
+	fields := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool { return !isTermRune(r) })
+	terms := make([]string, 0, len(fields))
+	for _, field := range fields {
+		term := strings.Trim(field, ".")
+		if len(term) > 0 {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
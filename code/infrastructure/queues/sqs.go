@@ -97,6 +97,13 @@ func (sqsHelper *SQSHelper) SendMessage(ctx context.Context, queueMessage core.Q
 	ctx, cancel := context.WithTimeout(ctx, sqsHelper.timeout)
 	defer cancel()
 	sendMsgInp := sqs.SendMessageInput{QueueUrl: &sqsHelper.queueURL, MessageBody: &queueMessage.Body}
+	// only FIFO queues accept deduplication and group IDs
+	if len(queueMessage.DeduplicationID) > 0 {
+		sendMsgInp.MessageDeduplicationId = aws.String(queueMessage.DeduplicationID)
+	}
+	if len(queueMessage.GroupID) > 0 {
+		sendMsgInp.MessageGroupId = aws.String(queueMessage.GroupID)
+	}
 	_, err := sqsHelper.client.SendMessage(ctx, &sendMsgInp)
 	if err != nil {
 		return fmt.Errorf("sqs send message error: %v", err)
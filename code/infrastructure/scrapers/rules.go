@@ -0,0 +1,123 @@
+package scrapers
+
+import (
+	"code/core"
+	"code/helpers"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+const mnRevisorRulesURL = "https://www.revisor.mn.gov/rules/"
+
+var ruleSubpartFormat = subdivisionFormat{
+	numberXPath:    subpartNumberRelativeToSubpartDivXPath,
+	numberPrefixes: []string{subpartPrefix, subpPrefix, subpTypoPrefix},
+	parseClauses:   helpers.ParseRuleClauses,
+}
+
+// matches links to the chapters of an agency, e.g. "/rules/agency/1", or to a chapter of the rules, e.g. "/rules/7100/"
+var ruleChapterURLRegexp = regexp.MustCompile(`^(?:(?:https?:)?//www\.revisor\.mn\.gov)?/rules/(\d{4}|agency/\d+)/?$`)
+
+// matches links to a part of the rules, e.g. "https://www.revisor.mn.gov/rules/7100.0100/"
+var rulePartURLRegexp = regexp.MustCompile(`^(?:(?:https?:)?//www\.revisor\.mn\.gov)?/rules/(\d{4}\.\d{4})/?$`)
+
+// matches links to a part, e.g. "/rules/7100.0100/", or to a subpart, e.g. "/rules/7100.0100/#rule.7100.0100.3"
+var ruleLinkRegexp = regexp.MustCompile(`^(?:(?:https?:)?//www\.revisor\.mn\.gov)?/rules/(\d{4})\.(\d{4})/?(?:#rule\.\d{4}\.\d{4}\.(\d+[A-Za-z]?))?$`)
+
+// extractRuleURLs returns the agencies and chapters linked from a rules table of chapters, or the parts linked
+// from a rules chapter.
+func (scraper *Scraper) extractRuleURLs(contents io.Reader, pageKind core.MNRevisorPageKind) ([]string, error) {
+	doc, err := htmlquery.Parse(contents)
+	if err != nil {
+		return nil, fmt.Errorf("error on parsing html: %v", err)
+	}
+	urlRegexp := ruleChapterURLRegexp
+	if pageKind == core.RulesPartsTable {
+		urlRegexp = rulePartURLRegexp
+	}
+	urls := findRuleURLs(doc, urlRegexp)
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("could not find rules links for page kind %v", pageKind)
+	}
+	return urls, nil
+}
+
+// findRuleURLs returns the absolute URLs of the links matching the regexp, in order and without duplicates.
+func findRuleURLs(doc *html.Node, urlRegexp *regexp.Regexp) []string {
+	urls := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, anchorNode := range htmlquery.Find(doc, anchorRelativeToContentXPath) {
+		match := urlRegexp.FindStringSubmatch(htmlquery.SelectAttr(anchorNode, "href"))
+		if match == nil {
+			continue
+		}
+		url := mnRevisorRulesURL + match[1] + "/"
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+		urls = append(urls, url)
+	}
+	return urls
+}
+
+// ExtractRule parses a part of the rules and its subparts. Repealed parts are returned empty.
+func (scraper *Scraper) ExtractRule(contents io.Reader) (core.Rule, error) {
+	doc, err := htmlquery.Parse(contents)
+	if err != nil {
+		return core.Rule{}, fmt.Errorf("error on parsing html: %v", err)
+	}
+	partNode := htmlquery.FindOne(doc, rulePartDivXPath)
+	if partNode == nil {
+		return core.Rule{}, errors.New("error could not find 'part' div")
+	}
+	title := htmlquery.FindOne(partNode, titleRelativeToSectionXPath)
+	if title == nil {
+		pNode := htmlquery.FindOne(partNode, paraNodeRelativeToSectionXPath)
+		if pNode != nil && strings.Contains(htmlquery.InnerText(pNode), repealedSubstring) {
+			return core.Rule{}, nil
+		}
+		return core.Rule{}, errors.New("error could not find rule title")
+	}
+	subpartDivs := htmlquery.Find(partNode, subpartDivRelativeToPartXPath)
+	var subparts []core.Subdivision
+	if subpartDivs == nil {
+		paragraphs, bodyNodes, err := extractBody(partNode)
+		if err != nil {
+			return core.Rule{}, err
+		}
+		if len(paragraphs) == 0 {
+			return core.Rule{}, errors.New("error could not find subparts")
+		}
+		subparts = []core.Subdivision{{
+			Content:    strings.Join(paragraphs, "\n"),
+			Clauses:    helpers.ParseRuleClauses(paragraphs),
+			References: extractReferences(bodyNodes...),
+		}}
+	} else {
+		subparts, err = scraper.extractSubdivisions(subpartDivs, ruleSubpartFormat)
+		if err != nil {
+			return core.Rule{}, err
+		}
+	}
+
+	titleParts := strings.SplitN(strings.TrimSpace(htmlquery.InnerText(title)), " ", 2)
+	partNumberParts := strings.SplitN(titleParts[0], ".", 2)
+	if len(titleParts) < 2 || len(partNumberParts) < 2 {
+		return core.Rule{}, fmt.Errorf("error could not parse rule title '%s'", htmlquery.InnerText(title))
+	}
+	setDefinedTerms(titleParts[1], subparts)
+	rule := core.Rule{
+		Chapter:  partNumberParts[0],
+		Part:     partNumberParts[1],
+		Title:    titleParts[1],
+		Subparts: subparts,
+	}
+	return rule, nil
+}
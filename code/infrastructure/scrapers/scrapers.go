@@ -2,9 +2,12 @@ package scrapers
 
 import (
 	"code/core"
+	"code/helpers"
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/antchfx/htmlquery"
@@ -17,11 +20,41 @@ const (
 	subdivisionPrefix               = "Subdivision "
 	subdPrefix                      = "Subd. "
 	subdTypoPrefix                  = "Subd "
+	subpartPrefix                   = "Subpart "
+	subpPrefix                      = "Subp. "
+	subpTypoPrefix                  = "Subp "
 	repealedSubstring               = "[Repealed"
+	paragraphElement                = "p"
+	tableElement                    = "table"
 )
 
 type Scraper struct{}
 
+// subdivisionFormat tells how the subdivisions of statutes, or the subparts of rules, are numbered and how their
+// clauses nest.
+type subdivisionFormat struct {
+	numberXPath    string
+	numberPrefixes []string
+	parseClauses   func([]string) []core.Clause
+}
+
+var statuteSubdivisionFormat = subdivisionFormat{
+	numberXPath:    subdivNumberRelativeToSubdivDivXPath,
+	numberPrefixes: []string{subdivisionPrefix, subdPrefix, subdTypoPrefix},
+	parseClauses:   helpers.ParseClauses,
+}
+
+// matches a session law of a history block, e.g. "1Sp1985 c 13 art 2 s 69,70" or "1963 c 753 art 1 s 609.02",
+// extra sessions are written "Ex1971"
+var sessionLawRegexp = regexp.MustCompile(`^(?:(\d+)Sp|(Ex))?(\d{4}) c (\d+[A-Za-z]?)(?: art (\d+[A-Za-z]?))?(?: s (\d+[A-Za-z]*(?:\.\d+[A-Za-z]*)?(?:\s*,\s*\d+[A-Za-z]*(?:\.\d+[A-Za-z]*)?)*))?`)
+
+// matches links to a section, e.g. "/statutes/cite/609.02", or to a subdivision, e.g.
+// "https://www.revisor.mn.gov/statutes/cite/609.02#stat.609.02.1"
+var statuteLinkRegexp = regexp.MustCompile(`^(?:(?:https?:)?//www\.revisor\.mn\.gov)?/statutes/(?:\d{4}/)?cite/(\d+[A-Za-z]?)\.(\d+[A-Za-z]*)/?(?:#stat\.\d+[A-Za-z]?\.\d+[A-Za-z]*\.(\d+[A-Za-z]?))?$`)
+
+// matches references to older compilations of the statutes, e.g. "(6-7)"
+var compilationReferenceRegexp = regexp.MustCompile(`^\s*\([^)]*\)`)
+
 func InitializeScraper() (*Scraper, error) {
 	return &Scraper{}, nil
 }
@@ -31,6 +64,10 @@ func (scraper *Scraper) GetPageKind(contents io.Reader) (core.MNRevisorPageKind,
 	if err != nil {
 		return core.MNRevisorPageKindError, fmt.Errorf("error on parsing html: %v", err)
 	}
+	// rules
+	if htmlquery.FindOne(doc, rulePartDivXPath) != nil {
+		return core.Rules, nil
+	}
 	// chapters table/subtable
 	tocHeading := htmlquery.FindOne(doc, tableOfChaptersH2XPath)
 	if tocHeading != nil {
@@ -39,9 +76,8 @@ func (scraper *Scraper) GetPageKind(contents io.Reader) (core.MNRevisorPageKind,
 			return core.StatutesChaptersTable, nil
 		} else if strings.HasPrefix(headingStr, subTableOfChaptersHeadingPrefix) { // heading == Table of Chapters, 1 - 2A
 			return core.StatutesChaptersShortTable, nil
-		} else { // did not forsee this
-			return core.MNRevisorPageKindError, errors.New("could not determine page kind from page header")
 		}
+		// other headings over tables, e.g. the agencies of the rules, are told apart below
 	}
 	// sections list
 	sectionsListHeading := htmlquery.FindOne(doc, sectionsListH2XPath)
@@ -53,6 +89,13 @@ func (scraper *Scraper) GetPageKind(contents io.Reader) (core.MNRevisorPageKind,
 	if statutesHeading != nil {
 		return core.Statutes, nil
 	}
+	// rules parts/chapters lists, told apart by what they link to
+	if len(findRuleURLs(doc, rulePartURLRegexp)) > 0 {
+		return core.RulesPartsTable, nil
+	}
+	if len(findRuleURLs(doc, ruleChapterURLRegexp)) > 0 {
+		return core.RulesChaptersTable, nil
+	}
 	return core.MNRevisorPageKindError, errors.New("cound not determine page kind")
 }
 
@@ -68,6 +111,8 @@ func (scraper *Scraper) ExtractURLs(contents io.Reader, pageKind core.MNRevisorP
 	case core.StatutesSectionsTable:
 		xpath = sectionsTableXPath
 		identifier = "chapters_analysis"
+	case core.RulesChaptersTable, core.RulesPartsTable:
+		return scraper.extractRuleURLs(contents, pageKind)
 	default:
 		return nil, errors.New("error on extracting urls")
 	}
@@ -145,18 +190,23 @@ func (scraper *Scraper) ExtractStatute(contents io.Reader) (core.Statute, error)
 	subdivisionDivs := htmlquery.Find(sectionNode, subdivDivRelativeToSectionXPath)
 	var subdivisions []core.Subdivision
 	if subdivisionDivs == nil {
-		paraNode := htmlquery.FindOne(sectionNode, paraNodeRelativeToSectionXPath)
-		if paraNode == nil {
+		paragraphs, bodyNodes, err := extractBody(sectionNode)
+		if err != nil {
+			return core.Statute{}, err
+		}
+		if len(paragraphs) == 0 {
 			return core.Statute{}, fmt.Errorf("error could not find subdivisionss")
 		}
 		var subdivision = core.Subdivision{
-			Number:  "",
-			Heading: "",
-			Content: htmlquery.InnerText(paraNode),
+			Number:     "",
+			Heading:    "",
+			Content:    strings.Join(paragraphs, "\n"),
+			Clauses:    helpers.ParseClauses(paragraphs),
+			References: extractReferences(bodyNodes...),
 		}
 		subdivisions = []core.Subdivision{subdivision}
 	} else {
-		subdivisions, err = scraper.extractSubdivisions(subdivisionDivs)
+		subdivisions, err = scraper.extractSubdivisions(subdivisionDivs, statuteSubdivisionFormat)
 		if err != nil {
 			return core.Statute{}, err
 		}
@@ -165,20 +215,68 @@ func (scraper *Scraper) ExtractStatute(contents io.Reader) (core.Statute, error)
 	titleStr := htmlquery.InnerText(title)
 	parts := strings.SplitN(titleStr, " ", 2)
 	parts2 := strings.SplitN(parts[0], ".", 2)
+	setDefinedTerms(parts[1], subdivisions)
+	var history []core.SessionLaw
+	if historyNode := htmlquery.FindOne(doc, historyParaXPath); historyNode != nil {
+		history = parseHistory(htmlquery.InnerText(historyNode))
+	}
 	statute := core.Statute{
 		Chapter:      parts2[0],
 		Section:      parts2[1],
 		Title:        parts[1],
 		Subdivisions: subdivisions,
+		History:      history,
 	}
 	return statute, nil
 }
 
-func (*Scraper) extractSubdivisions(subdivisionDivs []*html.Node) ([]core.Subdivision, error) {
+// setDefinedTerms parses the terms defined in definitions sections, e.g. 609.02, or in a definitions subdivision,
+// e.g. 609.531, subd. 1
+func setDefinedTerms(title string, subdivisions []core.Subdivision) {
+	isDefinitionsSection := helpers.IsDefinitionsHeading(title)
+	for i := range subdivisions {
+		if isDefinitionsSection || helpers.IsDefinitionsHeading(subdivisions[i].Heading) {
+			subdivisions[i].DefinedTerms = helpers.ParseDefinedTerms(subdivisions[i].Content)
+		}
+	}
+}
+
+// parseHistory parses the session laws of a history block, e.g. "(6-7) 1937 c 124 s 1; 1Sp1985 c 13 s 69;
+// 1987 c 404 s 64,65". Laws citing several sections are listed once per section, and references to older
+// compilations, like "(6-7)", are skipped.
+func parseHistory(text string) []core.SessionLaw {
+	history := make([]core.SessionLaw, 0)
+	for _, entry := range strings.Split(text, ";") {
+		entry = strings.TrimSpace(compilationReferenceRegexp.ReplaceAllString(entry, ""))
+		match := sessionLawRegexp.FindStringSubmatch(entry)
+		if match == nil {
+			continue
+		}
+		sessionLaw := core.SessionLaw{Chapter: match[4], Article: match[5]}
+		sessionLaw.Year, _ = strconv.Atoi(match[3])
+		if len(match[1]) > 0 {
+			sessionLaw.SpecialSession, _ = strconv.Atoi(match[1])
+		} else if len(match[2]) > 0 {
+			sessionLaw.SpecialSession = 1
+		}
+		if len(match[6]) == 0 {
+			history = append(history, sessionLaw)
+			continue
+		}
+		for _, section := range strings.Split(match[6], ",") {
+			sessionLaw.Section = strings.TrimSpace(section)
+			history = append(history, sessionLaw)
+		}
+	}
+	return history
+}
+
+// extractSubdivisions parses the subdivisions of a statute, or the subparts of a rule, in the given format.
+func (*Scraper) extractSubdivisions(subdivisionDivs []*html.Node, format subdivisionFormat) ([]core.Subdivision, error) {
 	var subdivisions = make([]core.Subdivision, 0)
 	for _, subd := range subdivisionDivs {
 
-		subdNoNode := htmlquery.FindOne(subd, subdivNumberRelativeToSubdivDivXPath)
+		subdNoNode := htmlquery.FindOne(subd, format.numberXPath)
 		if subdNoNode == nil {
 			return nil, fmt.Errorf("could not find subdivision headers")
 		}
@@ -188,13 +286,13 @@ func (*Scraper) extractSubdivisions(subdivisionDivs []*html.Node) ([]core.Subdiv
 		}
 		subdNoText := htmlquery.InnerText(subdNoNode)
 		var subdNumTitle string
-		if strings.HasPrefix(subdNoText, subdivisionPrefix) {
-			subdNumTitle = subdNoText[len(subdivisionPrefix):]
-		} else if strings.HasPrefix(subdNoText, subdPrefix) {
-			subdNumTitle = subdNoText[len(subdPrefix):]
-		} else if strings.HasPrefix(subdNoText, subdTypoPrefix) {
-			subdNumTitle = subdNoText[len(subdTypoPrefix):]
-		} else {
+		for _, numberPrefix := range format.numberPrefixes {
+			if strings.HasPrefix(subdNoText, numberPrefix) {
+				subdNumTitle = subdNoText[len(numberPrefix):]
+				break
+			}
+		}
+		if len(subdNumTitle) == 0 {
 			return nil, fmt.Errorf("could not determine the subdivision format")
 		}
 
@@ -209,21 +307,14 @@ func (*Scraper) extractSubdivisions(subdivisionDivs []*html.Node) ([]core.Subdiv
 			return nil, fmt.Errorf("did not correctly parse heading into headnote heading: heading='%s', headnoteHeading='%s'", heading, headnoteHeading)
 		}
 
-		contentNode := htmlquery.FindOne(subd, contentRelativeToSubdivXPath)
-		var content string
-		if contentNode == nil {
-			tableNode := htmlquery.FindOne(subd, tableRelativeToSubdivisionXPath)
-			if tableNode == nil {
-				return nil, fmt.Errorf("could not determine subdivision body")
-			}
-			var err error
-			content, err = table2csv(tableNode)
-			if err != nil {
-				return nil, fmt.Errorf("error on converting table2csv: %v", err)
-			}
-		} else {
-			content = htmlquery.InnerText(contentNode)
+		paragraphs, bodyNodes, err := extractBody(subd)
+		if err != nil {
+			return nil, err
+		}
+		if len(paragraphs) == 0 {
+			return nil, fmt.Errorf("could not determine subdivision body")
 		}
+		content := strings.Join(paragraphs, "\n")
 
 		if len(heading) == 0 {
 			subdStr := htmlquery.InnerText(subd)
@@ -231,15 +322,71 @@ func (*Scraper) extractSubdivisions(subdivisionDivs []*html.Node) ([]core.Subdiv
 		}
 
 		subdivision := core.Subdivision{
-			Number:  subdivNum,
-			Heading: heading,
-			Content: content,
+			Number:     subdivNum,
+			Heading:    heading,
+			Content:    content,
+			Clauses:    format.parseClauses(paragraphs),
+			References: extractReferences(bodyNodes...),
 		}
 		subdivisions = append(subdivisions, subdivision)
 	}
 	return subdivisions, nil
 }
 
+// extractBody returns the text of every paragraph and table of a subdivision, or of a section without
+// subdivisions, in order, along with their nodes. Tables are converted to csv.
+func extractBody(parentNode *html.Node) ([]string, []*html.Node, error) {
+	var paragraphs []string
+	var bodyNodes []*html.Node
+	for node := parentNode.FirstChild; node != nil; node = node.NextSibling {
+		if node.Type != html.ElementNode {
+			continue
+		}
+		var paragraph string
+		switch node.Data {
+		case paragraphElement:
+			paragraph = htmlquery.InnerText(node)
+		case tableElement:
+			var err error
+			paragraph, err = table2csv(node)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error on converting table2csv: %v", err)
+			}
+		default:
+			continue
+		}
+		paragraphs = append(paragraphs, paragraph)
+		bodyNodes = append(bodyNodes, node)
+	}
+	return paragraphs, bodyNodes, nil
+}
+
+// extractReferences returns the statutes and rules linked from the content, in order and without duplicates.
+// Links to whole chapters or outside the statutes and rules are skipped.
+func extractReferences(contentNodes ...*html.Node) []core.Reference {
+	var references []core.Reference
+	seen := make(map[core.Reference]bool)
+	for _, contentNode := range contentNodes {
+		for _, anchorNode := range htmlquery.Find(contentNode, anchorRelativeToContentXPath) {
+			href := htmlquery.SelectAttr(anchorNode, "href")
+			var reference core.Reference
+			if match := statuteLinkRegexp.FindStringSubmatch(href); match != nil {
+				reference = core.Reference{Chapter: match[1], Section: match[2], Subdivision: match[3]}
+			} else if match := ruleLinkRegexp.FindStringSubmatch(href); match != nil {
+				reference = core.Reference{Chapter: helpers.RuleChunkIDPrefix + match[1], Section: match[2], Subdivision: match[3]}
+			} else {
+				continue
+			}
+			if seen[reference] {
+				continue
+			}
+			seen[reference] = true
+			references = append(references, reference)
+		}
+	}
+	return references
+}
+
 func table2csv(tableNode *html.Node) (string, error) {
 	var builder strings.Builder
 	tableBody := htmlquery.FindOne(tableNode, tableBodyRelativeToTableXPath)
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/antchfx/htmlquery"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,7 +21,13 @@ const (
 	sectionWithSubSections         = "section_with_subsections.html"
 	sectionWithRepealedSubSections = "section_with_repealed_subsections.html"
 	sectionWithTables              = "section_with_tables.html"
+	sectionWithDefinitions         = "section_with_definitions.html"
+	sectionWithClauses             = "section_with_clauses.html"
 	sectionEmpty                   = "section_empty.html"
+	rulesChaptersTable             = "rules_chapters_table.html"
+	rulesPartsTable                = "rules_parts_table.html"
+	ruleWithSubparts               = "rule_with_subparts.html"
+	ruleRepealed                   = "rule_repealed.html"
 )
 
 type pageKindTest struct {
@@ -35,6 +43,10 @@ var pageKindTests []pageKindTest = []pageKindTest{
 	{testKind: core.Statutes, fileName: sectionWithSubSections},
 	{testKind: core.Statutes, fileName: sectionWithRepealedSubSections},
 	{testKind: core.Statutes, fileName: sectionWithTables},
+	{testKind: core.RulesChaptersTable, fileName: rulesChaptersTable},
+	{testKind: core.RulesPartsTable, fileName: rulesPartsTable},
+	{testKind: core.Rules, fileName: ruleWithSubparts},
+	{testKind: core.Rules, fileName: ruleRepealed},
 }
 
 type extractURLsTest struct {
@@ -46,6 +58,8 @@ var extractURLsTests = []extractURLsTest{
 	{fileName: chaptersTable, urls: chaptersTableURLs},
 	{fileName: chaptersShortTable, urls: chaptersShortTableURLs},
 	{fileName: sectionsTable, urls: sectionsTableURLs},
+	{fileName: rulesChaptersTable, urls: rulesChaptersTableURLs},
+	{fileName: rulesPartsTable, urls: rulesPartsTableURLs},
 }
 
 type extractStatuteTest struct {
@@ -58,9 +72,21 @@ var extractStatuteTests = []extractStatuteTest{
 	{fileName: sectionWithRepealedSubSections, statute: sectionWithRepealedSubSectionsStatute},
 	{fileName: sectionNoSubSections, statute: sectionWithNoSubSectionsStatute},
 	{fileName: sectionWithTables, statute: sectionWithTablesStatute},
+	{fileName: sectionWithDefinitions, statute: sectionWithDefinitionsStatute},
+	{fileName: sectionWithClauses, statute: sectionWithClausesStatute},
 	{fileName: sectionEmpty, statute: emptyStatute},
 }
 
+type extractRuleTest struct {
+	fileName string
+	rule     core.Rule
+}
+
+var extractRuleTests = []extractRuleTest{
+	{fileName: ruleWithSubparts, rule: ruleWithSubpartsRule},
+	{fileName: ruleRepealed, rule: core.Rule{}},
+}
+
 func TestScrapers(t *testing.T) {
 	scraper, err := InitializeScraper()
 	assert.NoError(t, err)
@@ -100,6 +126,49 @@ func TestScrapers(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("testing Rules", func(t *testing.T) {
+		for _, test := range extractRuleTests {
+			contents, err := readContents(test.fileName)
+			assert.NoError(t, err, "error on reading text file contents: %v", err)
+			rule, err := scraper.ExtractRule(contents)
+			if assert.NoError(t, err, "error on extracting rule: %v", err) {
+				assert.Equal(t, test.rule, rule, "rules are not equal")
+			}
+		}
+	})
+
+	t.Run("testing extract references", func(t *testing.T) {
+		contentNode, err := htmlquery.Parse(strings.NewReader(`<p>As defined in <a href="/statutes/cite/609.02#stat.609.02.6">section 609.02, subdivision 6</a>, ` +
+			`<a href="https://www.revisor.mn.gov/statutes/cite/609.02#stat.609.02.6">again</a>, under <a href="/statutes/cite/152">chapter 152</a>, ` +
+			`<a href="/statutes/cite/609.5316">section 609.5316</a> and <a href="/laws/2023/0/43/">2023 c 43</a>.</p>`))
+		assert.NoError(t, err)
+		expected := []core.Reference{
+			{Chapter: "609", Section: "02", Subdivision: "6"},
+			{Chapter: "609", Section: "5316"},
+		}
+		assert.Equal(t, expected, extractReferences(contentNode), "references are not extracted")
+	})
+
+	t.Run("testing parse history", func(t *testing.T) {
+		history := parseHistory("Ex1971 c 3 s 2; 2005 c 156 art 2 s 5a, 6; 1999 c 7; see 1999 c 8")
+		expected := []core.SessionLaw{
+			{Year: 1971, SpecialSession: 1, Chapter: "3", Section: "2"},
+			{Year: 2005, Chapter: "156", Article: "2", Section: "5a"},
+			{Year: 2005, Chapter: "156", Article: "2", Section: "6"},
+			{Year: 1999, Chapter: "7"},
+		}
+		assert.Equal(t, expected, history, "history is not parsed")
+		assert.Empty(t, parseHistory(""), "empty history has session laws")
+
+		history = parseHistory("1963 c 753 art 1 s 609.02; 1986 c 444 s 609.02, 609.0341")
+		expected = []core.SessionLaw{
+			{Year: 1963, Chapter: "753", Article: "1", Section: "609.02"},
+			{Year: 1986, Chapter: "444", Section: "609.02"},
+			{Year: 1986, Chapter: "444", Section: "609.0341"},
+		}
+		assert.Equal(t, expected, history, "sections of session laws enacting statutes should keep their statute number")
+	})
 }
 
 func readContents(fileName string) (io.Reader, error) {
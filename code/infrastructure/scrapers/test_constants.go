@@ -177,6 +177,11 @@ var sectionWithSubSectionsStatute = core.Statute{
 			Content: "A photograph of the pink and white lady slipper, obtained and approved by the commissioner of natural resources, shall be preserved in the Office of the Secretary of State.",
 		},
 	},
+	History: []core.SessionLaw{
+		{Year: 1967, Chapter: "291", Section: "1"},
+		{Year: 1969, Chapter: "1129", Article: "3", Section: "1"},
+		{Year: 1984, Chapter: "628", Article: "1", Section: "1"},
+	},
 }
 
 var sectionWithRepealedSubSectionsStatute = core.Statute{
@@ -195,6 +200,13 @@ var sectionWithRepealedSubSectionsStatute = core.Statute{
 			Content: "A department or agency may not contract with an attorney, consultant, or other person either to provide drafting services to the department or agency or to advise on drafting unless the revisor determines that special expertise is required for the drafting and the expertise is not available from the revisor or the revisor's staff. A department or agency may not request legislative staff, other than the revisor of statutes, to provide drafting services to the department or agency.",
 		},
 	},
+	History: []core.SessionLaw{
+		{Year: 1985, SpecialSession: 1, Chapter: "13", Section: "69"},
+		{Year: 1987, Chapter: "404", Section: "64"},
+		{Year: 1987, Chapter: "404", Section: "65"},
+		{Year: 1988, Chapter: "686", Article: "5", Section: "1"},
+		{Year: 1989, Chapter: "335", Article: "1", Section: "55"},
+	},
 }
 
 var sectionWithNoSubSectionsStatute = core.Statute{
@@ -208,6 +220,11 @@ var sectionWithNoSubSectionsStatute = core.Statute{
 			Content: "When requested by the commissioner of transportation the governor, in behalf of the state, may grant, bargain, sell, and convey to the United States of America any easement for flowage in and upon any easement or fee owned by the state of Minnesota for trunk highway right-of-way purposes when it is required by the United States to aid a public improvement.",
 		},
 	},
+	History: []core.SessionLaw{
+		{Year: 1937, Chapter: "124", Section: "1"},
+		{Year: 1976, Chapter: "166", Section: "7"},
+		{Year: 1984, Chapter: "628", Article: "1", Section: "1"},
+	},
 }
 
 var sectionWithTablesStatute = core.Statute{
@@ -216,9 +233,10 @@ var sectionWithTablesStatute = core.Statute{
 	Title:   "LICENSE FEES; PENALTIES.",
 	Subdivisions: []core.Subdivision{
 		{
-			Number:  "1",
-			Heading: "General.",
-			Content: "License fees, penalties for late renewal of licenses, and penalties for not obtaining a license before conducting business in food handling that are set in this section apply to the sections named except as provided under section 28A.09. Except as specified herein, bonds and assessments based on number of units operated or volume handled or processed which are provided for in said laws shall not be affected, nor shall any penalties for late payment of said assessments, nor shall inspection fees, be affected by this chapter. The penalties may be waived by the commissioner. Fees for all new licenses must be based on the anticipated future gross annual food sales. If a firm is found to be operating for multiple years without paying license fees, the state may collect the appropriate fees and penalties for each year of operation.",
+			Number:     "1",
+			Heading:    "General.",
+			Content:    "License fees, penalties for late renewal of licenses, and penalties for not obtaining a license before conducting business in food handling that are set in this section apply to the sections named except as provided under section 28A.09. Except as specified herein, bonds and assessments based on number of units operated or volume handled or processed which are provided for in said laws shall not be affected, nor shall any penalties for late payment of said assessments, nor shall inspection fees, be affected by this chapter. The penalties may be waived by the commissioner. Fees for all new licenses must be based on the anticipated future gross annual food sales. If a firm is found to be operating for multiple years without paying license fees, the state may collect the appropriate fees and penalties for each year of operation.",
+			References: []core.Reference{{Chapter: "28A", Section: "09"}},
 		},
 		{
 			Number:  "3",
@@ -231,6 +249,149 @@ var sectionWithTablesStatute = core.Statute{
 			Content: "A food handler license account is established in the agricultural fund. Fees paid under subdivision 3 must be deposited in this account. Money in the account, including interest, is appropriated to the commissioner for expenses relating to licensing and inspecting food handlers under chapters 28 to 34A or rules adopted under one of those chapters.",
 		},
 	},
+	History: []core.SessionLaw{
+		{Year: 1971, Chapter: "339", Section: "8"},
+		{Year: 1975, Chapter: "412", Section: "12"},
+		{Year: 1977, Chapter: "114", Section: "2"},
+		{Year: 1981, Chapter: "356", Section: "266"},
+		{Year: 1983, Chapter: "293", Section: "53"},
+		{Year: 1987, Chapter: "396", Article: "11", Section: "4"},
+		{Year: 1991, Chapter: "254", Article: "3", Section: "15"},
+		{Year: 1992, Chapter: "513", Article: "2", Section: "17"},
+		{Year: 1995, Chapter: "220", Section: "43"},
+		{Year: 1996, Chapter: "407", Section: "19"},
+		{Year: 1997, Chapter: "216", Section: "53"},
+		{Year: 1999, Chapter: "59", Section: "2"},
+		{Year: 1999, Chapter: "231", Section: "52"},
+		{Year: 2003, Chapter: "128", Article: "3", Section: "28"},
+		{Year: 2008, Chapter: "297", Article: "1", Section: "12"},
+		{Year: 2014, Chapter: "181", Section: "7"},
+		{Year: 2019, Chapter: "38", Section: "14"},
+		{Year: 2019, Chapter: "50", Article: "1", Section: "13"},
+		{Year: 2023, Chapter: "43", Article: "2", Section: "116"},
+	},
+}
+
+var sectionWithDefinitionsStatute = core.Statute{
+	Chapter: "609",
+	Section: "02",
+	Title:   "DEFINITIONS.",
+	Subdivisions: []core.Subdivision{
+		{
+			Number:       "1",
+			Heading:      "Crime.",
+			Content:      "\"Crime\" means conduct which is prohibited by statute and for which the actor may be sentenced to imprisonment, with or without a fine.",
+			DefinedTerms: []string{"crime"},
+		},
+		{
+			Number:       "2",
+			Heading:      "Felony.",
+			Content:      "\"Felony\" means a crime for which a sentence of imprisonment for more than one year may be imposed.",
+			DefinedTerms: []string{"felony"},
+		},
+		{
+			Number:       "3",
+			Heading:      "Misdemeanor.",
+			Content:      "\"Misdemeanor\" means a crime for which a sentence of not more than 90 days or a fine of not more than $1,000, or both, may be imposed.",
+			DefinedTerms: []string{"misdemeanor"},
+		},
+	},
+	History: []core.SessionLaw{
+		{Year: 1963, Chapter: "753", Article: "1", Section: "609.02"},
+		{Year: 1971, Chapter: "937", Section: "18"},
+		{Year: 1983, Chapter: "264", Section: "10"},
+	},
+}
+
+var sectionWithClausesStatute = core.Statute{
+	Chapter: "609",
+	Section: "5311",
+	Title:   "PROPERTY ASSOCIATED WITH CONTROLLED SUBSTANCE OFFENSES.",
+	Subdivisions: []core.Subdivision{
+		{
+			Number:  "1",
+			Heading: "Controlled substances.",
+			Content: "All controlled substances that were manufactured, distributed, dispensed, or acquired in violation of chapter 152 are subject to forfeiture under this section.",
+		},
+		{
+			Number:  "3",
+			Heading: "Limitations on forfeiture of certain property associated with controlled substances.",
+			Content: "(a) A conveyance device is subject to forfeiture under this section only if the retail value of the controlled substance is:\n" +
+				"(1) $75 or more and the conveyance device is associated with a felony-level controlled substance crime; or\n" +
+				"(2) less than $75 and the owner knew that the conveyance device was used:\n" +
+				"(i) in a controlled substance crime under section 152.021; or\n" +
+				"(ii) in a crime under section 152.022.\n" +
+				"(b) Real property is subject to forfeiture under this section only if the retail value of the controlled substance or contraband is $1,000 or more.\n" +
+				"The limitations of this subdivision do not apply to forfeitures under section 609.5318.",
+			Clauses: []core.Clause{
+				{Kind: core.ClauseKindParagraph, Label: "a", Content: "A conveyance device is subject to forfeiture under this section only if the retail value of the controlled substance is:", Clauses: []core.Clause{
+					{Kind: core.ClauseKindClause, Label: "1", Content: "$75 or more and the conveyance device is associated with a felony-level controlled substance crime; or"},
+					{Kind: core.ClauseKindClause, Label: "2", Content: "less than $75 and the owner knew that the conveyance device was used:", Clauses: []core.Clause{
+						{Kind: core.ClauseKindItem, Label: "i", Content: "in a controlled substance crime under section 152.021; or"},
+						{Kind: core.ClauseKindItem, Label: "ii", Content: "in a crime under section 152.022."},
+					}},
+				}},
+				{Kind: core.ClauseKindParagraph, Label: "b", Content: "Real property is subject to forfeiture under this section only if the retail value of the controlled substance or contraband is $1,000 or more."},
+				{Content: "The limitations of this subdivision do not apply to forfeitures under section 609.5318."},
+			},
+			References: []core.Reference{
+				{Chapter: "152", Section: "021"},
+				{Chapter: "152", Section: "022"},
+				{Chapter: "609", Section: "5318"},
+			},
+		},
+	},
+	History: []core.SessionLaw{
+		{Year: 1988, Chapter: "665", Section: "6"},
+		{Year: 2010, Chapter: "391", Section: "2"},
+	},
 }
 
 var emptyStatute = core.Statute{}
+
+var rulesChaptersTableURLs = []string{
+	"https://www.revisor.mn.gov/rules/7100/",
+	"https://www.revisor.mn.gov/rules/7105/",
+	"https://www.revisor.mn.gov/rules/agency/1/",
+}
+
+var rulesPartsTableURLs = []string{
+	"https://www.revisor.mn.gov/rules/7100.0100/",
+	"https://www.revisor.mn.gov/rules/7100.0200/",
+	"https://www.revisor.mn.gov/rules/7100.0300/",
+}
+
+var ruleWithSubpartsRule = core.Rule{
+	Chapter: "7100",
+	Part:    "0200",
+	Title:   "PERMITS.",
+	Subparts: []core.Subdivision{
+		{
+			Number:     "1",
+			Heading:    "Applications.",
+			Content:    "Applications for a permit must be made to the board as required by Minnesota Statutes, section 326A.05.",
+			References: []core.Reference{{Chapter: "326A", Section: "05"}},
+		},
+		{
+			Number:  "2",
+			Heading: "Issuance.",
+			Content: "The board shall:\n" +
+				"A. issue a permit if:\n" +
+				"(1) the fee under part 7100.0100, subpart 3, is paid; and\n" +
+				"(2) the applicant is a firm; or\n" +
+				"B. deny the application under part 7100.0300.",
+			Clauses: []core.Clause{
+				{Content: "The board shall:"},
+				{Kind: core.ClauseKindItem, Label: "A", Content: "issue a permit if:", Clauses: []core.Clause{
+					{Kind: core.ClauseKindSubitem, Label: "1", Content: "the fee under part 7100.0100, subpart 3, is paid; and"},
+					{Kind: core.ClauseKindSubitem, Label: "2", Content: "the applicant is a firm; or"},
+				}},
+				{Kind: core.ClauseKindItem, Label: "B", Content: "deny the application under part 7100.0300."},
+			},
+			References: []core.Reference{
+				{Chapter: "R7100", Section: "0100", Subdivision: "3"},
+				{Chapter: "R7100", Section: "0300"},
+			},
+		},
+	},
+}
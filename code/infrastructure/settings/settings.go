@@ -2,9 +2,11 @@ package settings
 
 import (
 	"bytes"
+	"code/core"
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,6 +17,35 @@ const relativeSettingsFilePath = "../../../settings.env"
 const defaultContextTimeout = 59 * time.Second
 const defaultEmbeddingModelID = "amazon.titan-embed-text-v2:0"
 const defaultFoundationModelID = "anthropic.claude-v2"
+const defaultOpensearchSearchWeight = 1.0
+const defaultConversationTTL = 30 * time.Minute
+const defaultSMSPartsPerReply = 3
+const defaultSinchAPIURL = "https://us.sms.api.sinch.com/xms/v1"
+const defaultSinchWebhookReplayWindow = 5 * time.Minute
+const defaultSinchConversationAPIURL = "https://us.conversation.api.sinch.com"
+const defaultSinchAuthURL = "https://auth.sinch.com/oauth2/token"
+const defaultRateLimitBurst = 5
+const defaultRateLimitPerHour = 10.0
+const defaultRateLimitDailyBudget = 1000
+const redactedValue = "[REDACTED]"
+
+// backends
+const (
+	BackendMemory                    = "memory"
+	DataStoreBackendS3               = "s3"
+	DataStoreBackendFileSystem       = "filesystem"
+	QueueBackendSQS                  = "sqs"
+	SeenURLStoreBackendDynamoDB      = "dynamodb"
+	IndexBackendOpenSearch           = "opensearch"
+	ModelBackendBedrock              = "bedrock"
+	CommsBackendSinch                = "sinch"
+	ConversationStoreBackendDynamoDB = "dynamodb"
+	OptOutStoreBackendDynamoDB       = "dynamodb"
+	RateLimiterBackendDynamoDB       = "dynamodb"
+	DedupStoreBackendDynamoDB        = "dynamodb"
+	ReferenceGraphBackendDynamoDB    = "dynamodb"
+	DefinitionStoreBackendDynamoDB   = "dynamodb"
+)
 
 type Settings struct {
 	ContextTimeout time.Duration `mapstructure:"CONTEXT_TIMEOUT"`
@@ -24,40 +55,80 @@ type Settings struct {
 	MainBucketName  string `mapstructure:"MAIN_BUCKET_NAME"`
 	ChunkPathPrefix string `mapstructure:"CHUNK_PATH_PREFIX"`
 	RawPathPrefix   string `mapstructure:"RAW_PATH_PREFIX"`
+	// backends
+	DataStoreBackend         string `mapstructure:"DATA_STORE_BACKEND"`
+	DataStoreDir             string `mapstructure:"DATA_STORE_DIR"`
+	QueueBackend             string `mapstructure:"QUEUE_BACKEND"`
+	SeenURLStoreBackend      string `mapstructure:"SEEN_URL_STORE_BACKEND"`
+	IndexBackend             string `mapstructure:"INDEX_BACKEND"`
+	ModelBackend             string `mapstructure:"MODEL_BACKEND"`
+	CommsBackend             string `mapstructure:"COMMS_BACKEND"`
+	ConversationStoreBackend string `mapstructure:"CONVERSATION_STORE_BACKEND"`
+	OptOutStoreBackend       string `mapstructure:"OPT_OUT_STORE_BACKEND"`
+	RateLimiterBackend       string `mapstructure:"RATE_LIMITER_BACKEND"`
+	DedupStoreBackend        string `mapstructure:"DEDUP_STORE_BACKEND"`
+	ReferenceGraphBackend    string `mapstructure:"REFERENCE_GRAPH_BACKEND"`
+	DefinitionStoreBackend   string `mapstructure:"DEFINITION_STORE_BACKEND"`
+	// answerer
+	CitationPolicy   string `mapstructure:"CITATION_POLICY"`
+	SMSSegment       bool   `mapstructure:"SMS_SEGMENT"`
+	SMSPartsPerReply int    `mapstructure:"SMS_PARTS_PER_REPLY"`
+	SMSSummaryFirst  bool   `mapstructure:"SMS_SUMMARY_FIRST"`
+	// rate limits
+	RateLimitBurst       int     `mapstructure:"RATE_LIMIT_BURST"`
+	RateLimitPerHour     float64 `mapstructure:"RATE_LIMIT_PER_HOUR"`
+	RateLimitDailyBudget int     `mapstructure:"RATE_LIMIT_DAILY_BUDGET"`
 	// sqs
 	URLSQSARN       string `mapstructure:"URL_SQS_ARN"`
 	RawEventsSQSARN string `mapstructure:"RAW_EVENTS_SQS_ARN"`
 	ToIndexSQSARN   string `mapstructure:"TO_INDEX_SQS_ARN"`
+	InboundSQSARN   string `mapstructure:"INBOUND_SQS_ARN"`
 	// ddb
-	Table1ARN string `mapstructure:"TABLE_1_ARN"`
+	Table1ARN       string        `mapstructure:"TABLE_1_ARN"`
+	ConversationTTL time.Duration `mapstructure:"CONVERSATION_TTL"`
 	// ecs
 	TriggerCrawlerTaskDfnArn string   `mapstructure:"TRIGGER_CRAWLER_TASK_DFN_ARN"`
 	TriggerCrawlerClusterArn string   `mapstructure:"TRIGGER_CRAWLER_CLUSTER_ARN"`
 	SubnetIds                []string `mapstructure:"PRIVATE_ISOLATED_SUBNET_IDS"`
 	SecurityGroupIds         []string `mapstructure:"SECURITY_GROUP_IDS"`
 	// bedrock
-	EmbeddingModelID  string `mapstructure:"EMBEDDING_MODEL_ID"`
-	FoundationModelID string `mapstructure:"FOUNDATION_MODEL_ID"`
+	EmbeddingModelID  string  `mapstructure:"EMBEDDING_MODEL_ID"`
+	FoundationModelID string  `mapstructure:"FOUNDATION_MODEL_ID"`
+	BedrockEndpoint   *string `mapstructure:"BEDROCK_ENDPOINT"`
 	// opensearch
-	OpensearchUsername        string `mapstructure:"OPENSEARCH_USERNAME"`
-	OpensearchPassword        string `mapstructure:"OPENSEARCH_PASSWORD"`
-	OpensearchDomain          string `mapstructure:"OPENSEARCH_DOMAIN"`
-	DoAllowOpensearchInsecure bool   `mapstructure:"DO_ALLOW_OPENSEARCH_INSECURE"`
-	OpensearchIndexName       string `mapstructure:"OPENSEARCH_INDEX_NAME"`
+	OpensearchUsername        string  `mapstructure:"OPENSEARCH_USERNAME"`
+	OpensearchPassword        string  `mapstructure:"OPENSEARCH_PASSWORD"`
+	OpensearchDomain          string  `mapstructure:"OPENSEARCH_DOMAIN"`
+	DoAllowOpensearchInsecure bool    `mapstructure:"DO_ALLOW_OPENSEARCH_INSECURE"`
+	OpensearchIndexName       string  `mapstructure:"OPENSEARCH_INDEX_NAME"`
+	OpensearchLexicalWeight   float64 `mapstructure:"OPENSEARCH_LEXICAL_WEIGHT"`
+	OpensearchVectorWeight    float64 `mapstructure:"OPENSEARCH_VECTOR_WEIGHT"`
 	// sinch
-	SinchAPIToken           string `mapstructure:"SINCH_API_TOKEN"`
-	SinchServiceID          string `mapstructure:"SINCH_SERVICE_ID"`
-	SinchVirtualPhoneNumber string `mapstructure:"SINCH_VIRTUAL_PHONE_NUMBER"`
+	SinchAPIURL              string        `mapstructure:"SINCH_API_URL"`
+	SinchAPIToken            string        `mapstructure:"SINCH_API_TOKEN"`
+	SinchServiceID           string        `mapstructure:"SINCH_SERVICE_ID"`
+	SinchVirtualPhoneNumber  string        `mapstructure:"SINCH_VIRTUAL_PHONE_NUMBER"`
+	SinchWebhookSecret       string        `mapstructure:"SINCH_WEBHOOK_SECRET"`
+	SinchWebhookReplayWindow time.Duration `mapstructure:"SINCH_WEBHOOK_REPLAY_WINDOW"`
+	// sinch conversation api, WhatsApp, Messenger and RCS are only sent over when an app id is set
+	SinchConversationAPIURL string `mapstructure:"SINCH_CONVERSATION_API_URL"`
+	SinchAuthURL            string `mapstructure:"SINCH_AUTH_URL"`
+	SinchProjectID          string `mapstructure:"SINCH_PROJECT_ID"`
+	SinchAppID              string `mapstructure:"SINCH_APP_ID"`
+	SinchAccessKeyID        string `mapstructure:"SINCH_ACCESS_KEY_ID"`
+	SinchAccessKeySecret    string `mapstructure:"SINCH_ACCESS_KEY_SECRET"`
 }
 
 const emptySettings = `
 LOCAL_ENDPOINT=
+BEDROCK_ENDPOINT=
 MAIN_BUCKET_NAME=
 CHUNK_PATH_PREFIX=
 RAW_PATH_PREFIX=
 URL_SQS_ARN=
 RAW_EVENTS_SQS_ARN=
 TO_INDEX_SQS_ARN=
+INBOUND_SQS_ARN=
 TABLE_1_ARN=
 PRIVATE_ISOLATED_SUBNET_IDS=
 SECURITY_GROUP_IDS=
@@ -67,6 +138,11 @@ OPENSEARCH_DOMAIN=
 OPENSEARCH_INDEX_NAME=
 `
 
+// GetRateLimits returns the rate limits of the answerer.
+func (settings *Settings) GetRateLimits() core.RateLimits {
+	return core.RateLimits{Burst: settings.RateLimitBurst, PerHour: settings.RateLimitPerHour, DailyBudget: settings.RateLimitDailyBudget}
+}
+
 func GetSettings() (*Settings, error) {
 	viper.SetConfigType("env")
 	viper.AutomaticEnv()
@@ -77,9 +153,41 @@ func GetSettings() (*Settings, error) {
 	viper.SetDefault("EMBEDDING_MODEL_ID", defaultEmbeddingModelID)
 	viper.SetDefault("FOUNDATION_MODEL_ID", defaultFoundationModelID)
 	viper.SetDefault("DO_ALLOW_OPENSEARCH_INSECURE", false)
+	viper.SetDefault("OPENSEARCH_LEXICAL_WEIGHT", defaultOpensearchSearchWeight)
+	viper.SetDefault("OPENSEARCH_VECTOR_WEIGHT", defaultOpensearchSearchWeight)
+	viper.SetDefault("SINCH_API_URL", defaultSinchAPIURL)
 	viper.SetDefault("SINCH_API_TOKEN", "")
 	viper.SetDefault("SINCH_SERVICE_ID", "")
 	viper.SetDefault("SINCH_VIRTUAL_PHONE_NUMBER", "")
+	viper.SetDefault("SINCH_WEBHOOK_SECRET", "")
+	viper.SetDefault("SINCH_WEBHOOK_REPLAY_WINDOW", defaultSinchWebhookReplayWindow)
+	viper.SetDefault("SINCH_CONVERSATION_API_URL", defaultSinchConversationAPIURL)
+	viper.SetDefault("SINCH_AUTH_URL", defaultSinchAuthURL)
+	viper.SetDefault("SINCH_PROJECT_ID", "")
+	viper.SetDefault("SINCH_APP_ID", "")
+	viper.SetDefault("SINCH_ACCESS_KEY_ID", "")
+	viper.SetDefault("SINCH_ACCESS_KEY_SECRET", "")
+	viper.SetDefault("DATA_STORE_BACKEND", DataStoreBackendS3)
+	viper.SetDefault("DATA_STORE_DIR", "")
+	viper.SetDefault("QUEUE_BACKEND", QueueBackendSQS)
+	viper.SetDefault("SEEN_URL_STORE_BACKEND", SeenURLStoreBackendDynamoDB)
+	viper.SetDefault("INDEX_BACKEND", IndexBackendOpenSearch)
+	viper.SetDefault("MODEL_BACKEND", ModelBackendBedrock)
+	viper.SetDefault("COMMS_BACKEND", CommsBackendSinch)
+	viper.SetDefault("CONVERSATION_STORE_BACKEND", ConversationStoreBackendDynamoDB)
+	viper.SetDefault("OPT_OUT_STORE_BACKEND", OptOutStoreBackendDynamoDB)
+	viper.SetDefault("RATE_LIMITER_BACKEND", RateLimiterBackendDynamoDB)
+	viper.SetDefault("DEDUP_STORE_BACKEND", DedupStoreBackendDynamoDB)
+	viper.SetDefault("REFERENCE_GRAPH_BACKEND", ReferenceGraphBackendDynamoDB)
+	viper.SetDefault("DEFINITION_STORE_BACKEND", DefinitionStoreBackendDynamoDB)
+	viper.SetDefault("CONVERSATION_TTL", defaultConversationTTL)
+	viper.SetDefault("CITATION_POLICY", string(core.CitationPolicyAnnotate))
+	viper.SetDefault("SMS_SEGMENT", true)
+	viper.SetDefault("SMS_PARTS_PER_REPLY", defaultSMSPartsPerReply)
+	viper.SetDefault("SMS_SUMMARY_FIRST", false)
+	viper.SetDefault("RATE_LIMIT_BURST", defaultRateLimitBurst)
+	viper.SetDefault("RATE_LIMIT_PER_HOUR", defaultRateLimitPerHour)
+	viper.SetDefault("RATE_LIMIT_DAILY_BUDGET", defaultRateLimitDailyBudget)
 
 	// load settings
 
@@ -105,12 +213,61 @@ func GetSettings() (*Settings, error) {
 
 	// sanitize settings
 
-	//// convert empty LocalEndpoint to nil
-	if settings.LocalEndpoint != nil {
-		if len(strings.TrimSpace(*settings.LocalEndpoint)) == 0 {
-			settings.LocalEndpoint = nil
+	//// convert empty LocalEndpoint and BedrockEndpoint to nil
+	for _, endpoint := range []**string{&settings.LocalEndpoint, &settings.BedrockEndpoint} {
+		if *endpoint != nil && len(strings.TrimSpace(**endpoint)) == 0 {
+			*endpoint = nil
+		}
+	}
+
+	//// validate the backends and policies
+	backends := []struct {
+		name    string
+		value   *string
+		allowed []string
+	}{
+		{name: "DATA_STORE_BACKEND", value: &settings.DataStoreBackend, allowed: []string{DataStoreBackendS3, DataStoreBackendFileSystem, BackendMemory}},
+		{name: "QUEUE_BACKEND", value: &settings.QueueBackend, allowed: []string{QueueBackendSQS, BackendMemory}},
+		{name: "SEEN_URL_STORE_BACKEND", value: &settings.SeenURLStoreBackend, allowed: []string{SeenURLStoreBackendDynamoDB, BackendMemory}},
+		{name: "INDEX_BACKEND", value: &settings.IndexBackend, allowed: []string{IndexBackendOpenSearch, BackendMemory}},
+		{name: "MODEL_BACKEND", value: &settings.ModelBackend, allowed: []string{ModelBackendBedrock, BackendMemory}},
+		{name: "COMMS_BACKEND", value: &settings.CommsBackend, allowed: []string{CommsBackendSinch, BackendMemory}},
+		{name: "CONVERSATION_STORE_BACKEND", value: &settings.ConversationStoreBackend, allowed: []string{ConversationStoreBackendDynamoDB, BackendMemory}},
+		{name: "OPT_OUT_STORE_BACKEND", value: &settings.OptOutStoreBackend, allowed: []string{OptOutStoreBackendDynamoDB, BackendMemory}},
+		{name: "RATE_LIMITER_BACKEND", value: &settings.RateLimiterBackend, allowed: []string{RateLimiterBackendDynamoDB, BackendMemory}},
+		{name: "DEDUP_STORE_BACKEND", value: &settings.DedupStoreBackend, allowed: []string{DedupStoreBackendDynamoDB, BackendMemory}},
+		{name: "REFERENCE_GRAPH_BACKEND", value: &settings.ReferenceGraphBackend, allowed: []string{ReferenceGraphBackendDynamoDB, BackendMemory}},
+		{name: "DEFINITION_STORE_BACKEND", value: &settings.DefinitionStoreBackend, allowed: []string{DefinitionStoreBackendDynamoDB, BackendMemory}},
+		{name: "CITATION_POLICY", value: &settings.CitationPolicy, allowed: []string{string(core.CitationPolicyAnnotate), string(core.CitationPolicyStrip), string(core.CitationPolicyRegenerate)}},
+	}
+	for _, backend := range backends {
+		*backend.value = strings.ToLower(strings.TrimSpace(*backend.value))
+		if !slices.Contains(backend.allowed, *backend.value) {
+			return nil, fmt.Errorf("unsupported %s=%s, expected one of %v", backend.name, *backend.value, backend.allowed)
 		}
 	}
-	log.Printf("%+v\n", settings)
+	if settings.ConversationTTL <= 0 {
+		return nil, fmt.Errorf("CONVERSATION_TTL must be positive, got %v", settings.ConversationTTL)
+	}
+	if settings.RateLimitBurst < 1 || settings.RateLimitPerHour <= 0 || settings.RateLimitDailyBudget < 0 {
+		return nil, fmt.Errorf("RATE_LIMIT_BURST and RATE_LIMIT_PER_HOUR must be positive and RATE_LIMIT_DAILY_BUDGET must not be negative, got %d, %v and %d", settings.RateLimitBurst, settings.RateLimitPerHour, settings.RateLimitDailyBudget)
+	}
+	if settings.SMSPartsPerReply < 0 {
+		return nil, fmt.Errorf("SMS_PARTS_PER_REPLY must not be negative, got %d", settings.SMSPartsPerReply)
+	}
+	if settings.DataStoreBackend == DataStoreBackendFileSystem && len(strings.TrimSpace(settings.DataStoreDir)) == 0 {
+		return nil, fmt.Errorf("DATA_STORE_DIR is required for DATA_STORE_BACKEND=%s", settings.DataStoreBackend)
+	}
+	log.Printf("%+v\n", settings.redacted())
 	return &settings, nil
 }
+
+// redacted returns a copy of the settings with the secrets hidden, for logging.
+func (settings Settings) redacted() Settings {
+	for _, secret := range []*string{&settings.OpensearchPassword, &settings.SinchAPIToken, &settings.SinchWebhookSecret, &settings.SinchAccessKeySecret} {
+		if len(*secret) > 0 {
+			*secret = redactedValue
+		}
+	}
+	return settings
+}
@@ -0,0 +1,179 @@
+package stores
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	dirPermissions  = 0o755
+	filePermissions = 0o644
+	chunkFileSuffix = ".txt"
+)
+
+// FileSystemStore stores raw pages and chunks as files below a root directory, using the same object
+// keys as S3Helper so a snapshot of the directory mirrors the bucket.
+type FileSystemStore struct {
+	rootDir         string
+	rawPathPrefix   string
+	chunkPathPrefix string
+}
+
+func InitializeFileSystemStore(rootDir, rawPathPrefix, chunkPathPrefix string) (*FileSystemStore, error) {
+	if len(rootDir) == 0 || len(rawPathPrefix) == 0 || len(chunkPathPrefix) == 0 {
+		return nil, fmt.Errorf("rootDir, rawPathPrefix, or chunkPathPrefix is not specified")
+	}
+	absRootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("error on getting absolute path for rootDir='%s': %v", rootDir, err)
+	}
+	if err := os.MkdirAll(absRootDir, dirPermissions); err != nil {
+		return nil, fmt.Errorf("error on creating rootDir='%s': %v", absRootDir, err)
+	}
+	return &FileSystemStore{rootDir: absRootDir, rawPathPrefix: rawPathPrefix, chunkPathPrefix: chunkPathPrefix}, nil
+}
+
+func (fsStore *FileSystemStore) GetChunk(ctx context.Context, chunkID string) (core.Chunk, error) {
+	key := fsStore.GetChunkObjectKey(chunkID)
+	body, err := fsStore.readFile(key)
+	if err != nil {
+		return emptyChunk, fmt.Errorf("error on reading chunk file: %w", err)
+	}
+	return core.Chunk{ID: chunkID, Body: body}, nil
+}
+
+func (fsStore *FileSystemStore) PutChunk(ctx context.Context, chunk core.Chunk) error {
+	key := fsStore.GetChunkObjectKey(chunk.ID)
+	return fsStore.writeFile(key, strings.NewReader(chunk.Body))
+}
+
+func (fsStore *FileSystemStore) ListChunkIDs(ctx context.Context, prefix string) ([]string, error) {
+	chunkDir, err := fsStore.getFilePath(fsStore.chunkPathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(chunkDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make([]string, 0), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error on reading chunk directory: %v", err)
+	}
+	chunkIDs := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, chunkFileSuffix) || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		chunkIDs = append(chunkIDs, helpers.ChunkObjectKeyToID(name))
+	}
+	return chunkIDs, nil
+}
+
+func (fsStore *FileSystemStore) PutTextFile(ctx context.Context, fileName string, body io.Reader) error {
+	key := fsStore.GetRawObjectKey(fileName)
+	return fsStore.writeFile(key, body)
+}
+
+func (fsStore *FileSystemStore) GetTextFile(ctx context.Context, key string) (string, error) {
+	if err := validateKeyPrefix(key, fsStore.rawPathPrefix); err != nil {
+		return "", err
+	}
+	return fsStore.readFile(key)
+}
+
+func (fsStore *FileSystemStore) DeleteTextFile(ctx context.Context, fileName string) error {
+	key := fsStore.GetRawObjectKey(fileName)
+	filePath, err := fsStore.getFilePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error on removing file (key=%s): %v", key, err)
+	}
+	return nil
+}
+
+func (fsStore *FileSystemStore) GetRawObjectKey(fileName string) string {
+	return fsStore.rawPathPrefix + "/" + fileName
+}
+
+func (fsStore *FileSystemStore) GetChunkObjectKey(chunkID string) string {
+	return fsStore.chunkPathPrefix + "/" + chunkID + chunkFileSuffix
+}
+
+func (fsStore *FileSystemStore) writeFile(key string, body io.Reader) error {
+	filePath, err := fsStore.getFilePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), dirPermissions); err != nil {
+		return fmt.Errorf("error on creating directory (key=%s): %v", key, err)
+	}
+	contents, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("error on reading body (key=%s): %v", key, err)
+	}
+	// write to a temporary file of its own first so readers never see a partially written file and
+	// concurrent writers of the same key don't clobber each other's temporary file
+	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error on creating temporary file (key=%s): %v", key, err)
+	}
+	tmpFilePath := tmpFile.Name()
+	defer os.Remove(tmpFilePath) // no-op once renamed
+	if _, err := tmpFile.Write(contents); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("error on writing file (key=%s): %v", key, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("error on closing file (key=%s): %v", key, err)
+	}
+	if err := os.Chmod(tmpFilePath, filePermissions); err != nil {
+		return fmt.Errorf("error on setting file permissions (key=%s): %v", key, err)
+	}
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		return fmt.Errorf("error on renaming file (key=%s): %v", key, err)
+	}
+	return nil
+}
+
+func (fsStore *FileSystemStore) readFile(key string) (string, error) {
+	filePath, err := fsStore.getFilePath(key)
+	if err != nil {
+		return "", err
+	}
+	contents, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("error on reading file (key=%s): %w", key, core.ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error on reading file (key=%s): %v", key, err)
+	}
+	return string(contents), nil
+}
+
+// getFilePath maps an object key to a path below the root directory, rejecting keys that escape it.
+func (fsStore *FileSystemStore) getFilePath(key string) (string, error) {
+	filePath := filepath.Join(fsStore.rootDir, filepath.FromSlash(key))
+	if !strings.HasPrefix(filePath, fsStore.rootDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("key is outside of the root directory, key=%s", key)
+	}
+	return filePath, nil
+}
+
+func validateKeyPrefix(key, prefix string) error {
+	prefix = prefix + "/"
+	if !strings.HasPrefix(key, prefix) {
+		return fmt.Errorf("key doesn't have correct prefix, prefix=%s, key=%s", prefix, key)
+	}
+	return nil
+}
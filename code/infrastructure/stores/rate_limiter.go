@@ -0,0 +1,276 @@
+package stores
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	pkRateLimitPrefix   = "ratelimit#"
+	skRateLimitPrefix   = "ratelimit#"
+	pkDailyBudgetPrefix = "budget#"
+	skDailyBudgetPrefix = "budget#"
+	// buckets are updated optimistically, concurrent questions from the same phone number are retried
+	maxBucketAttempts = 3
+	dailyBudgetTTL    = 48 * time.Hour
+)
+
+// RateLimiter keeps the token bucket of every phone number and the daily budget in table1.
+type RateLimiter struct {
+	table1     *Table1
+	rateLimits core.RateLimits
+	now        func() time.Time
+}
+
+// bucketRecord is the token bucket of a phone number, UpdatedAt is in unix milliseconds
+type bucketRecord struct {
+	table1RecordPrimaryKey
+	Tokens     float64 `dynamodbav:"tokens"`
+	UpdatedAt  int64   `dynamodbav:"updated_at"`
+	IsNotified bool    `dynamodbav:"is_notified"`
+	TTL        int64   `dynamodbav:"ttl"`
+}
+
+func newBucketRecordPrimaryKey(phoneNumber string) table1RecordPrimaryKey {
+	return table1RecordPrimaryKey{
+		PartitionKey: fmt.Sprintf("%s%s", pkRateLimitPrefix, phoneNumber),
+		SortKey:      fmt.Sprintf("%s%s", skRateLimitPrefix, phoneNumber),
+	}
+}
+
+func newDailyBudgetRecordPrimaryKey(day string) table1RecordPrimaryKey {
+	return table1RecordPrimaryKey{
+		PartitionKey: fmt.Sprintf("%s%s", pkDailyBudgetPrefix, day),
+		SortKey:      fmt.Sprintf("%s%s", skDailyBudgetPrefix, day),
+	}
+}
+
+func InitializeRateLimiter(table1 *Table1, rateLimits core.RateLimits) (*RateLimiter, error) {
+	if rateLimits.Burst < 1 || rateLimits.PerHour <= 0 || rateLimits.DailyBudget < 0 {
+		return nil, fmt.Errorf("invalid rate limits=%+v", rateLimits)
+	}
+	return &RateLimiter{table1: table1, rateLimits: rateLimits, now: time.Now}, nil
+}
+
+func (rateLimiter *RateLimiter) Allow(ctx context.Context, phoneNumber string) (core.RateLimitDecision, error) {
+	var decision core.RateLimitDecision
+	// the daily budget is taken first, so a rejection by it doesn't take the phone number's token, and is
+	// given back when the bucket is empty
+	isBudgeted := rateLimiter.rateLimits.DailyBudget > 0
+	var budgetDay string
+	if isBudgeted {
+		budgetDay = helpers.GetBudgetDay(rateLimiter.now())
+		isTaken, err := rateLimiter.takeFromDailyBudget(ctx, budgetDay)
+		if err != nil {
+			return decision, err
+		}
+		decision.IsDailyBudget = !isTaken
+	}
+	if !decision.IsDailyBudget {
+		isTaken, err := rateLimiter.takeFromBucket(ctx, phoneNumber)
+		if err != nil {
+			return decision, err
+		}
+		decision.IsAllowed = isTaken
+		if !isTaken && isBudgeted {
+			if err := rateLimiter.giveBackToDailyBudget(ctx, budgetDay); err != nil {
+				return decision, err
+			}
+		}
+	}
+	if !decision.IsAllowed {
+		var err error
+		decision.IsFirstRejection, err = rateLimiter.setNotified(ctx, phoneNumber)
+		if err != nil {
+			return decision, err
+		}
+	}
+	return decision, nil
+}
+
+// takeFromBucket takes a token from the phone number's bucket, the bucket is only written if it has not
+// changed since it was read.
+func (rateLimiter *RateLimiter) takeFromBucket(ctx context.Context, phoneNumber string) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		record, isFound, err := rateLimiter.getBucketRecord(ctx, phoneNumber)
+		if err != nil {
+			return false, err
+		}
+		now := rateLimiter.now()
+		previousUpdatedAt := record.UpdatedAt
+		if !isFound {
+			record = bucketRecord{Tokens: float64(rateLimiter.rateLimits.Burst), UpdatedAt: now.UnixMilli()}
+		}
+		var isTaken bool
+		record.Tokens, isTaken = helpers.TakeToken(record.Tokens, time.UnixMilli(record.UpdatedAt), now, rateLimiter.rateLimits)
+		record.UpdatedAt = now.UnixMilli()
+		if isTaken {
+			record.IsNotified = false
+		}
+		record.TTL = now.Add(helpers.GetRefillDuration(rateLimiter.rateLimits)).Unix()
+
+		err = rateLimiter.putBucketRecord(ctx, phoneNumber, record, isFound, previousUpdatedAt)
+		if err == nil {
+			return isTaken, nil
+		}
+		if !isConditionalCheckFailed(err) || attempt == maxBucketAttempts {
+			return false, err
+		}
+	}
+}
+
+func (rateLimiter *RateLimiter) getBucketRecord(ctx context.Context, phoneNumber string) (bucketRecord, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, rateLimiter.table1.timeout)
+	defer cancel()
+	keyInput, err := attributevalue.MarshalMap(newBucketRecordPrimaryKey(phoneNumber))
+	if err != nil {
+		return bucketRecord{}, false, fmt.Errorf("error on MarshalMap over bucketRecordPrimaryKey: %v", err)
+	}
+	output, err := rateLimiter.table1.client.GetItem(ctx, &dynamodb.GetItemInput{
+		Key:            keyInput,
+		TableName:      aws.String(rateLimiter.table1.tableName),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return bucketRecord{}, false, fmt.Errorf("error on GetItem of bucket: %v", err)
+	}
+	var record bucketRecord
+	if len(output.Item) == 0 {
+		return record, false, nil
+	}
+	if err := attributevalue.UnmarshalMap(output.Item, &record); err != nil {
+		return bucketRecord{}, false, fmt.Errorf("error on UnmarshalMap of bucket record: %v", err)
+	}
+	return record, true, nil
+}
+
+func (rateLimiter *RateLimiter) putBucketRecord(ctx context.Context, phoneNumber string, record bucketRecord, isFound bool, previousUpdatedAt int64) error {
+	ctx, cancel := context.WithTimeout(ctx, rateLimiter.table1.timeout)
+	defer cancel()
+	record.table1RecordPrimaryKey = newBucketRecordPrimaryKey(phoneNumber)
+	item, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return fmt.Errorf("error creating item for bucket record: %v", err)
+	}
+	input := &dynamodb.PutItemInput{
+		TableName:           &rateLimiter.table1.tableName,
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(pk)"),
+	}
+	if isFound {
+		input.ConditionExpression = aws.String("updated_at = :previousUpdatedAt")
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":previousUpdatedAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(previousUpdatedAt, 10)},
+		}
+	}
+	if _, err = rateLimiter.table1.client.PutItem(ctx, input); err != nil {
+		return fmt.Errorf("error on PutItem of bucket into ddb table: %w", err)
+	}
+	return nil
+}
+
+// takeFromDailyBudget counts an answer against the day's budget unless it is used up.
+func (rateLimiter *RateLimiter) takeFromDailyBudget(ctx context.Context, day string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, rateLimiter.table1.timeout)
+	defer cancel()
+	now := rateLimiter.now()
+	keyInput, err := attributevalue.MarshalMap(newDailyBudgetRecordPrimaryKey(day))
+	if err != nil {
+		return false, fmt.Errorf("error on MarshalMap over dailyBudgetRecordPrimaryKey: %v", err)
+	}
+	_, err = rateLimiter.table1.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(rateLimiter.table1.tableName),
+		Key:                 keyInput,
+		UpdateExpression:    aws.String("ADD answers :one SET #ttl = :ttl"),
+		ConditionExpression: aws.String("attribute_not_exists(answers) OR answers < :budget"),
+		ExpressionAttributeNames: map[string]string{
+			"#ttl": "ttl",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one":    &types.AttributeValueMemberN{Value: "1"},
+			":budget": &types.AttributeValueMemberN{Value: strconv.Itoa(rateLimiter.rateLimits.DailyBudget)},
+			":ttl":    &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Add(dailyBudgetTTL).Unix(), 10)},
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error on UpdateItem of daily budget: %v", err)
+	}
+	return true, nil
+}
+
+// giveBackToDailyBudget uncounts an answer taken from the day's budget that was rejected by the bucket.
+func (rateLimiter *RateLimiter) giveBackToDailyBudget(ctx context.Context, day string) error {
+	ctx, cancel := context.WithTimeout(ctx, rateLimiter.table1.timeout)
+	defer cancel()
+	keyInput, err := attributevalue.MarshalMap(newDailyBudgetRecordPrimaryKey(day))
+	if err != nil {
+		return fmt.Errorf("error on MarshalMap over dailyBudgetRecordPrimaryKey: %v", err)
+	}
+	_, err = rateLimiter.table1.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(rateLimiter.table1.tableName),
+		Key:                 keyInput,
+		UpdateExpression:    aws.String("ADD answers :minusOne"),
+		ConditionExpression: aws.String("answers > :zero"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":minusOne": &types.AttributeValueMemberN{Value: "-1"},
+			":zero":     &types.AttributeValueMemberN{Value: "0"},
+		},
+	})
+	if err != nil && !isConditionalCheckFailed(err) {
+		return fmt.Errorf("error on UpdateItem of daily budget: %v", err)
+	}
+	return nil
+}
+
+// setNotified flags the phone number as told about the limit, it returns false if it already was. A phone
+// number rejected by the daily budget may not have a bucket yet, it is created full.
+func (rateLimiter *RateLimiter) setNotified(ctx context.Context, phoneNumber string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, rateLimiter.table1.timeout)
+	defer cancel()
+	now := rateLimiter.now()
+	keyInput, err := attributevalue.MarshalMap(newBucketRecordPrimaryKey(phoneNumber))
+	if err != nil {
+		return false, fmt.Errorf("error on MarshalMap over bucketRecordPrimaryKey: %v", err)
+	}
+	_, err = rateLimiter.table1.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(rateLimiter.table1.tableName),
+		Key:                 keyInput,
+		UpdateExpression:    aws.String("SET is_notified = :true, tokens = if_not_exists(tokens, :burst), updated_at = if_not_exists(updated_at, :now), #ttl = if_not_exists(#ttl, :ttl)"),
+		ConditionExpression: aws.String("attribute_not_exists(pk) OR is_notified = :false"),
+		ExpressionAttributeNames: map[string]string{
+			"#ttl": "ttl",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":true":  &types.AttributeValueMemberBOOL{Value: true},
+			":false": &types.AttributeValueMemberBOOL{Value: false},
+			":burst": &types.AttributeValueMemberN{Value: strconv.Itoa(rateLimiter.rateLimits.Burst)},
+			":now":   &types.AttributeValueMemberN{Value: strconv.FormatInt(now.UnixMilli(), 10)},
+			":ttl":   &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Add(helpers.GetRefillDuration(rateLimiter.rateLimits)).Unix(), 10)},
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error on UpdateItem of bucket notification: %v", err)
+	}
+	return true, nil
+}
+
+func isConditionalCheckFailed(err error) bool {
+	var conditionalCheckFailed *types.ConditionalCheckFailedException
+	return errors.As(err, &conditionalCheckFailed)
+}
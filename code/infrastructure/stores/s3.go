@@ -4,6 +4,7 @@ import (
 	"code/core"
 	"code/helpers"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 type S3Helper struct {
@@ -46,7 +48,7 @@ func (s3Helper *S3Helper) GetChunk(ctx context.Context, chunkID string) (core.Ch
 	key := s3Helper.getChunkObjectKey(chunkID)
 	body, err := s3Helper.getObject(ctx, key)
 	if err != nil {
-		return emptyChunk, fmt.Errorf("error on s3 get object: %v", err)
+		return emptyChunk, fmt.Errorf("error on s3 get object: %w", err)
 	}
 	chunk := core.Chunk{ID: chunkID, Body: body}
 	return chunk, nil
@@ -58,6 +60,25 @@ func (s3Helper *S3Helper) PutChunk(ctx context.Context, chunk core.Chunk) error
 	return s3Helper.putFile(ctx, key, body)
 }
 
+func (s3Helper *S3Helper) ListChunkIDs(ctx context.Context, prefix string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s3Helper.timeout)
+	defer cancel()
+	keyPrefix := s3Helper.chunkPathPrefix + "/" + prefix
+	listObjectsInput := &s3.ListObjectsV2Input{Bucket: aws.String(s3Helper.bucketName), Prefix: aws.String(keyPrefix)}
+	paginator := s3.NewListObjectsV2Paginator(s3Helper.client, listObjectsInput)
+	chunkIDs := make([]string, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error on listing objects from s3 (bucketName=%s, prefix=%s): %v", s3Helper.bucketName, keyPrefix, err)
+		}
+		for _, object := range page.Contents {
+			chunkIDs = append(chunkIDs, helpers.ChunkObjectKeyToID(aws.ToString(object.Key)))
+		}
+	}
+	return chunkIDs, nil
+}
+
 func (s3Helper *S3Helper) PutTextFile(ctx context.Context, fileName string, body io.Reader) error {
 	key := s3Helper.getRawObjectKey(fileName)
 	return s3Helper.putFile(ctx, key, body)
@@ -90,6 +111,10 @@ func (s3Helper *S3Helper) getObject(ctx context.Context, key string) (string, er
 	defer cancel()
 	getObjectInput := &s3.GetObjectInput{Bucket: aws.String(s3Helper.bucketName), Key: aws.String(key)}
 	getObjectOutput, err := s3Helper.client.GetObject(ctx, getObjectInput)
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return "", fmt.Errorf("error on get object from s3 (bucketName=%s, key=%s): %w", s3Helper.bucketName, key, core.ErrNotFound)
+	}
 	if err != nil {
 		return "", fmt.Errorf("error on get object from s3 (bucketName=%s, key=%s): %v", s3Helper.bucketName, key, err)
 	}
@@ -121,9 +146,5 @@ func (s3Helper *S3Helper) getChunkObjectKey(fileName string) string {
 }
 
 func (s3Helper *S3Helper) validatePrefix(key, prefix string) error {
-	prefix = prefix + "/"
-	if !strings.HasPrefix(key, prefix) {
-		return fmt.Errorf("key doens't have correct prefix, prefix=%s, key=%s", prefix, key)
-	}
-	return nil
+	return validateKeyPrefix(key, prefix)
 }
@@ -17,7 +17,7 @@ func TestTable1(t *testing.T) {
 
 	ctx := context.Background()
 
-	table1, err := InitializeTable1(ctx, mySettings.Table1ARN, mySettings.ContextTimeout, mySettings.LocalEndpoint)
+	table1, err := InitializeTable1(ctx, mySettings.Table1ARN, mySettings.ConversationTTL, mySettings.ContextTimeout, mySettings.LocalEndpoint)
 	assert.NoError(t, err, "error on InitializeTable1: %v", err)
 
 	const url1 = "https://url1.com"
@@ -51,6 +51,83 @@ func TestTable1(t *testing.T) {
 			assert.False(t, hasURL, "url=%s in table but shouldn't be", url)
 		}
 	})
+
+	t.Run("test Get, Add conversation turns", func(t *testing.T) {
+		const phoneNumber = "15555550100"
+		turn := core.ConversationTurn{Prompt: "what is § 1a.34?", Answer: "an answer", ChunkIDs: []string{core.Chunk11.ID, core.Chunk12.ID}}
+		for i := 0; i < core.ConversationMaxTurns+1; i++ {
+			err := table1.AddConversationTurn(ctx, phoneNumber, turn)
+			assert.NoError(t, err, "error on AddConversationTurn: %v", err)
+		}
+		turns, err := table1.GetConversation(ctx, phoneNumber)
+		assert.NoError(t, err, "error on GetConversation: %v", err)
+		assert.Len(t, turns, core.ConversationMaxTurns, "only the most recent turns should be kept")
+		assert.Equal(t, turn, turns[0], "turn that was added is not equal to turn that was read")
+
+		err = table1.DeleteAll(ctx)
+		assert.NoError(t, err, "error on DeleteAll: %v", err)
+		turns, err = table1.GetConversation(ctx, phoneNumber)
+		assert.NoError(t, err, "error on GetConversation: %v", err)
+		assert.Len(t, turns, core.ConversationMaxTurns, "DeleteAll should only delete seen urls")
+
+		err = table1.SetPendingParts(ctx, phoneNumber, []string{"(2/3) second", "(3/3) third"})
+		assert.NoError(t, err, "error on SetPendingParts: %v", err)
+		err = table1.AddConversationTurn(ctx, phoneNumber, turn)
+		assert.NoError(t, err, "error on AddConversationTurn: %v", err)
+		parts, err := table1.GetPendingParts(ctx, phoneNumber)
+		assert.NoError(t, err, "error on GetPendingParts: %v", err)
+		assert.Equal(t, []string{"(2/3) second", "(3/3) third"}, parts, "adding a turn should keep the pending parts")
+		err = table1.SetPendingParts(ctx, phoneNumber, nil)
+		assert.NoError(t, err, "error on SetPendingParts: %v", err)
+		parts, _ = table1.GetPendingParts(ctx, phoneNumber)
+		assert.Empty(t, parts, "pending parts should be cleared")
+
+		err = table1.DeleteConversation(ctx, phoneNumber)
+		assert.NoError(t, err, "error on DeleteConversation: %v", err)
+		turns, _ = table1.GetConversation(ctx, phoneNumber)
+		assert.Empty(t, turns, "conversation should be deleted")
+	})
+
+	t.Run("test RateLimiter", func(t *testing.T) {
+		const phoneNumber = "15555550103"
+		rateLimiter, err := InitializeRateLimiter(table1, core.RateLimits{Burst: 1, PerHour: 1})
+		assert.NoError(t, err, "error on InitializeRateLimiter: %v", err)
+		decision, err := rateLimiter.Allow(ctx, phoneNumber)
+		assert.NoError(t, err, "error on Allow: %v", err)
+		assert.True(t, decision.IsAllowed, "first question should be allowed")
+		decision, err = rateLimiter.Allow(ctx, phoneNumber)
+		assert.NoError(t, err, "error on Allow: %v", err)
+		assert.Equal(t, core.RateLimitDecision{IsFirstRejection: true}, decision, "empty bucket should be rejected")
+		decision, _ = rateLimiter.Allow(ctx, phoneNumber)
+		assert.Equal(t, core.RateLimitDecision{}, decision, "only the first rejection should be notified")
+	})
+
+	t.Run("test IsOptedOut, SetOptedOut", func(t *testing.T) {
+		const phoneNumber = "15555550102"
+		for _, isOptedOut := range []bool{true, false} {
+			err := table1.SetOptedOut(ctx, phoneNumber, isOptedOut)
+			assert.NoError(t, err, "error on SetOptedOut: %v", err)
+			foundIsOptedOut, err := table1.IsOptedOut(ctx, phoneNumber)
+			assert.NoError(t, err, "error on IsOptedOut: %v", err)
+			assert.Equal(t, isOptedOut, foundIsOptedOut, "opt-out was not stored")
+		}
+	})
+
+	t.Run("test PutMessageID, DeleteMessageID", func(t *testing.T) {
+		const messageID = "01HZZKQ7C3V9Y2J4W6N8P0R5T1"
+		table1.DeleteMessageID(ctx, messageID)
+		isPut, err := table1.PutMessageID(ctx, messageID)
+		assert.NoError(t, err, "error on PutMessageID: %v", err)
+		assert.True(t, isPut, "new message ID should be put")
+		isPut, err = table1.PutMessageID(ctx, messageID)
+		assert.NoError(t, err, "error on PutMessageID: %v", err)
+		assert.False(t, isPut, "message ID should only be put once")
+		err = table1.DeleteMessageID(ctx, messageID)
+		assert.NoError(t, err, "error on DeleteMessageID: %v", err)
+		isPut, _ = table1.PutMessageID(ctx, messageID)
+		assert.True(t, isPut, "deleted message ID should be put again")
+		table1.DeleteMessageID(ctx, messageID)
+	})
 }
 
 func TestS3Helper(t *testing.T) {
@@ -92,6 +169,10 @@ func TestS3Helper(t *testing.T) {
 			assert.NoError(t, err, "error on put chunk: %v", err)
 		}
 
+		chunkIDs, err := s3Helper.ListChunkIDs(ctx, "1a.34.")
+		assert.NoError(t, err, "error on list chunk ids: %v", err)
+		assert.ElementsMatch(t, []string{core.Chunk11.ID, core.Chunk12.ID}, chunkIDs, "chunk ids of the section should be listed")
+
 		for _, chunk := range chunks {
 			foundChunk, err := s3Helper.GetChunk(ctx, chunk.ID)
 			assert.NoError(t, err, "error on get object: %v", err)
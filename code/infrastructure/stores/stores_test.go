@@ -0,0 +1,105 @@
+package stores
+
+import (
+	"code/core"
+	"code/helpers"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRawPathPrefix   = "raw"
+	testChunkPathPrefix = "chunk"
+)
+
+func TestFileSystemStore(t *testing.T) {
+	ctx := context.Background()
+	rootDir := t.TempDir()
+
+	fsStore, err := InitializeFileSystemStore(rootDir, testRawPathPrefix, testChunkPathPrefix)
+	assert.NoError(t, err, "error on InitializeFileSystemStore: %v", err)
+
+	t.Run("test PutTextFile, GetTextFile, DeleteTextFile", func(t *testing.T) {
+		fileName := "url=aHR0cHM6Ly93d3cucmV2aXNvci5tbi5nb3Yvc3RhdHV0ZXMv"
+		fileContents := "some test contents."
+
+		err := fsStore.PutTextFile(ctx, fileName, strings.NewReader(fileContents))
+		assert.NoError(t, err, "error on PutTextFile with fileName=%s: %v", fileName, err)
+
+		key := fsStore.GetRawObjectKey(fileName)
+		_, err = os.Stat(filepath.Join(rootDir, testRawPathPrefix, fileName))
+		assert.NoError(t, err, "raw file should be stored under the raw prefix: %v", err)
+		foundContents, err := fsStore.GetTextFile(ctx, key)
+		assert.NoError(t, err, "error on GetTextFile with key=%s: %v", key, err)
+		assert.Equal(t, fileContents, foundContents, "get text file contents are not the same.")
+
+		_, err = fsStore.GetTextFile(ctx, fileName)
+		assert.Error(t, err, "get text file without the raw prefix should fail")
+
+		err = fsStore.DeleteTextFile(ctx, fileName)
+		assert.NoError(t, err, "error on DeleteTextFile with fileName=%s: %v", fileName, err)
+		_, err = fsStore.GetTextFile(ctx, key)
+		assert.Error(t, err, "get text file was supposed to receive an error after deletion, fileName=%s", fileName)
+		err = fsStore.DeleteTextFile(ctx, fileName)
+		assert.NoError(t, err, "deleting a missing file should not fail: %v", err)
+	})
+
+	t.Run("test PutChunk, GetChunk", func(t *testing.T) {
+		chunks := helpers.Statute2SubdivisionChunks(core.TestStatute1)
+		for _, chunk := range chunks {
+			err := fsStore.PutChunk(ctx, chunk)
+			assert.NoError(t, err, "error on put chunk: %v", err)
+
+			chunkKey := fsStore.GetChunkObjectKey(chunk.ID)
+			assert.Equal(t, chunk.ID, helpers.ChunkObjectKeyToID(chunkKey), "chunk key should map back to the chunk id")
+			_, err = os.Stat(filepath.Join(rootDir, testChunkPathPrefix, chunk.ID+".txt"))
+			assert.NoError(t, err, "chunk should be stored with the .txt suffix: %v", err)
+
+			foundChunk, err := fsStore.GetChunk(ctx, chunk.ID)
+			assert.NoError(t, err, "error on get chunk: %v", err)
+			assert.Equal(t, chunk, foundChunk, "chunk that was put is not equal to chunk that was read")
+		}
+
+		chunkIDs, err := fsStore.ListChunkIDs(ctx, "1a.34.")
+		assert.NoError(t, err, "error on list chunk ids: %v", err)
+		assert.ElementsMatch(t, []string{core.Chunk11.ID, core.Chunk12.ID}, chunkIDs, "chunk ids of the section should be listed")
+		chunkIDs, err = fsStore.ListChunkIDs(ctx, "2b.")
+		assert.NoError(t, err, "error on list chunk ids: %v", err)
+		assert.Empty(t, chunkIDs, "no chunk ids should be listed for an unknown prefix")
+	})
+
+	t.Run("test concurrent writers of the same chunk", func(t *testing.T) {
+		var wg sync.WaitGroup
+		bodies := []string{"first body", "second body", "third body", "fourth body"}
+		for _, body := range bodies {
+			wg.Add(1)
+			go func(body string) {
+				defer wg.Done()
+				err := fsStore.PutChunk(ctx, core.Chunk{ID: "3c.10", Body: body})
+				assert.NoError(t, err, "error on put chunk: %v", err)
+			}(body)
+		}
+		wg.Wait()
+		foundChunk, err := fsStore.GetChunk(ctx, "3c.10")
+		assert.NoError(t, err, "error on get chunk: %v", err)
+		assert.Contains(t, bodies, foundChunk.Body, "chunk should hold one of the bodies written")
+		entries, err := os.ReadDir(filepath.Join(rootDir, testChunkPathPrefix))
+		assert.NoError(t, err, "error on reading chunk directory: %v", err)
+		for _, entry := range entries {
+			assert.False(t, strings.HasSuffix(entry.Name(), ".tmp"), "temporary files should not be left behind: %s", entry.Name())
+		}
+	})
+
+	t.Run("test keys outside of the root directory are rejected", func(t *testing.T) {
+		err := fsStore.PutTextFile(ctx, "../../escape", strings.NewReader("x"))
+		assert.Error(t, err, "put text file outside of the root directory should fail")
+		_, err = fsStore.GetTextFile(ctx, testRawPathPrefix+"/../../escape")
+		assert.Error(t, err, "get text file outside of the root directory should fail")
+	})
+}
@@ -1,10 +1,12 @@
 package stores
 
 import (
+	"code/core"
 	"context"
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,17 +19,28 @@ import (
 )
 
 const (
-	extendedRateLimit = 5 * time.Second
-	rateLimit         = time.Second
-	batchSize         = 25 // DynamoDB allows a maximum of 25 items per batch write
-	pkURLPrefix       = "url#"
-	skURLPrefix       = "url#"
+	extendedRateLimit    = 5 * time.Second
+	rateLimit            = time.Second
+	batchSize            = 25 // DynamoDB allows a maximum of 25 items per batch write
+	pkURLPrefix          = "url#"
+	skURLPrefix          = "url#"
+	pkConversationPrefix = "conversation#"
+	skConversationPrefix = "conversation#"
+	pkOptOutPrefix       = "optout#"
+	skOptOutPrefix       = "optout#"
+	pkMessagePrefix      = "message#"
+	skMessagePrefix      = "message#"
+	pkReferencePrefix    = "reference#"
+	skReferencePrefix    = "reference#"
+	pkDefinitionPrefix   = "definition#"
+	skDefinitionPrefix   = "definition#"
 )
 
 type Table1 struct {
-	client    *dynamodb.Client
-	tableName string
-	timeout   time.Duration
+	client          *dynamodb.Client
+	tableName       string
+	conversationTTL time.Duration
+	timeout         time.Duration
 }
 
 type table1Record struct {
@@ -39,6 +52,42 @@ type table1RecordPrimaryKey struct {
 	SortKey      string `dynamodbav:"sk"`
 }
 
+// conversationRecord holds the recent turns with a phone number, ttl is the epoch second at which
+// DynamoDB may delete the record
+type conversationRecord struct {
+	table1RecordPrimaryKey
+	Turns        []conversationTurnRecord `dynamodbav:"turns"`
+	PendingParts []string                 `dynamodbav:"pending_parts"`
+	TTL          int64                    `dynamodbav:"ttl"`
+}
+
+// messageRecord marks an inbound message as handled until its ttl
+type messageRecord struct {
+	table1RecordPrimaryKey
+	TTL int64 `dynamodbav:"ttl"`
+}
+
+// referenceRecord holds the chunk IDs a chunk references
+type referenceRecord struct {
+	table1RecordPrimaryKey
+	ChunkIDs []string `dynamodbav:"chunk_ids"`
+}
+
+// definitionRecord holds the chunk ID of the subdivision defining a term, the definitions of a chapter share
+// its partition key and the ones of a section the prefix of their sort key
+type definitionRecord struct {
+	table1RecordPrimaryKey
+	Term            string `dynamodbav:"term"`
+	ChunkID         string `dynamodbav:"chunk_id"`
+	IsSectionScoped bool   `dynamodbav:"is_section_scoped"`
+}
+
+type conversationTurnRecord struct {
+	Prompt   string   `dynamodbav:"prompt"`
+	Answer   string   `dynamodbav:"answer"`
+	ChunkIDs []string `dynamodbav:"chunk_ids"`
+}
+
 func newURLRecord(url string) table1Record {
 	recPk := newURLRecordPrimaryKey(url)
 	return table1Record{
@@ -53,7 +102,42 @@ func newURLRecordPrimaryKey(url string) table1RecordPrimaryKey {
 	}
 }
 
-func InitializeTable1(ctx context.Context, tableARN string, timeout time.Duration, endpointURL *string) (*Table1, error) {
+func newConversationRecordPrimaryKey(phoneNumber string) table1RecordPrimaryKey {
+	return table1RecordPrimaryKey{
+		PartitionKey: fmt.Sprintf("%s%s", pkConversationPrefix, phoneNumber),
+		SortKey:      fmt.Sprintf("%s%s", skConversationPrefix, phoneNumber),
+	}
+}
+
+func newOptOutRecordPrimaryKey(phoneNumber string) table1RecordPrimaryKey {
+	return table1RecordPrimaryKey{
+		PartitionKey: fmt.Sprintf("%s%s", pkOptOutPrefix, phoneNumber),
+		SortKey:      fmt.Sprintf("%s%s", skOptOutPrefix, phoneNumber),
+	}
+}
+
+func newMessageRecordPrimaryKey(messageID string) table1RecordPrimaryKey {
+	return table1RecordPrimaryKey{
+		PartitionKey: fmt.Sprintf("%s%s", pkMessagePrefix, messageID),
+		SortKey:      fmt.Sprintf("%s%s", skMessagePrefix, messageID),
+	}
+}
+
+func newReferenceRecordPrimaryKey(chunkID string) table1RecordPrimaryKey {
+	return table1RecordPrimaryKey{
+		PartitionKey: fmt.Sprintf("%s%s", pkReferencePrefix, chunkID),
+		SortKey:      fmt.Sprintf("%s%s", skReferencePrefix, chunkID),
+	}
+}
+
+func newDefinitionRecordPrimaryKey(chapter, section, term string) table1RecordPrimaryKey {
+	return table1RecordPrimaryKey{
+		PartitionKey: fmt.Sprintf("%s%s", pkDefinitionPrefix, chapter),
+		SortKey:      fmt.Sprintf("%s%s#%s", skDefinitionPrefix, section, term),
+	}
+}
+
+func InitializeTable1(ctx context.Context, tableARN string, conversationTTL, timeout time.Duration, endpointURL *string) (*Table1, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	cfg, err := config.LoadDefaultConfig(ctx)
@@ -69,7 +153,7 @@ func InitializeTable1(ctx context.Context, tableARN string, timeout time.Duratio
 	if err != nil {
 		return nil, fmt.Errorf("error getting table-name for table-arn='%s': %v", tableARN, err)
 	}
-	return &Table1{client: client, tableName: tableName, timeout: timeout}, nil
+	return &Table1{client: client, tableName: tableName, conversationTTL: conversationTTL, timeout: timeout}, nil
 }
 
 func getTableName(ctx context.Context, client *dynamodb.Client, tableArn string, timeout time.Duration) (string, error) {
@@ -134,9 +218,326 @@ func (table1 *Table1) HasURL(ctx context.Context, url string) (bool, error) {
 	return hasUrl, nil
 }
 
+func (table1 *Table1) GetConversation(ctx context.Context, phoneNumber string) ([]core.ConversationTurn, error) {
+	record, err := table1.getConversationRecord(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	turns := make([]core.ConversationTurn, 0, len(record.Turns))
+	for _, turn := range record.Turns {
+		turns = append(turns, core.ConversationTurn{Prompt: turn.Prompt, Answer: turn.Answer, ChunkIDs: turn.ChunkIDs})
+	}
+	return turns, nil
+}
+
+func (table1 *Table1) AddConversationTurn(ctx context.Context, phoneNumber string, turn core.ConversationTurn) error {
+	record, err := table1.getConversationRecord(ctx, phoneNumber)
+	if err != nil {
+		return err
+	}
+	record.Turns = append(record.Turns, conversationTurnRecord{Prompt: turn.Prompt, Answer: turn.Answer, ChunkIDs: turn.ChunkIDs})
+	if len(record.Turns) > core.ConversationMaxTurns {
+		record.Turns = record.Turns[len(record.Turns)-core.ConversationMaxTurns:]
+	}
+	return table1.putConversationRecord(ctx, phoneNumber, record)
+}
+
+func (table1 *Table1) GetPendingParts(ctx context.Context, phoneNumber string) ([]string, error) {
+	record, err := table1.getConversationRecord(ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	return append(make([]string, 0, len(record.PendingParts)), record.PendingParts...), nil
+}
+
+func (table1 *Table1) SetPendingParts(ctx context.Context, phoneNumber string, parts []string) error {
+	record, err := table1.getConversationRecord(ctx, phoneNumber)
+	if err != nil {
+		return err
+	}
+	record.PendingParts = parts
+	return table1.putConversationRecord(ctx, phoneNumber, record)
+}
+
+func (table1 *Table1) DeleteConversation(ctx context.Context, phoneNumber string) error {
+	return table1.deleteRecord(ctx, newConversationRecordPrimaryKey(phoneNumber))
+}
+
+// putConversationRecord stores the record and extends its ttl
+func (table1 *Table1) putConversationRecord(ctx context.Context, phoneNumber string, record conversationRecord) error {
+	record.table1RecordPrimaryKey = newConversationRecordPrimaryKey(phoneNumber)
+	record.TTL = time.Now().Add(table1.conversationTTL).Unix()
+
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	item, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return fmt.Errorf("error creating item for conversation record: %v", err)
+	}
+	_, err = table1.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &table1.tableName,
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("error on PutItem of conversation into ddb table: %v", err)
+	}
+	return nil
+}
+
+func (table1 *Table1) IsOptedOut(ctx context.Context, phoneNumber string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	keyInput, err := attributevalue.MarshalMap(newOptOutRecordPrimaryKey(phoneNumber))
+	if err != nil {
+		return false, fmt.Errorf("error on MarshalMap over optOutRecordPrimaryKey: %v", err)
+	}
+	output, err := table1.client.GetItem(ctx, &dynamodb.GetItemInput{
+		Key:       keyInput,
+		TableName: aws.String(table1.tableName),
+	})
+	if err != nil {
+		return false, fmt.Errorf("error on GetItem of opt-out: %v", err)
+	}
+	return len(output.Item) > 0, nil
+}
+
+// SetOptedOut stores a record for opted out phone numbers and deletes it when they opt back in.
+func (table1 *Table1) SetOptedOut(ctx context.Context, phoneNumber string, isOptedOut bool) error {
+	if !isOptedOut {
+		return table1.deleteRecord(ctx, newOptOutRecordPrimaryKey(phoneNumber))
+	}
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	item, err := attributevalue.MarshalMap(table1Record{newOptOutRecordPrimaryKey(phoneNumber)})
+	if err != nil {
+		return fmt.Errorf("error creating item for opt-out record: %v", err)
+	}
+	_, err = table1.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &table1.tableName,
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("error on PutItem of opt-out into ddb table: %v", err)
+	}
+	return nil
+}
+
+func (table1 *Table1) PutReferences(ctx context.Context, chunkID string, referencedChunkIDs []string) error {
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	item, err := attributevalue.MarshalMap(referenceRecord{newReferenceRecordPrimaryKey(chunkID), referencedChunkIDs})
+	if err != nil {
+		return fmt.Errorf("error creating item for reference record: %v", err)
+	}
+	_, err = table1.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &table1.tableName,
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("error on PutItem of references into ddb table: %v", err)
+	}
+	return nil
+}
+
+// GetReferences returns no chunk IDs for chunks without a record.
+func (table1 *Table1) GetReferences(ctx context.Context, chunkID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	keyInput, err := attributevalue.MarshalMap(newReferenceRecordPrimaryKey(chunkID))
+	if err != nil {
+		return nil, fmt.Errorf("error on MarshalMap over referenceRecordPrimaryKey: %v", err)
+	}
+	output, err := table1.client.GetItem(ctx, &dynamodb.GetItemInput{
+		Key:       keyInput,
+		TableName: aws.String(table1.tableName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error on GetItem of references: %v", err)
+	}
+	var record referenceRecord
+	if err := attributevalue.UnmarshalMap(output.Item, &record); err != nil {
+		return nil, fmt.Errorf("error on UnmarshalMap of reference record: %v", err)
+	}
+	if record.ChunkIDs == nil {
+		return make([]string, 0), nil
+	}
+	return record.ChunkIDs, nil
+}
+
+func (table1 *Table1) PutDefinitions(ctx context.Context, chapter, section string, definitions []core.Definition) error {
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	storedRecords, err := table1.queryDefinitionRecords(ctx, chapter, skDefinitionPrefix+section+"#")
+	if err != nil {
+		return err
+	}
+	isDefined := make(map[string]bool)
+	writeRequests := make([]types.WriteRequest, 0, batchSize)
+	for _, definition := range definitions {
+		isDefined[definition.Term] = true
+		item, err := attributevalue.MarshalMap(definitionRecord{newDefinitionRecordPrimaryKey(chapter, section, definition.Term), definition.Term, definition.ChunkID, definition.IsSectionScoped})
+		if err != nil {
+			return fmt.Errorf("error creating item for definition record: %v", err)
+		}
+		writeRequests = append(writeRequests, types.WriteRequest{PutRequest: &types.PutRequest{Item: item}})
+		if len(writeRequests) == batchSize {
+			if err := table1.handleBatchWriteWithRetries(ctx, writeRequests); err != nil {
+				return fmt.Errorf("error during batch write of definitions: %v", err)
+			}
+			writeRequests = make([]types.WriteRequest, 0, batchSize)
+		}
+	}
+	// delete the definitions the section no longer has
+	for _, storedRecord := range storedRecords {
+		if isDefined[storedRecord.Term] {
+			continue
+		}
+		key, err := attributevalue.MarshalMap(storedRecord.table1RecordPrimaryKey)
+		if err != nil {
+			return fmt.Errorf("error creating key for definition record: %v", err)
+		}
+		writeRequests = append(writeRequests, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}})
+		if len(writeRequests) == batchSize {
+			if err := table1.handleBatchWriteWithRetries(ctx, writeRequests); err != nil {
+				return fmt.Errorf("error during batch write of definitions: %v", err)
+			}
+			writeRequests = make([]types.WriteRequest, 0, batchSize)
+		}
+	}
+	if len(writeRequests) > 0 {
+		if err := table1.handleBatchWriteWithRetries(ctx, writeRequests); err != nil {
+			return fmt.Errorf("error during final batch write of definitions: %v", err)
+		}
+	}
+	return nil
+}
+
+func (table1 *Table1) GetDefinitions(ctx context.Context, chapter string) ([]core.Definition, error) {
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	records, err := table1.queryDefinitionRecords(ctx, chapter, skDefinitionPrefix)
+	if err != nil {
+		return nil, err
+	}
+	definitions := make([]core.Definition, 0, len(records))
+	for _, record := range records {
+		definitions = append(definitions, core.Definition{Term: record.Term, ChunkID: record.ChunkID, IsSectionScoped: record.IsSectionScoped})
+	}
+	return definitions, nil
+}
+
+// queryDefinitionRecords returns the definition records of the chapter whose sort key starts with the prefix.
+func (table1 *Table1) queryDefinitionRecords(ctx context.Context, chapter, skPrefix string) ([]definitionRecord, error) {
+	queryPaginator := dynamodb.NewQueryPaginator(table1.client, &dynamodb.QueryInput{
+		TableName:              &table1.tableName,
+		KeyConditionExpression: aws.String("pk = :pk AND begins_with(sk, :sk)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: pkDefinitionPrefix + chapter},
+			":sk": &types.AttributeValueMemberS{Value: skPrefix},
+		},
+	})
+	definitionRecords := make([]definitionRecord, 0)
+	for queryPaginator.HasMorePages() {
+		queryPage, err := queryPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching next query page of definitions: %v", err)
+		}
+		var records []definitionRecord
+		if err := attributevalue.UnmarshalListOfMaps(queryPage.Items, &records); err != nil {
+			return nil, fmt.Errorf("error on UnmarshalListOfMaps of definition records: %v", err)
+		}
+		definitionRecords = append(definitionRecords, records...)
+	}
+	return definitionRecords, nil
+}
+
+// PutMessageID stores a record for the message ID unless one exists, records past their ttl that DynamoDB
+// has not deleted yet are overwritten.
+func (table1 *Table1) PutMessageID(ctx context.Context, messageID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	now := time.Now()
+	record := messageRecord{newMessageRecordPrimaryKey(messageID), now.Add(core.DedupTTL).Unix()}
+	item, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return false, fmt.Errorf("error creating item for message record: %v", err)
+	}
+	_, err = table1.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           &table1.tableName,
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(pk) OR #ttl <= :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#ttl": "ttl",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Unix(), 10)},
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error on PutItem of message into ddb table: %v", err)
+	}
+	return true, nil
+}
+
+func (table1 *Table1) DeleteMessageID(ctx context.Context, messageID string) error {
+	return table1.deleteRecord(ctx, newMessageRecordPrimaryKey(messageID))
+}
+
+func (table1 *Table1) deleteRecord(ctx context.Context, primaryKey table1RecordPrimaryKey) error {
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	keyInput, err := attributevalue.MarshalMap(primaryKey)
+	if err != nil {
+		return fmt.Errorf("error on MarshalMap over primaryKey: %v", err)
+	}
+	_, err = table1.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		Key:       keyInput,
+		TableName: aws.String(table1.tableName),
+	})
+	if err != nil {
+		return fmt.Errorf("error on DeleteItem with pk=%s: %v", primaryKey.PartitionKey, err)
+	}
+	return nil
+}
+
+// getConversationRecord returns an empty record when the conversation does not exist or has expired,
+// DynamoDB deletes expired records lazily so the ttl is checked here as well
+func (table1 *Table1) getConversationRecord(ctx context.Context, phoneNumber string) (conversationRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, table1.timeout)
+	defer cancel()
+	keyInput, err := attributevalue.MarshalMap(newConversationRecordPrimaryKey(phoneNumber))
+	if err != nil {
+		return conversationRecord{}, fmt.Errorf("error on MarshalMap over conversationRecordPrimaryKey: %v", err)
+	}
+	output, err := table1.client.GetItem(ctx, &dynamodb.GetItemInput{
+		Key:       keyInput,
+		TableName: aws.String(table1.tableName),
+	})
+	if err != nil {
+		return conversationRecord{}, fmt.Errorf("error on GetItem of conversation: %v", err)
+	}
+	var record conversationRecord
+	if len(output.Item) == 0 {
+		return record, nil
+	}
+	if err := attributevalue.UnmarshalMap(output.Item, &record); err != nil {
+		return conversationRecord{}, fmt.Errorf("error on UnmarshalMap of conversation record: %v", err)
+	}
+	if record.TTL <= time.Now().Unix() {
+		return conversationRecord{}, nil
+	}
+	return record, nil
+}
+
+// DeleteAll deletes all seen urls, other records sharing the table are kept.
 func (table1 *Table1) DeleteAll(ctx context.Context) error {
 	scanPaginator := dynamodb.NewScanPaginator(table1.client, &dynamodb.ScanInput{
-		TableName: &table1.tableName,
+		TableName:                 &table1.tableName,
+		FilterExpression:          aws.String("begins_with(pk, :pkPrefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{":pkPrefix": &types.AttributeValueMemberS{Value: pkURLPrefix}},
 	})
 
 	var writeRequests []types.WriteRequest = make([]types.WriteRequest, 0, batchSize)
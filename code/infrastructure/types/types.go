@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type S3EventMessage struct {
 	Detail S3Detail `json:"detail"`
 }
@@ -13,12 +15,16 @@ type S3Object struct {
 }
 
 type SinchWebhookPayload struct {
-	Message SinchMessage `json:"message"`
+	AcceptedTime time.Time    `json:"accepted_time"`
+	EventTime    time.Time    `json:"event_time"`
+	Message      SinchMessage `json:"message"`
 }
 
 type SinchMessage struct {
+	ID              string               `json:"id"`
 	ContactMessage  SinchContactMessage  `json:"contact_message"`
 	ChannelIdentity SinchChannelIdentity `json:"channel_identity"`
+	AcceptTime      time.Time            `json:"accept_time"`
 }
 
 type SinchContactMessage struct {
@@ -30,5 +36,56 @@ type SinchTextMessage struct {
 }
 
 type SinchChannelIdentity struct {
+	Channel  string `json:"channel"`
 	Identity string `json:"identity"`
 }
+
+type WebChatRequest struct {
+	SessionID string `json:"session_id"`
+	MessageID string `json:"message_id"`
+	Text      string `json:"text"`
+}
+
+type WebChatResponse struct {
+	Messages []string `json:"messages"`
+	Format   string   `json:"format"`
+}
+
+type AskRequest struct {
+	Prompt string `json:"prompt"`
+}
+
+type AskResponse struct {
+	Answer    string        `json:"answer"`
+	Citations []APICitation `json:"citations"`
+	Chunks    []APIChunk    `json:"chunks"`
+}
+
+type APICitation struct {
+	ChunkID    string `json:"chunk_id"`
+	URL        string `json:"url"`
+	IsVerified bool   `json:"is_verified"`
+}
+
+type APIChunk struct {
+	ID      string  `json:"id"`
+	Body    string  `json:"body"`
+	URL     string  `json:"url"`
+	Score   float64 `json:"score"`
+	IsCited bool    `json:"is_cited"`
+}
+
+type StatuteResponse struct {
+	Statute string     `json:"statute"`
+	URL     string     `json:"url"`
+	Chunks  []APIChunk `json:"chunks"`
+}
+
+type SearchResponse struct {
+	Query  string     `json:"query"`
+	Chunks []APIChunk `json:"chunks"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
@@ -2,6 +2,7 @@ package vectorizers
 
 import (
 	"code/core"
+	"code/helpers"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,8 +15,16 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 )
 
-const defaultClientTimeout = 20 * time.Second
-const systemPrompt = "You are an expert on Minnesota statutes. Answer the Human's question with references to the statutes. When a subdivision references another subdivision, include the text of the referenced subdivision as well. Reference the statute using standard notation (e.g., § 337.10, subd. 1). At the end of the message, provide the entire relevant subdivision. Be careful to accurately cite the statute without merging the subdivision number into the statute number. Note that some statutes do not have a subdivision and are labeled simply as chapter.section (e.g., § 86B.33). Verify each citation to ensure it is correct and clearly distinguishes between the statute number and the subdivision number.\n"
+// embeddings are quick, answers of up to answerMaxTokens take longer and are bounded by the helper's timeout instead
+const embeddingTimeout = 20 * time.Second
+const (
+	anthropicVersion = "bedrock-2023-05-31"
+	answerMaxTokens  = 1024 // long answers are texted in parts
+	userRole         = "user"
+	assistantRole    = "assistant"
+	textContentType  = "text"
+)
+const systemPrompt = "You are an expert on Minnesota statutes and administrative rules. Answer the user's question with references to the statutes. When a subdivision references another subdivision, include the text of the referenced subdivision as well. Reference the statute using standard notation (e.g., § 337.10, subd. 1), adding the clause when the answer rests on one (e.g., § 609.5311, subd. 3, paragraph (a), clause (2)). At the end of the message, provide the entire relevant subdivision. Be careful to accurately cite the statute without merging the subdivision number into the statute number. Note that some statutes do not have a subdivision and are labeled simply as chapter.section (e.g., § 86B.33). Verify each citation to ensure it is correct and clearly distinguishes between the statute number and the subdivision number. Statutes end with their history; when it matters, mention the year the statute was last amended (e.g., as amended in 2023). Cite the administrative rules by part and subpart (e.g., Minn. R. 7100.0100, subp. 3), never with a § sign.\n"
 
 var emptyVD = core.VectorDocument{}
 
@@ -30,30 +39,55 @@ type Embeddings struct {
 	Embedding []float64 `json:"embedding"`
 }
 
-// Define a custom type for parsing the output
-type ModelResponse struct {
-	Type       string `json:"type"`
-	Completion string `json:"completion"`
-	StopReason string `json:"stop_reason"`
-	Stop       string `json:"stop"`
+// request and response of the Anthropic Messages API on Bedrock
+type messagesRequest struct {
+	AnthropicVersion string    `json:"anthropic_version"`
+	MaxTokens        int       `json:"max_tokens"`
+	System           string    `json:"system,omitempty"`
+	Messages         []message `json:"messages"`
+	Temperature      float64   `json:"temperature"`
+	TopK             int       `json:"top_k"`
+	TopP             float64   `json:"top_p"`
+}
+
+type message struct {
+	Role    string           `json:"role"`
+	Content []messageContent `json:"content"`
+}
+
+type messageContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type messagesResponse struct {
+	Content    []messageContent `json:"content"`
+	StopReason string           `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
 }
 
-func InitializeBedrockHelper(ctx context.Context, embeddingModelID, foundationModelID string, timeout time.Duration) (*BedrockHelper, error) {
+func InitializeBedrockHelper(ctx context.Context, embeddingModelID, foundationModelID string, timeout time.Duration, endpointURL *string) (*BedrockHelper, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	cfg, err := config.LoadDefaultConfig(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error loading default config: %v", err)
 	}
-	customHTTPClient := &http.Client{
-		Timeout: defaultClientTimeout,
-	}
+	// no client-wide timeout, each call is bounded by its context deadline
+	customHTTPClient := &http.Client{}
 	customCfg := aws.Config{
 		Region:      cfg.Region,
 		Credentials: cfg.Credentials,
 		HTTPClient:  customHTTPClient,
 	}
-	client := bedrockruntime.NewFromConfig(customCfg)
+	client := bedrockruntime.NewFromConfig(customCfg, func(o *bedrockruntime.Options) {
+		if endpointURL != nil {
+			o.BaseEndpoint = aws.String(*endpointURL)
+		}
+	})
 
 	return &BedrockHelper{
 		client:            client,
@@ -63,40 +97,40 @@ func InitializeBedrockHelper(ctx context.Context, embeddingModelID, foundationMo
 	}, nil
 }
 
-func (bedrockHelper *BedrockHelper) AskWithChunks(ctx context.Context, prompt string, chunks []core.Chunk) (string, error) {
-	// build augmented prompt
-	var augmentedPrompt strings.Builder
-	augmentedPrompt.WriteString("\n\nSystem: ")
-	augmentedPrompt.WriteString(systemPrompt)
+func (bedrockHelper *BedrockHelper) AskWithChunks(ctx context.Context, prompt string, history []core.ConversationTurn, chunks []core.Chunk) (core.Answer, error) {
+	// the statutes are part of the system prompt, the conversation alternates user and assistant messages
+	var system strings.Builder
+	system.WriteString(systemPrompt)
 	for _, chunk := range chunks {
-		augmentedPrompt.WriteString(chunk.Body)
-		augmentedPrompt.WriteString("\n")
+		system.WriteString(chunk.Body)
+		system.WriteString("\n")
+	}
+	messages := make([]message, 0, 2*len(history)+1)
+	for _, turn := range history {
+		messages = append(messages, newTextMessage(userRole, turn.Prompt), newTextMessage(assistantRole, turn.Answer))
 	}
-	augmentedPrompt.WriteString("\n\nHuman: ")
-	augmentedPrompt.WriteString(prompt)
-	augmentedPrompt.WriteString("\n\nAssistant:\n")
-	thePrompt := augmentedPrompt.String()
+	messages = append(messages, newTextMessage(userRole, prompt))
 
 	// build input body
-	body, err := json.Marshal(map[string]interface{}{
-		"prompt":               thePrompt,
-		"max_tokens_to_sample": 300,
-		"temperature":          0.5,
-		"top_k":                250,
-		"top_p":                1,
-		"stop_sequences":       []string{"\n\nHuman:"},
-		"anthropic_version":    "bedrock-2023-05-31",
+	body, err := json.Marshal(messagesRequest{
+		AnthropicVersion: anthropicVersion,
+		MaxTokens:        answerMaxTokens,
+		System:           system.String(),
+		Messages:         messages,
+		Temperature:      0.5,
+		TopK:             250,
+		TopP:             1,
 	})
 	if err != nil {
-		return "", fmt.Errorf("error on json.Marshal: %v", err)
+		return core.Answer{}, fmt.Errorf("error on json.Marshal: %v", err)
 	}
 
 	// create InvokeModelInput
 	input := &bedrockruntime.InvokeModelInput{
-		ModelId:     aws.String("anthropic.claude-v2"),
+		ModelId:     aws.String(bedrockHelper.foundationModelID),
 		Body:        body,
 		ContentType: aws.String("application/json"),
-		Accept:      aws.String("*/*"),
+		Accept:      aws.String("application/json"),
 	}
 
 	// set context and invoke model
@@ -105,17 +139,36 @@ func (bedrockHelper *BedrockHelper) AskWithChunks(ctx context.Context, prompt st
 
 	output, err := bedrockHelper.client.InvokeModel(ctx, input)
 	if err != nil {
-		return "", fmt.Errorf("error invoking model: %v", err)
+		return core.Answer{}, fmt.Errorf("error invoking model: %v", err)
 	}
 
 	// Parse the response
-	var response ModelResponse
+	var response messagesResponse
 	if err := json.Unmarshal(output.Body, &response); err != nil {
-		return "", fmt.Errorf("error parsing model response: %v", err)
+		return core.Answer{}, fmt.Errorf("error parsing model response: %v", err)
+	}
+	var text strings.Builder
+	for _, content := range response.Content {
+		if content.Type == textContentType {
+			text.WriteString(content.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return core.Answer{}, fmt.Errorf("model response has no text content, stop_reason=%s", response.StopReason)
 	}
 
-	return strings.TrimLeft(response.Completion, " "), nil
+	answerText := strings.TrimSpace(text.String())
+	return core.Answer{
+		Text:         answerText,
+		Citations:    helpers.ParseCitations(answerText),
+		StopReason:   response.StopReason,
+		InputTokens:  response.Usage.InputTokens,
+		OutputTokens: response.Usage.OutputTokens,
+	}, nil
+}
 
+func newTextMessage(role, text string) message {
+	return message{Role: role, Content: []messageContent{{Type: textContentType, Text: text}}}
 }
 
 func (bedrockHelper *BedrockHelper) VectorizeChunk(ctx context.Context, chunk core.Chunk) (core.VectorDocument, error) {
@@ -123,7 +176,7 @@ func (bedrockHelper *BedrockHelper) VectorizeChunk(ctx context.Context, chunk co
 	if err != nil {
 		return emptyVD, fmt.Errorf("error on get embeddings: %v", err)
 	}
-	return core.VectorDocument{ID: chunk.ID, Vector: embeddings}, nil
+	return core.VectorDocument{ID: chunk.ID, Text: chunk.Body, Vector: embeddings}, nil
 }
 
 func (bedrockHelper *BedrockHelper) Vectorize(ctx context.Context, content string) (core.VectorDocument, error) {
@@ -131,11 +184,11 @@ func (bedrockHelper *BedrockHelper) Vectorize(ctx context.Context, content strin
 	if err != nil {
 		return emptyVD, fmt.Errorf("error on get embeddings: %v", err)
 	}
-	return core.VectorDocument{ID: "", Vector: embeddings}, nil
+	return core.VectorDocument{ID: "", Text: content, Vector: embeddings}, nil
 }
 
 func (bedrockHelper *BedrockHelper) getEmbeddings(ctx context.Context, inputText string) ([]float64, error) {
-	ctx, cancel := context.WithTimeout(ctx, bedrockHelper.timeout)
+	ctx, cancel := context.WithTimeout(ctx, min(bedrockHelper.timeout, embeddingTimeout))
 	defer cancel()
 	body, err := json.Marshal(map[string]interface{}{
 		"inputText": inputText,
@@ -37,7 +37,7 @@ func TestVectorizers(t *testing.T) {
 	assert := assert.New(t)
 	mySettings, err := settings.GetSettings()
 	assert.NoError(err, "error on get settings: %v", err)
-	bedrockHelper, err := InitializeBedrockHelper(ctx, mySettings.EmbeddingModelID, mySettings.FoundationModelID, mySettings.ContextTimeout)
+	bedrockHelper, err := InitializeBedrockHelper(ctx, mySettings.EmbeddingModelID, mySettings.FoundationModelID, mySettings.ContextTimeout, mySettings.BedrockEndpoint)
 	assert.NoError(err, "error on initialize bedrock helper: %v", err)
 
 	t.Run("test vectorize chunk", func(t *testing.T) {
@@ -55,8 +55,8 @@ func TestVectorizers(t *testing.T) {
 	})
 
 	t.Run("test AskWithChunks", func(t *testing.T) {
-		answer, err := bedrockHelper.AskWithChunks(ctx, dcPrompt, core.DCChunks)
+		answer, err := bedrockHelper.AskWithChunks(ctx, dcPrompt, nil, core.DCChunks)
 		assert.NoError(err, "found error on ask with chunks: %v", err)
-		assert.Contains(answer, expectedStatute)
+		assert.Contains(answer.Text, expectedStatute)
 	})
 }
@@ -0,0 +1,101 @@
+package vectorizers
+
+import (
+	"code/core"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeEmbeddingModelID  = "amazon.titan-embed-text-v2:0"
+	fakeFoundationModelID = "anthropic.claude-3-haiku-20240307-v1:0"
+	fakeAnswer            = "Register by July 1, see § 115B.49, subd. 4."
+)
+
+// fakeBedrock serves InvokeModel requests like Bedrock, recording the last messages request
+type fakeBedrock struct {
+	lastRequest messagesRequest
+	stopReason  string
+}
+
+func (fake *fakeBedrock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/model/" + fakeEmbeddingModelID + "/invoke":
+		json.NewEncoder(w).Encode(Embeddings{Embedding: []float64{0.6, 0.8}})
+	case "/model/" + fakeFoundationModelID + "/invoke":
+		if err := json.NewDecoder(r.Body).Decode(&fake.lastRequest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","content":[{"type":"text","text":"` + fakeAnswer + `"}],"stop_reason":"` + fake.stopReason + `","usage":{"input_tokens":120,"output_tokens":15}}`))
+	default:
+		http.Error(w, `{"message":"unknown model"}`, http.StatusNotFound)
+	}
+}
+
+func TestBedrockHelper(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	fake := &fakeBedrock{stopReason: core.StopReasonEndTurn}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	endpointURL := server.URL
+	bedrockHelper, err := InitializeBedrockHelper(ctx, fakeEmbeddingModelID, fakeFoundationModelID, time.Minute, &endpointURL)
+	assert.NoError(err, "error on initialize bedrock helper: %v", err)
+
+	t.Run("test AskWithChunks uses the messages api", func(t *testing.T) {
+		history := []core.ConversationTurn{{Prompt: "previous prompt", Answer: "previous answer"}}
+		answer, err := bedrockHelper.AskWithChunks(ctx, "By when should I register?", history, core.DCChunks)
+		assert.NoError(err, "error on ask with chunks: %v", err)
+		assert.Equal(fakeAnswer, answer.Text, "answer text should be the text content")
+		assert.Equal(core.StopReasonEndTurn, answer.StopReason, "stop reason should be surfaced")
+		assert.Equal(120, answer.InputTokens, "input tokens should be surfaced")
+		assert.Equal(15, answer.OutputTokens, "output tokens should be surfaced")
+		if assert.Len(answer.Citations, 1) {
+			assert.Equal("115B.49.4", answer.Citations[0].ChunkID, "citation should be parsed from the answer")
+		}
+
+		request := fake.lastRequest
+		assert.Equal(anthropicVersion, request.AnthropicVersion)
+		assert.True(strings.HasPrefix(request.System, systemPrompt), "system should start with the system prompt")
+		assert.Contains(request.System, core.DCChunk1.Body, "system should contain the chunks")
+		roles := make([]string, 0, len(request.Messages))
+		for _, message := range request.Messages {
+			roles = append(roles, message.Role)
+		}
+		assert.Equal([]string{userRole, assistantRole, userRole}, roles, "history should precede the prompt")
+		assert.Equal("By when should I register?", request.Messages[2].Content[0].Text)
+	})
+
+	t.Run("test AskWithChunks surfaces max tokens", func(t *testing.T) {
+		fake.stopReason = core.StopReasonMaxTokens
+		defer func() { fake.stopReason = core.StopReasonEndTurn }()
+		answer, err := bedrockHelper.AskWithChunks(ctx, "prompt", nil, nil)
+		assert.NoError(err, "error on ask with chunks: %v", err)
+		assert.Equal(core.StopReasonMaxTokens, answer.StopReason)
+	})
+
+	t.Run("test Vectorize", func(t *testing.T) {
+		vd, err := bedrockHelper.Vectorize(ctx, "prompt")
+		assert.NoError(err, "error on vectorize: %v", err)
+		assert.Equal(core.VectorDocument{Text: "prompt", Vector: []float64{0.6, 0.8}}, vd)
+	})
+
+	t.Run("test unknown foundation model fails", func(t *testing.T) {
+		otherHelper, _ := InitializeBedrockHelper(ctx, fakeEmbeddingModelID, "anthropic.unknown", time.Minute, &endpointURL)
+		_, err := otherHelper.AskWithChunks(ctx, "prompt", nil, nil)
+		assert.Error(err, "unknown foundation model should fail")
+	})
+}
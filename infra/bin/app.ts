@@ -20,6 +20,7 @@ function main(app: cdk.App, config: conf.Config) {
 
   const commonProps: stacks.CommonStackProps = {
     answererRole: statefulStack.answererRole,
+    inboundDQ: statefulStack.inboundDQ,
     indexerRole: statefulStack.indexerRole,
     mainBucket: statefulStack.mainBucket,
     opensearchDomain: statefulStack.opensearchDomain,
@@ -25,10 +25,15 @@ export function parseConfig(): Config {
     sinchApiToken: String(config.sinchApiToken),
     sinchServiceId: String(config.sinchServiceId),
     sinchVirtualPhoneNumber: String(config.sinchVirtualPhoneNumber),
+    sinchWebhookSecret: String(config.sinchWebhookSecret),
+    sinchProjectId: config.sinchProjectId ? String(config.sinchProjectId) : undefined,
+    sinchAppId: config.sinchAppId ? String(config.sinchAppId) : undefined,
+    sinchAccessKeyId: config.sinchAccessKeyId ? String(config.sinchAccessKeyId) : undefined,
+    sinchAccessKeySecret: config.sinchAccessKeySecret ? String(config.sinchAccessKeySecret) : undefined,
   };
 }
 function validateConfig(config: any) {
-  const fields = ["azCount", "nonce", "sinchApiToken", "sinchServiceId", "sinchVirtualPhoneNumber"];
+  const fields = ["azCount", "nonce", "sinchApiToken", "sinchServiceId", "sinchVirtualPhoneNumber", "sinchWebhookSecret"];
   const missingFields = [];
   for (var i = 0; i < fields.length; i++) {
     const field = fields[i];
@@ -11,8 +11,13 @@ export const RAW_OBJECT_PREFIX_PATH_WILDCARD = "raw/*";
 
 export const SCRAPER_TIMEOUT_DURATION = cdk.Duration.minutes(3);
 export const INDEXER_TIMEOUT_DURATION = cdk.Duration.minutes(5);
+export const ANSWER_WORKER_TIMEOUT_DURATION = cdk.Duration.minutes(2);
+export const WEB_CHAT_TIMEOUT_DURATION = cdk.Duration.seconds(29); // api gateway integration timeout
 
 export const ANSWERER_CMD = "answerer";
+export const ANSWER_WORKER_CMD = "answer_worker";
+export const WEB_CHAT_CMD = "web_chat";
+export const API_SERVER_CMD = "api_server";
 export const CRAWLER_CMD = "crawler";
 export const TRIGGER_CRAWLER_CMD = "trigger_crawler";
 export const RAW_SCRAPER_CMD = "raw_scraper";
@@ -20,6 +25,9 @@ export const INVOKE_TRIGGER_CRAWLER_CMD = "invoke_trigger_crawler";
 export const INDEXER_CMD = "indexer";
 export const VALID_CMDS = [
   ANSWERER_CMD,
+  ANSWER_WORKER_CMD,
+  WEB_CHAT_CMD,
+  API_SERVER_CMD,
   CRAWLER_CMD,
   TRIGGER_CRAWLER_CMD,
   RAW_SCRAPER_CMD,
@@ -34,6 +42,7 @@ export const TABLE_1_ARN_ENV_NAME = "TABLE_1_ARN";
 export const URL_SQS_ARN_ENV_NAME = "URL_SQS_ARN";
 export const RAW_EVENTS_SQS_ARN_ENV_NAME = "RAW_EVENTS_SQS_ARN";
 export const TO_INDEX_SQS_ARN_ENV_NAME = "TO_INDEX_SQS_ARN";
+export const INBOUND_SQS_ARN_ENV_NAME = "INBOUND_SQS_ARN";
 export const TRIGGER_CRAWLER_TASK_DEFINITION_ARN_ENV_NAME = "TRIGGER_CRAWLER_TASK_DFN_ARN";
 export const TRIGGER_CRAWLER_CLUSTER_ARN_ENV_NAME = "TRIGGER_CRAWLER_CLUSTER_ARN";
 export const SECURITY_GROUP_IDS_ENV_NAME = "SECURITY_GROUP_IDS";
@@ -60,6 +69,11 @@ export const OPENSEARCH_INDEX_NAME_ENV_NAME = "OPENSEARCH_INDEX_NAME";
 export const SINCH_API_TOKEN_ENV_NAME = "SINCH_API_TOKEN";
 export const SINCH_SERVICE_ID_ENV_NAME = "SINCH_SERVICE_ID";
 export const SINCH_VIRTUAL_PHONE_NUMBER_ENV_NAME = "SINCH_VIRTUAL_PHONE_NUMBER";
+export const SINCH_WEBHOOK_SECRET_ENV_NAME = "SINCH_WEBHOOK_SECRET";
+export const SINCH_PROJECT_ID_ENV_NAME = "SINCH_PROJECT_ID";
+export const SINCH_APP_ID_ENV_NAME = "SINCH_APP_ID";
+export const SINCH_ACCESS_KEY_ID_ENV_NAME = "SINCH_ACCESS_KEY_ID";
+export const SINCH_ACCESS_KEY_SECRET_ENV_NAME = "SINCH_ACCESS_KEY_SECRET";
 
 export const TITAN_EMBEDDING_V2_MODEL_ID = "amazon.titan-embed-text-v2:0";
 export const CLAUDE_MODEL_ID = "anthropic.claude-v2";
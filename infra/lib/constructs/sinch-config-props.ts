@@ -2,4 +2,10 @@ export interface SinchConfigProps {
   sinchApiToken: string;
   sinchServiceId: string;
   sinchVirtualPhoneNumber: string;
+  sinchWebhookSecret: string;
+  // conversation api, WhatsApp, Messenger and RCS are only sent over when an app id is set
+  sinchProjectId?: string;
+  sinchAppId?: string;
+  sinchAccessKeyId?: string;
+  sinchAccessKeySecret?: string;
 }
@@ -98,6 +98,7 @@ export interface getEnvironmentProps extends Partial<SinchConfigProps> {
   urlDQ?: DualQueue;
   rawEventsDQ?: DualQueue;
   toIndexDQ?: DualQueue;
+  inboundDQ?: DualQueue;
   // ecs
   triggerCrawlerTaskDefinition?: ecs.TaskDefinition;
   triggerCrawlerCluster?: ecs.Cluster;
@@ -127,6 +128,9 @@ export function getEnvironment(props: getEnvironmentProps): { [key: string]: str
   if (props.toIndexDQ) {
     environment[constants.TO_INDEX_SQS_ARN_ENV_NAME] = props.toIndexDQ.src.queueArn;
   }
+  if (props.inboundDQ) {
+    environment[constants.INBOUND_SQS_ARN_ENV_NAME] = props.inboundDQ.src.queueArn;
+  }
   if (props.triggerCrawlerTaskDefinition) {
     environment[constants.TRIGGER_CRAWLER_TASK_DEFINITION_ARN_ENV_NAME] =
       props.triggerCrawlerTaskDefinition.taskDefinitionArn;
@@ -159,6 +163,21 @@ export function getEnvironment(props: getEnvironmentProps): { [key: string]: str
   if (props.sinchVirtualPhoneNumber) {
     environment[constants.SINCH_VIRTUAL_PHONE_NUMBER_ENV_NAME] = props.sinchVirtualPhoneNumber;
   }
+  if (props.sinchWebhookSecret) {
+    environment[constants.SINCH_WEBHOOK_SECRET_ENV_NAME] = props.sinchWebhookSecret;
+  }
+  if (props.sinchProjectId) {
+    environment[constants.SINCH_PROJECT_ID_ENV_NAME] = props.sinchProjectId;
+  }
+  if (props.sinchAppId) {
+    environment[constants.SINCH_APP_ID_ENV_NAME] = props.sinchAppId;
+  }
+  if (props.sinchAccessKeyId) {
+    environment[constants.SINCH_ACCESS_KEY_ID_ENV_NAME] = props.sinchAccessKeyId;
+  }
+  if (props.sinchAccessKeySecret) {
+    environment[constants.SINCH_ACCESS_KEY_SECRET_ENV_NAME] = props.sinchAccessKeySecret;
+  }
   return environment;
 }
 
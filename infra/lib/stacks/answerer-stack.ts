@@ -1,6 +1,8 @@
 import * as cdk from "aws-cdk-lib";
 import { HttpLambdaIntegration } from "aws-cdk-lib/aws-apigatewayv2-integrations";
+import { HttpIamAuthorizer } from "aws-cdk-lib/aws-apigatewayv2-authorizers";
 import * as apigwv2 from "aws-cdk-lib/aws-apigatewayv2";
+import * as eventsources from "aws-cdk-lib/aws-lambda-event-sources";
 import { CommonStackProps } from "./common-stack-props";
 import { SinchConfigProps } from "../constructs/sinch-config-props";
 import { Construct } from "constructs";
@@ -9,7 +11,10 @@ import * as constants from "../constants";
 import * as helpers from "../helpers";
 
 const ANSWERER_LAMBDA_INTEGRATION_ID = "answerer-lambda-integration";
+const WEB_CHAT_LAMBDA_INTEGRATION_ID = "web-chat-lambda-integration";
+const API_SERVER_LAMBDA_INTEGRATION_ID = "api-server-lambda-integration";
 const API_GATEWAY_ID = "api-gateway";
+const QUERY_API_GATEWAY_ID = "query-api-gateway";
 
 export interface AnswererStackProps extends CommonStackProps, SinchConfigProps {}
 
@@ -17,33 +22,130 @@ export class AnswererStack extends cdk.Stack {
   constructor(scope: Construct, id: string, props: AnswererStackProps) {
     super(scope, id, props);
 
+    // the webhook only verifies and queues messages, so Sinch gets its response right away
     const answererFunction = new ConfiguredFunction(this, constants.ANSWERER_CMD, {
+      environment: helpers.getEnvironment(props),
+      securityGroup: props.securityGroup,
+      vpc: props.vpc,
+      vpcSubnets: props.privateWithEgressSubnets,
+    });
+
+    props.inboundDQ.src.grantSendMessages(answererFunction);
+    answererFunction.addToRolePolicy(helpers.getListPolicy({ queues: true }));
+
+    // the worker answers queued messages
+    const answerWorkerFunction = new ConfiguredFunction(this, constants.ANSWER_WORKER_CMD, {
+      environment: helpers.getEnvironment(props),
+      role: props.answererRole,
+      securityGroup: props.securityGroup,
+      timeout: constants.ANSWER_WORKER_TIMEOUT_DURATION,
+      vpc: props.vpc,
+      vpcSubnets: props.privateWithEgressSubnets,
+    });
+    answerWorkerFunction.addEventSource(new eventsources.SqsEventSource(props.inboundDQ.src));
+
+    props.inboundDQ.src.grantConsumeMessages(answerWorkerFunction);
+    props.mainBucket.grantRead(answerWorkerFunction);
+    props.table1.grantReadWriteData(answerWorkerFunction); // conversations
+    answerWorkerFunction.addToRolePolicy(helpers.getListPolicy({ queues: true, tables: true }));
+    props.opensearchDomain.grantIndexReadWrite(constants.VECTOR_INDEX_NAME, answerWorkerFunction);
+    answerWorkerFunction.addToRolePolicy(
+      helpers.getBedrockInvokePolicy(constants.TITAN_EMBEDDING_V2_MODEL_ID, constants.CLAUDE_MODEL_ID)
+    );
+
+    // the web chat answers while the widget waits, replies are returned in the response
+    const webChatFunction = new ConfiguredFunction(this, constants.WEB_CHAT_CMD, {
+      environment: helpers.getEnvironment(props),
+      role: props.answererRole,
+      securityGroup: props.securityGroup,
+      timeout: constants.WEB_CHAT_TIMEOUT_DURATION,
+      vpc: props.vpc,
+      vpcSubnets: props.privateWithEgressSubnets,
+    });
+
+    props.mainBucket.grantRead(webChatFunction);
+    props.table1.grantReadWriteData(webChatFunction); // conversations
+    webChatFunction.addToRolePolicy(helpers.getListPolicy({ tables: true }));
+    props.opensearchDomain.grantIndexReadWrite(constants.VECTOR_INDEX_NAME, webChatFunction);
+    webChatFunction.addToRolePolicy(
+      helpers.getBedrockInvokePolicy(constants.TITAN_EMBEDDING_V2_MODEL_ID, constants.CLAUDE_MODEL_ID)
+    );
+
+    // the query api serves internal tools, it answers without texting anyone
+    const apiServerFunction = new ConfiguredFunction(this, constants.API_SERVER_CMD, {
       environment: helpers.getEnvironment(props),
       role: props.answererRole,
       securityGroup: props.securityGroup,
+      timeout: constants.WEB_CHAT_TIMEOUT_DURATION,
       vpc: props.vpc,
       vpcSubnets: props.privateWithEgressSubnets,
     });
 
-    props.mainBucket.grantRead(answererFunction);
-    props.opensearchDomain.grantIndexReadWrite(constants.VECTOR_INDEX_NAME, answererFunction);
-    answererFunction.addToRolePolicy(
+    props.mainBucket.grantRead(apiServerFunction);
+    props.table1.grantReadData(apiServerFunction); // reference graph
+    props.opensearchDomain.grantIndexReadWrite(constants.VECTOR_INDEX_NAME, apiServerFunction);
+    apiServerFunction.addToRolePolicy(
       helpers.getBedrockInvokePolicy(constants.TITAN_EMBEDDING_V2_MODEL_ID, constants.CLAUDE_MODEL_ID)
     );
 
     const answererFunctionIntegration = new HttpLambdaIntegration(ANSWERER_LAMBDA_INTEGRATION_ID, answererFunction);
 
-    const httpApi = new apigwv2.HttpApi(this, API_GATEWAY_ID);
+    const webChatFunctionIntegration = new HttpLambdaIntegration(WEB_CHAT_LAMBDA_INTEGRATION_ID, webChatFunction);
+
+    // the web widget is served from another origin
+    const httpApi = new apigwv2.HttpApi(this, API_GATEWAY_ID, {
+      corsPreflight: {
+        allowHeaders: ["Content-Type"],
+        allowMethods: [apigwv2.CorsHttpMethod.POST],
+        allowOrigins: ["*"],
+      },
+    });
     httpApi.addRoutes({
       path: "/api/v1",
       methods: [apigwv2.HttpMethod.POST],
       integration: answererFunctionIntegration,
     });
+    httpApi.addRoutes({
+      path: "/api/v1/chat",
+      methods: [apigwv2.HttpMethod.POST],
+      integration: webChatFunctionIntegration,
+    });
+
+    // only callers with IAM credentials allowed to invoke the query api may use it
+    const apiServerFunctionIntegration = new HttpLambdaIntegration(API_SERVER_LAMBDA_INTEGRATION_ID, apiServerFunction);
+    const queryApi = new apigwv2.HttpApi(this, QUERY_API_GATEWAY_ID, {
+      defaultAuthorizer: new HttpIamAuthorizer(),
+    });
+    queryApi.addRoutes({
+      path: "/v1/ask",
+      methods: [apigwv2.HttpMethod.POST],
+      integration: apiServerFunctionIntegration,
+    });
+    queryApi.addRoutes({
+      path: "/v1/statutes/{statute}",
+      methods: [apigwv2.HttpMethod.GET],
+      integration: apiServerFunctionIntegration,
+    });
+    queryApi.addRoutes({
+      path: "/v1/search",
+      methods: [apigwv2.HttpMethod.GET],
+      integration: apiServerFunctionIntegration,
+    });
 
     // Output the API endpoint to the console
     new cdk.CfnOutput(this, "ApiEndpoint", {
       value: httpApi.apiEndpoint + "/api/v1",
       description: "The API endpoint for the Sinch webhook",
     });
+
+    new cdk.CfnOutput(this, "WebChatEndpoint", {
+      value: httpApi.apiEndpoint + "/api/v1/chat",
+      description: "The API endpoint for the web chat widget",
+    });
+
+    new cdk.CfnOutput(this, "QueryApiEndpoint", {
+      value: queryApi.apiEndpoint + "/v1",
+      description: "The API endpoint for the query api",
+    });
   }
 }
@@ -15,6 +15,7 @@ export interface CommonStackProps extends cdk.StackProps {
   rawEventsDQ: DualQueue;
   urlDQ: DualQueue;
   toIndexDQ: DualQueue;
+  inboundDQ: DualQueue;
   // opensearch
   opensearchDomain: ConfiguredOpensearchDomain;
   // role
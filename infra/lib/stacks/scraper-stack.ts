@@ -27,6 +27,7 @@ export class ScraperStack extends cdk.Stack {
     props.mainBucket.grantRead(scraperFunction, constants.RAW_OBJECT_PREFIX_PATH_WILDCARD);
     props.mainBucket.grantDelete(scraperFunction, constants.RAW_OBJECT_PREFIX_PATH_WILDCARD);
     props.mainBucket.grantPut(scraperFunction, constants.CHUNK_OBJECT_PREFIX_PATH_WILDCARD);
-    scraperFunction.addToRolePolicy(helpers.getListPolicy({ queues: true }));
+    props.table1.grantReadWriteData(scraperFunction); // reference graph, definitions replaced by section
+    scraperFunction.addToRolePolicy(helpers.getListPolicy({ queues: true, tables: true }));
   }
 }
@@ -19,6 +19,7 @@ const RAW_EVENTS_DQ_ID = "raw-events-dq";
 const TABLE1_ID = "table-1";
 const URL_DQ_ID = "url-dq";
 const TO_INDEX_DQ_ID = "chunk-dq";
+const INBOUND_DQ_ID = "inbound-dq";
 const INDEXER_ROLE_ID = "indexer-role";
 const ANSWERER_ROLE_ID = "answerer-role";
 
@@ -35,6 +36,7 @@ export class StatefulStack extends cdk.Stack {
   readonly urlDQ: DualQueue;
   readonly rawEventsDQ: DualQueue;
   readonly toIndexDQ: DualQueue;
+  readonly inboundDQ: DualQueue;
   readonly opensearchDomain: ConfiguredOpensearchDomain;
   readonly indexerRole: LambdaRole;
   readonly answererRole: LambdaRole;
@@ -90,6 +92,16 @@ export class StatefulStack extends cdk.Stack {
         visibilityTimeout: constants.INDEXER_TIMEOUT_DURATION,
       },
     });
+    // FIFO so retried Sinch callbacks are deduplicated on their message ID, and texts from a phone number are answered in order
+    this.inboundDQ = new DualQueue(this, INBOUND_DQ_ID, {
+      src: {
+        fifo: true,
+        visibilityTimeout: constants.ANSWER_WORKER_TIMEOUT_DURATION,
+      },
+      dlq: {
+        fifo: true,
+      },
+    });
 
     // send PutObject events over s3://main-bucket/raw/* to the raw-events queue
     new S3Rule(this, PUT_RAW_EVENTS_TO_RAW_EVENTS_DQ_RULE_ID, {